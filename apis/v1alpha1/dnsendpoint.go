@@ -31,6 +31,7 @@ import (
 // +groupName=externaldns.k8s.io
 // +kubebuilder:resource:path=dnsendpoints
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:metadata:annotations="api-approved.kubernetes.io=https://github.com/kubernetes-sigs/external-dns/pull/2007"
 // +versionName=v1alpha1
 type DNSEndpoint struct {
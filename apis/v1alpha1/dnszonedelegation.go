@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSZoneDelegation grants namespaces the right to publish DNSEndpoints within a DNS subtree.
+// When zone delegation is enabled on the crd source, DNSEndpoints are only published
+// if they fall within a delegation of their namespace.
+// +k8s:openapi-gen=true
+// +groupName=externaldns.k8s.io
+// +kubebuilder:resource:path=dnszonedelegations,scope=Cluster
+// +kubebuilder:metadata:annotations="api-approved.kubernetes.io=https://github.com/kubernetes-sigs/external-dns/pull/2007"
+// +versionName=v1alpha1
+type DNSZoneDelegation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DNSZoneDelegationSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// DNSZoneDelegationList is a list of DNSZoneDelegation objects
+type DNSZoneDelegationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSZoneDelegation `json:"items"`
+}
+
+// DNSZoneDelegationSpec defines the namespaces, domains and quotas of a DNSZoneDelegation
+type DNSZoneDelegationSpec struct {
+	// The namespaces allowed to publish DNSEndpoints within the delegated domains.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+	// The delegated domains. A domain delegates itself and all of its subdomains,
+	// while a domain prefixed with "*." only delegates its subdomains.
+	// +kubebuilder:validation:MinItems=1
+	Domains []string `json:"domains"`
+	// The record types allowed within the delegated domains. All record types are allowed if empty.
+	// +optional
+	RecordTypes []string `json:"recordTypes,omitempty"`
+	// The maximum number of endpoints the namespaces may publish within the delegated domains.
+	// The number of endpoints is not limited if zero.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxEndpoints int `json:"maxEndpoints,omitempty"`
+}
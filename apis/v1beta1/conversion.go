@@ -0,0 +1,226 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// EndpointLabelsAnnotation preserves the labels of the v1alpha1 endpoints,
+// which have no equivalent in v1beta1, across conversions.
+const EndpointLabelsAnnotation = "externaldns.k8s.io/v1alpha1-endpoint-labels"
+
+// provider-specific properties of the v1alpha1 endpoints converted to routing policies
+const (
+	weightProperty                     = "aws/weight"
+	regionProperty                     = "aws/region"
+	failoverProperty                   = "aws/failover"
+	geolocationContinentCodeProperty   = "aws/geolocation-continent-code"
+	geolocationCountryCodeProperty     = "aws/geolocation-country-code"
+	geolocationSubdivisionCodeProperty = "aws/geolocation-subdivision-code"
+	multiValueAnswerProperty           = "aws/multi-value-answer"
+	healthCheckIDProperty              = "aws/health-check-id"
+)
+
+// ConvertTo converts this DNSEndpoint to the v1alpha1 hub version.
+func (in *DNSEndpoint) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.DNSEndpoint)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of DNSEndpoint to %T", dstRaw)
+	}
+
+	var labels []endpoint.Labels
+	if data, ok := in.Annotations[EndpointLabelsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &labels); err != nil {
+			return fmt.Errorf("invalid annotation %s: %w", EndpointLabelsAnnotation, err)
+		}
+	}
+
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	delete(dst.Annotations, EndpointLabelsAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	dst.Status = v1alpha1.DNSEndpointStatus{ObservedGeneration: in.Status.ObservedGeneration}
+	dst.Spec.Endpoints = nil
+	for i, ep := range in.Spec.Endpoints {
+		converted := ep.toV1alpha1()
+		if i < len(labels) && len(labels[i]) > 0 {
+			converted.Labels = labels[i]
+		}
+		dst.Spec.Endpoints = append(dst.Spec.Endpoints, converted)
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub version to this DNSEndpoint.
+func (in *DNSEndpoint) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.DNSEndpoint)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of DNSEndpoint from %T", srcRaw)
+	}
+
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Status = DNSEndpointStatus{ObservedGeneration: src.Status.ObservedGeneration}
+	in.Spec.Endpoints = nil
+
+	labels := make([]endpoint.Labels, 0, len(src.Spec.Endpoints))
+	hasLabels := false
+	for _, ep := range src.Spec.Endpoints {
+		if ep == nil {
+			continue
+		}
+		in.Spec.Endpoints = append(in.Spec.Endpoints, endpointFromV1alpha1(ep))
+		labels = append(labels, ep.Labels)
+		hasLabels = hasLabels || len(ep.Labels) > 0
+	}
+
+	if hasLabels {
+		data, err := json.Marshal(labels)
+		if err != nil {
+			return err
+		}
+		in.Annotations = maps.Clone(in.Annotations)
+		if in.Annotations == nil {
+			in.Annotations = map[string]string{}
+		}
+		in.Annotations[EndpointLabelsAnnotation] = string(data)
+	}
+	return nil
+}
+
+func (ep *Endpoint) toV1alpha1() *endpoint.Endpoint {
+	out := &endpoint.Endpoint{
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		Targets:       append(endpoint.Targets(nil), ep.Targets...),
+		SetIdentifier: ep.SetIdentifier,
+	}
+	if ep.TTL != nil {
+		out.RecordTTL = endpoint.TTL(ep.TTL.Duration / time.Second)
+	}
+	if rp := ep.RoutingPolicy; rp != nil {
+		if rp.Weight != nil {
+			out.WithProviderSpecific(weightProperty, strconv.FormatInt(*rp.Weight, 10))
+		}
+		if rp.Region != "" {
+			out.WithProviderSpecific(regionProperty, rp.Region)
+		}
+		if rp.Failover != "" {
+			out.WithProviderSpecific(failoverProperty, rp.Failover)
+		}
+		if geo := rp.GeoLocation; geo != nil {
+			if geo.ContinentCode != "" {
+				out.WithProviderSpecific(geolocationContinentCodeProperty, geo.ContinentCode)
+			}
+			if geo.CountryCode != "" {
+				out.WithProviderSpecific(geolocationCountryCodeProperty, geo.CountryCode)
+			}
+			if geo.SubdivisionCode != "" {
+				out.WithProviderSpecific(geolocationSubdivisionCodeProperty, geo.SubdivisionCode)
+			}
+		}
+		if rp.MultiValueAnswer {
+			out.WithProviderSpecific(multiValueAnswerProperty, "")
+		}
+		if rp.HealthCheckID != "" {
+			out.WithProviderSpecific(healthCheckIDProperty, rp.HealthCheckID)
+		}
+	}
+	for _, p := range ep.ProviderSpecific {
+		out.ProviderSpecific = append(out.ProviderSpecific, endpoint.ProviderSpecificProperty{Name: p.Name, Value: p.Value})
+	}
+	return out
+}
+
+func endpointFromV1alpha1(ep *endpoint.Endpoint) Endpoint {
+	out := Endpoint{
+		DNSName:       ep.DNSName,
+		RecordType:    ep.RecordType,
+		Targets:       append([]string(nil), ep.Targets...),
+		SetIdentifier: ep.SetIdentifier,
+	}
+	if ep.RecordTTL.IsConfigured() {
+		out.TTL = &metav1.Duration{Duration: time.Duration(ep.RecordTTL) * time.Second}
+	}
+
+	routingPolicy := &RoutingPolicy{}
+	geoLocation := &GeoLocation{}
+	for _, p := range ep.ProviderSpecific {
+		switch p.Name {
+		case weightProperty:
+			if weight, err := strconv.ParseInt(p.Value, 10, 64); err == nil {
+				routingPolicy.Weight = &weight
+				continue
+			}
+		case regionProperty:
+			if p.Value != "" {
+				routingPolicy.Region = p.Value
+				continue
+			}
+		case failoverProperty:
+			if p.Value == "PRIMARY" || p.Value == "SECONDARY" {
+				routingPolicy.Failover = p.Value
+				continue
+			}
+		case geolocationContinentCodeProperty:
+			if p.Value != "" {
+				geoLocation.ContinentCode = p.Value
+				continue
+			}
+		case geolocationCountryCodeProperty:
+			if p.Value != "" {
+				geoLocation.CountryCode = p.Value
+				continue
+			}
+		case geolocationSubdivisionCodeProperty:
+			if p.Value != "" {
+				geoLocation.SubdivisionCode = p.Value
+				continue
+			}
+		case multiValueAnswerProperty:
+			if p.Value == "" {
+				routingPolicy.MultiValueAnswer = true
+				continue
+			}
+		case healthCheckIDProperty:
+			if p.Value != "" {
+				routingPolicy.HealthCheckID = p.Value
+				continue
+			}
+		}
+		// properties without a structured field, or with a value the field cannot hold, such as an empty one, are kept as is
+		out.ProviderSpecific = append(out.ProviderSpecific, ProviderSpecificProperty{Name: p.Name, Value: p.Value})
+	}
+	if *geoLocation != (GeoLocation{}) {
+		routingPolicy.GeoLocation = geoLocation
+	}
+	if *routingPolicy != (RoutingPolicy{}) {
+		out.RoutingPolicy = routingPolicy
+	}
+	return out
+}
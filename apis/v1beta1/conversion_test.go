@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestConvertFromV1alpha1(t *testing.T) {
+	src := &v1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+		Spec: v1alpha1.DNSEndpointSpec{Endpoints: []*endpoint.Endpoint{
+			{
+				DNSName:       "www.example.com",
+				RecordType:    endpoint.RecordTypeA,
+				Targets:       endpoint.Targets{"1.2.3.4"},
+				RecordTTL:     300,
+				SetIdentifier: "eu",
+				Labels:        endpoint.Labels{"team": "a"},
+				ProviderSpecific: endpoint.ProviderSpecific{
+					{Name: "aws/weight", Value: "10"},
+					{Name: "aws/geolocation-country-code", Value: "DE"},
+					{Name: "aws/multi-value-answer"},
+					{Name: "aws/failover", Value: "invalid"},
+					{Name: "cloudflare-proxied", Value: "true"},
+				},
+			},
+			nil,
+			{DNSName: "example.com", RecordType: endpoint.RecordTypeTXT, Targets: endpoint.Targets{"hello"}},
+		}},
+		Status: v1alpha1.DNSEndpointStatus{ObservedGeneration: 1},
+	}
+
+	dst := &DNSEndpoint{}
+	require.NoError(t, dst.ConvertFrom(src))
+
+	weight := int64(10)
+	assert.Equal(t, DNSEndpointSpec{Endpoints: []Endpoint{
+		{
+			DNSName:       "www.example.com",
+			RecordType:    endpoint.RecordTypeA,
+			Targets:       []string{"1.2.3.4"},
+			TTL:           &metav1.Duration{Duration: 5 * time.Minute},
+			SetIdentifier: "eu",
+			RoutingPolicy: &RoutingPolicy{
+				Weight:           &weight,
+				GeoLocation:      &GeoLocation{CountryCode: "DE"},
+				MultiValueAnswer: true,
+			},
+			ProviderSpecific: []ProviderSpecificProperty{
+				{Name: "aws/failover", Value: "invalid"},
+				{Name: "cloudflare-proxied", Value: "true"},
+			},
+		},
+		{DNSName: "example.com", RecordType: endpoint.RecordTypeTXT, Targets: []string{"hello"}},
+	}}, dst.Spec)
+	assert.Equal(t, `[{"team":"a"},null]`, dst.Annotations[EndpointLabelsAnnotation])
+	assert.Equal(t, int64(1), dst.Status.ObservedGeneration)
+	assert.Nil(t, src.Annotations, "source must not be modified")
+
+	roundTrip := &v1alpha1.DNSEndpoint{}
+	require.NoError(t, dst.ConvertTo(roundTrip))
+	assert.Equal(t, src.ObjectMeta, roundTrip.ObjectMeta)
+	assert.Equal(t, src.Status, roundTrip.Status)
+	require.Len(t, roundTrip.Spec.Endpoints, 2)
+	assert.Equal(t, src.Spec.Endpoints[2], roundTrip.Spec.Endpoints[1])
+	ep := roundTrip.Spec.Endpoints[0]
+	assert.Equal(t, endpoint.TTL(300), ep.RecordTTL)
+	assert.Equal(t, src.Spec.Endpoints[0].Labels, ep.Labels)
+	assert.ElementsMatch(t, src.Spec.Endpoints[0].ProviderSpecific, ep.ProviderSpecific)
+}
+
+func TestConvertFromV1alpha1EmptyValues(t *testing.T) {
+	providerSpecific := endpoint.ProviderSpecific{
+		{Name: "aws/region"},
+		{Name: "aws/geolocation-continent-code"},
+		{Name: "aws/health-check-id"},
+		{Name: "aws/multi-value-answer", Value: "true"},
+	}
+	src := &v1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.DNSEndpointSpec{Endpoints: []*endpoint.Endpoint{
+			{DNSName: "www.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, ProviderSpecific: providerSpecific},
+		}},
+	}
+
+	dst := &DNSEndpoint{}
+	require.NoError(t, dst.ConvertFrom(src))
+	require.Len(t, dst.Spec.Endpoints, 1)
+	assert.Nil(t, dst.Spec.Endpoints[0].RoutingPolicy)
+	assert.Len(t, dst.Spec.Endpoints[0].ProviderSpecific, len(providerSpecific))
+
+	roundTrip := &v1alpha1.DNSEndpoint{}
+	require.NoError(t, dst.ConvertTo(roundTrip))
+	require.Len(t, roundTrip.Spec.Endpoints, 1)
+	assert.Equal(t, providerSpecific, roundTrip.Spec.Endpoints[0].ProviderSpecific)
+}
+
+func TestConvertToV1alpha1(t *testing.T) {
+	weight := int64(5)
+	src := &DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: map[string]string{"a": "b"}},
+		Spec: DNSEndpointSpec{Endpoints: []Endpoint{{
+			DNSName:       "www.example.com",
+			RecordType:    endpoint.RecordTypeCNAME,
+			Targets:       []string{"lb.example.com"},
+			TTL:           &metav1.Duration{Duration: 90 * time.Second},
+			SetIdentifier: "primary",
+			RoutingPolicy: &RoutingPolicy{
+				Weight:        &weight,
+				Region:        "eu-west-1",
+				Failover:      "PRIMARY",
+				GeoLocation:   &GeoLocation{ContinentCode: "EU", SubdivisionCode: "BY"},
+				HealthCheckID: "abc",
+			},
+		}}},
+	}
+
+	dst := &v1alpha1.DNSEndpoint{}
+	require.NoError(t, src.ConvertTo(dst))
+	assert.Equal(t, map[string]string{"a": "b"}, dst.Annotations)
+	assert.Equal(t, []*endpoint.Endpoint{{
+		DNSName:       "www.example.com",
+		RecordType:    endpoint.RecordTypeCNAME,
+		Targets:       endpoint.Targets{"lb.example.com"},
+		RecordTTL:     90,
+		SetIdentifier: "primary",
+		ProviderSpecific: endpoint.ProviderSpecific{
+			{Name: "aws/weight", Value: "5"},
+			{Name: "aws/region", Value: "eu-west-1"},
+			{Name: "aws/failover", Value: "PRIMARY"},
+			{Name: "aws/geolocation-continent-code", Value: "EU"},
+			{Name: "aws/geolocation-subdivision-code", Value: "BY"},
+			{Name: "aws/health-check-id", Value: "abc"},
+		},
+	}}, dst.Spec.Endpoints)
+
+	roundTrip := &DNSEndpoint{}
+	require.NoError(t, roundTrip.ConvertFrom(dst))
+	assert.Equal(t, src, roundTrip)
+}
+
+func TestConvertToV1alpha1InvalidLabels(t *testing.T) {
+	src := &DNSEndpoint{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{EndpointLabelsAnnotation: "{"}}}
+	require.ErrorContains(t, src.ConvertTo(&v1alpha1.DNSEndpoint{}), "invalid annotation "+EndpointLabelsAnnotation)
+}
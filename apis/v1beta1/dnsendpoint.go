@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSEndpoint is a contract that a user-specified CRD must implement to be used as a source for external-dns.
+// The user-specified CRD should also have the status sub-resource.
+// +k8s:openapi-gen=true
+// +groupName=externaldns.k8s.io
+// +kubebuilder:resource:path=dnsendpoints
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+// +kubebuilder:metadata:annotations="api-approved.kubernetes.io=https://github.com/kubernetes-sigs/external-dns/pull/2007"
+// +versionName=v1beta1
+type DNSEndpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSEndpointSpec   `json:"spec,omitempty"`
+	Status DNSEndpointStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// DNSEndpointList is a list of DNSEndpoint objects
+type DNSEndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSEndpoint `json:"items"`
+}
+
+// DNSEndpointSpec defines the desired state of DNSEndpoint
+type DNSEndpointSpec struct {
+	// +optional
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+}
+
+// Endpoint is a DNS record managed by external-dns
+type Endpoint struct {
+	// The hostname of the DNS record
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	DNSName string `json:"dnsName"`
+	// The type of the DNS record
+	// +kubebuilder:validation:Enum=A;AAAA;CNAME;MX;NAPTR;NS;PTR;SRV;TXT
+	RecordType string `json:"recordType"`
+	// The targets the DNS record points to
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// The TTL of the DNS record, e.g. "5m". The default TTL of the provider is used if unset.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Identifier to distinguish multiple records with the same name and type, e.g. records with a routing policy
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// The routing policy of the DNS record
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// Configuration specific to the DNS provider, not covered by the other fields
+	// +optional
+	ProviderSpecific []ProviderSpecificProperty `json:"providerSpecific,omitempty"`
+}
+
+// RoutingPolicy defines how the DNS provider answers queries for records sharing a name and type.
+// Routing policies are currently supported by the AWS provider.
+type RoutingPolicy struct {
+	// The relative weight of the record for weighted routing
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	Weight *int64 `json:"weight,omitempty"`
+	// The region of the record for latency-based routing
+	// +optional
+	Region string `json:"region,omitempty"`
+	// The failover role of the record for failover routing
+	// +kubebuilder:validation:Enum=PRIMARY;SECONDARY
+	// +optional
+	Failover string `json:"failover,omitempty"`
+	// The location of the record for geolocation routing
+	// +optional
+	GeoLocation *GeoLocation `json:"geoLocation,omitempty"`
+	// Whether the record is part of a multivalue answer
+	// +optional
+	MultiValueAnswer bool `json:"multiValueAnswer,omitempty"`
+	// The ID of the health check of the record
+	// +optional
+	HealthCheckID string `json:"healthCheckID,omitempty"`
+}
+
+// GeoLocation identifies a location for geolocation routing
+type GeoLocation struct {
+	// +optional
+	ContinentCode string `json:"continentCode,omitempty"`
+	// +optional
+	CountryCode string `json:"countryCode,omitempty"`
+	// +optional
+	SubdivisionCode string `json:"subdivisionCode,omitempty"`
+}
+
+// ProviderSpecificProperty holds the name and value of a configuration which is specific to individual DNS providers
+type ProviderSpecificProperty struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// DNSEndpointStatus defines the observed state of DNSEndpoint
+type DNSEndpointStatus struct {
+	// The generation observed by the external-dns controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -191,30 +193,52 @@ type Controller struct {
 	ExcludeRecordTypes []string
 	// MinEventSyncInterval is used as a window for batching events
 	MinEventSyncInterval time.Duration
+	// IncrementalReconcile limits reconciliations to the DNS names affected by the changes reported by the Source
+	IncrementalReconcile bool
+	// FullResyncInterval is the maximum interval between two full reconciliations when IncrementalReconcile is enabled
+	FullResyncInterval time.Duration
+	// lastFullSyncAt is the time of the last successful full reconciliation
+	lastFullSyncAt time.Time
+	// lastDesired holds the desired records of the last successful reconciliation, indexed by DNS name
+	lastDesired map[string][]string
+	// lastStatuses holds the status of the desired endpoints of the last reconciliation reaching the provider,
+	// indexed by endpointStatusKey
+	lastStatuses map[string]source.EndpointStatus
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
-func (c *Controller) RunOnce(ctx context.Context) error {
+func (c *Controller) RunOnce(ctx context.Context) (err error) {
 	lastReconcileTimestamp.Gauge.SetToCurrentTime()
 
+	now := time.Now()
 	c.runAtMutex.Lock()
-	c.lastRunAt = time.Now()
+	c.lastRunAt = now
 	c.runAtMutex.Unlock()
 
-	regMetrics := newMetricsRecorder()
-
-	regRecords, err := c.Registry.Records(ctx)
-	if err != nil {
-		registryErrorsTotal.Counter.Inc()
-		deprecatedRegistryErrors.Counter.Inc()
-		return err
+	changedKeys, incremental := c.sourceChanges(now)
+	if incremental && len(changedKeys) == 0 {
+		controllerNoChangesTotal.Counter.Inc()
+		log.Debug("No source changes since the last reconciliation, skipping")
+		lastSyncTimestamp.Gauge.SetToCurrentTime()
+		return nil
 	}
+	defer func() {
+		// changes reported by the source are consumed, so the next reconciliation must be a full one
+		if err != nil {
+			c.lastDesired = nil
+		}
+	}()
 
-	registryEndpointsTotal.Gauge.Set(float64(len(regRecords)))
-
-	countAddressRecords(regMetrics, regRecords, registryRecords)
-
-	ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+	// incremental reconciliations list the current records only once the desired records changed,
+	// so that the changed objects which do not change them do not reach the provider
+	var regRecords []*endpoint.Endpoint
+	if !incremental {
+		regRecords, err = c.listRecords(ctx)
+		if err != nil {
+			return err
+		}
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+	}
 
 	sourceEndpoints, err := c.Source.Endpoints(ctx)
 	if err != nil {
@@ -228,13 +252,33 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	sourceMetrics := newMetricsRecorder()
 	countAddressRecords(sourceMetrics, sourceEndpoints, sourceRecords)
 
-	vaMetrics := newMetricsRecorder()
-	countMatchingAddressRecords(vaMetrics, sourceEndpoints, regRecords, verifiedRecords)
-
 	endpoints, err := c.Registry.AdjustEndpoints(sourceEndpoints)
 	if err != nil {
 		return fmt.Errorf("adjusting endpoints: %w", err)
 	}
+
+	desired := desiredRecords(endpoints)
+	affectedNames := changedDNSNames(c.lastDesired, desired)
+	if incremental {
+		if len(affectedNames) == 0 {
+			c.lastDesired = desired
+			// the changed objects may still have new generations or endpoints to report
+			c.reportUnchangedStatus(ctx, endpoints)
+			controllerNoChangesTotal.Counter.Inc()
+			log.Info("All records are already up to date")
+			lastSyncTimestamp.Gauge.SetToCurrentTime()
+			return nil
+		}
+		regRecords, err = c.listRecords(ctx)
+		if err != nil {
+			return err
+		}
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, regRecords)
+	}
+
+	vaMetrics := newMetricsRecorder()
+	countMatchingAddressRecords(vaMetrics, sourceEndpoints, regRecords, verifiedRecords)
+
 	registryFilter := c.Registry.GetDomainFilter()
 
 	plan := &plan.Plan{
@@ -247,25 +291,192 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 		OwnerID:        c.Registry.OwnerID(),
 	}
 
-	plan = plan.Calculate()
+	if incremental {
+		plan.AffectedNames = affectedNames
+		log.Debugf("Reconciling %d DNS names affected by %d changed objects", len(affectedNames), len(changedKeys))
+	}
+
+	calculated := plan.Calculate()
 
-	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
+	if calculated.Changes.HasChanges() {
+		err = c.Registry.ApplyChanges(ctx, calculated.Changes)
 		if err != nil {
 			registryErrorsTotal.Counter.Inc()
 			deprecatedRegistryErrors.Counter.Inc()
+			c.reportStatus(ctx, plan, calculated.Changes, err)
 			return err
 		}
 	} else {
 		controllerNoChangesTotal.Counter.Inc()
 		log.Info("All records are already up to date")
 	}
+	c.reportStatus(ctx, plan, calculated.Changes, nil)
 
+	c.lastDesired = desired
+	if !incremental {
+		c.lastFullSyncAt = now
+	}
 	lastSyncTimestamp.Gauge.SetToCurrentTime()
 
 	return nil
 }
 
+// listRecords returns the current records of the registry.
+func (c *Controller) listRecords(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	regMetrics := newMetricsRecorder()
+
+	regRecords, err := c.Registry.Records(ctx)
+	if err != nil {
+		registryErrorsTotal.Counter.Inc()
+		deprecatedRegistryErrors.Counter.Inc()
+		return nil, err
+	}
+
+	registryEndpointsTotal.Gauge.Set(float64(len(regRecords)))
+
+	countAddressRecords(regMetrics, regRecords, registryRecords)
+	return regRecords, nil
+}
+
+// sourceChanges returns the keys of the objects changed since the previous reconciliation
+// and whether the reconciliation can be limited to them.
+func (c *Controller) sourceChanges(now time.Time) ([]string, bool) {
+	if !c.IncrementalReconcile {
+		return nil, false
+	}
+	tracker, ok := c.Source.(source.ChangeTracker)
+	if !ok {
+		return nil, false
+	}
+	// always consume the tracked changes, even if a full reconciliation is due
+	keys, tracked := tracker.ChangedKeys()
+	if !tracked || c.lastDesired == nil || now.Sub(c.lastFullSyncAt) >= c.FullResyncInterval {
+		return nil, false
+	}
+	return keys, true
+}
+
+// desiredRecords indexes the desired endpoints by DNS name, independently of the order of endpoints and targets.
+func desiredRecords(endpoints []*endpoint.Endpoint) map[string][]string {
+	records := map[string][]string{}
+	for _, ep := range endpoints {
+		targets := slices.Sorted(slices.Values(ep.Targets))
+		records[ep.DNSName] = append(records[ep.DNSName], fmt.Sprintf("%d %s %s %v %v", ep.RecordTTL, ep.RecordType, ep.SetIdentifier, targets, ep.ProviderSpecific))
+	}
+	for _, r := range records {
+		slices.Sort(r)
+	}
+	return records
+}
+
+// changedDNSNames returns the DNS names whose desired records differ between two reconciliations.
+func changedDNSNames(previous, current map[string][]string) []string {
+	var names []string
+	for name, records := range current {
+		if !slices.Equal(previous[name], records) {
+			names = append(names, name)
+		}
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// reportStatus publishes the outcome of the reconciliation of the desired endpoints of the plan
+// to the source, if it supports it.
+func (c *Controller) reportStatus(ctx context.Context, p *plan.Plan, changes *plan.Changes, applyErr error) {
+	reporter, ok := c.Source.(source.StatusReporter)
+	if !ok {
+		return
+	}
+	statuses := endpointStatuses(p, changes, applyErr)
+	c.lastStatuses = map[string]source.EndpointStatus{}
+	for _, resourceStatuses := range statuses {
+		for _, status := range resourceStatuses {
+			c.lastStatuses[endpointStatusKey(status.Endpoint)] = status
+		}
+	}
+	reporter.ReportStatus(ctx, statuses)
+}
+
+// reportUnchangedStatus publishes the status of the desired endpoints when their records did not change
+// since the last reconciliation reaching the provider, so that their outcome is the same as then.
+func (c *Controller) reportUnchangedStatus(ctx context.Context, endpoints []*endpoint.Endpoint) {
+	reporter, ok := c.Source.(source.StatusReporter)
+	if !ok {
+		return
+	}
+	statuses := map[string][]source.EndpointStatus{}
+	for _, ep := range endpoints {
+		resource := ep.Labels[endpoint.ResourceLabelKey]
+		if resource == "" {
+			continue
+		}
+		status := source.EndpointStatus{Endpoint: ep, Reason: source.StatusReasonProgrammed}
+		if last, ok := c.lastStatuses[endpointStatusKey(ep)]; ok {
+			status.Reason = last.Reason
+			status.Message = last.Message
+		}
+		statuses[resource] = append(statuses[resource], status)
+	}
+	reporter.ReportStatus(ctx, statuses)
+}
+
+// endpointStatusKey identifies the records of an endpoint independently of the resource it comes from.
+func endpointStatusKey(ep *endpoint.Endpoint) string {
+	return fmt.Sprintf("%s %s %s", statusKey(ep.DNSName), ep.RecordType, ep.SetIdentifier)
+}
+
+// endpointStatuses returns the status of each desired endpoint of the plan, indexed by resource label.
+func endpointStatuses(p *plan.Plan, changes *plan.Changes, applyErr error) map[string][]source.EndpointStatus {
+	foreignOwners := map[string]string{}
+	for _, ep := range p.Current {
+		if p.OwnerID != "" && !ep.IsOwnedBy(p.OwnerID) {
+			foreignOwners[statusKey(ep.DNSName)] = ep.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	changed := map[string]struct{}{}
+	for _, eps := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.Delete} {
+		for _, ep := range eps {
+			changed[statusKey(ep.DNSName)] = struct{}{}
+		}
+	}
+
+	statuses := map[string][]source.EndpointStatus{}
+	for _, ep := range p.Desired {
+		resource := ep.Labels[endpoint.ResourceLabelKey]
+		if resource == "" {
+			continue
+		}
+		key := statusKey(ep.DNSName)
+		status := source.EndpointStatus{Endpoint: ep, Reason: source.StatusReasonProgrammed}
+		if owner, ok := foreignOwners[key]; ok {
+			status.Reason = source.StatusReasonConflicted
+			status.Message = fmt.Sprintf("DNS name %s is owned by %q", ep.DNSName, owner)
+		} else if p.DomainFilter != nil && !p.DomainFilter.Match(ep.DNSName) {
+			status.Reason = source.StatusReasonFiltered
+			status.Message = fmt.Sprintf("DNS name %s does not match the domain filter", ep.DNSName)
+		} else if !plan.IsManagedRecord(ep.RecordType, p.ManagedRecords, p.ExcludeRecords) {
+			status.Reason = source.StatusReasonFiltered
+			status.Message = fmt.Sprintf("record type %s of %s is not managed", ep.RecordType, ep.DNSName)
+		} else if _, ok := changed[key]; ok && applyErr != nil {
+			status.Reason = source.StatusReasonFailed
+			status.Message = fmt.Sprintf("failed to apply changes to %s: %v", ep.DNSName, applyErr)
+		}
+		statuses[resource] = append(statuses[resource], status)
+	}
+	return statuses
+}
+
+// statusKey returns the DNS name in a form comparable between desired and current records.
+func statusKey(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
 func earliest(r time.Time, times ...time.Time) time.Time {
 	for _, t := range times {
 		if t.Before(r) {
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -553,3 +554,289 @@ func TestToggleRegistry(t *testing.T) {
 	r.failCountMu.Unlock()
 	assert.Equal(t, toggleRegistryFailureCount, finalCount, "failCount should be at least %d", toggleRegistryFailureCount)
 }
+
+// trackingSource returns fixed endpoints and reports preset changed keys.
+type trackingSource struct {
+	endpoints []*endpoint.Endpoint
+	keys      []string
+	tracked   bool
+}
+
+func (s *trackingSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return s.endpoints, nil
+}
+
+func (s *trackingSource) AddEventHandler(_ context.Context, _ func()) {}
+
+func (s *trackingSource) ChangedKeys() ([]string, bool) {
+	keys := s.keys
+	s.keys = nil
+	return keys, s.tracked
+}
+
+func TestIncrementalReconcile(t *testing.T) {
+	src := &trackingSource{
+		endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "5.6.7.8"),
+		},
+	}
+	provider := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:               src,
+		Registry:             r,
+		Policy:               &plan.SyncPolicy{},
+		ManagedRecordTypes:   []string{endpoint.RecordTypeA},
+		IncrementalReconcile: true,
+		FullResyncInterval:   time.Hour,
+	}
+
+	// changes are unknown, so the first run is a full reconciliation
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 1, provider.RecordsCallCount)
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	assert.Len(t, provider.ApplyChangesCalls[0].Create, 2)
+
+	// nothing changed since, so the provider is not queried at all
+	src.tracked = true
+	provider.RecordsStore = src.endpoints
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 1, provider.RecordsCallCount)
+	assert.Len(t, provider.ApplyChangesCalls, 1)
+
+	// objects changed without changing the desired records, so the provider is not queried either
+	src.keys = []string{"ingress/default/unrelated"}
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 1, provider.RecordsCallCount)
+	assert.Len(t, provider.ApplyChangesCalls, 1)
+
+	// only the DNS name affected by the change is reconciled, drift on other names is left to the full resync
+	provider.RecordsStore = []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "9.9.9.9"),
+	}
+	src.endpoints = []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "4.3.2.1"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "5.6.7.8"),
+	}
+	src.keys = []string{"ingress/default/a"}
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 2, provider.RecordsCallCount)
+	require.Len(t, provider.ApplyChangesCalls, 2)
+	require.Len(t, provider.ApplyChangesCalls[1].UpdateNew, 1)
+	assert.Equal(t, "a.example.org", provider.ApplyChangesCalls[1].UpdateNew[0].DNSName)
+
+	// a due full resync reconciles every DNS name
+	ctrl.lastFullSyncAt = time.Now().Add(-2 * time.Hour)
+	provider.RecordsStore[0] = endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "4.3.2.1")
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 3, provider.RecordsCallCount)
+	require.Len(t, provider.ApplyChangesCalls, 3)
+	require.Len(t, provider.ApplyChangesCalls[2].UpdateNew, 1)
+	assert.Equal(t, "b.example.org", provider.ApplyChangesCalls[2].UpdateNew[0].DNSName)
+}
+
+// recordsContextSource records whether the current records were listed before its endpoints.
+type recordsContextSource struct {
+	trackingSource
+	listedFirst []bool
+}
+
+func (s *recordsContextSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	s.listedFirst = append(s.listedFirst, ctx.Value(provider.RecordsContextKey) != nil)
+	return s.trackingSource.Endpoints(ctx)
+}
+
+func TestIncrementalReconcileRecordsOrder(t *testing.T) {
+	src := &recordsContextSource{trackingSource: trackingSource{
+		endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}}
+	p := &filteredMockProvider{RecordsStore: []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4")}}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:               src,
+		Registry:             r,
+		Policy:               &plan.SyncPolicy{},
+		ManagedRecordTypes:   []string{endpoint.RecordTypeA},
+		IncrementalReconcile: true,
+		FullResyncInterval:   time.Hour,
+	}
+
+	// full reconciliations list the current records before the sources
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	// incremental reconciliations list them once the desired records changed
+	src.tracked = true
+	src.keys = []string{"ingress/default/a"}
+	src.endpoints = []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "4.3.2.1")}
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+
+	assert.Equal(t, []bool{true, false}, src.listedFirst)
+	assert.Equal(t, 2, p.RecordsCallCount)
+}
+
+func TestChangedDNSNames(t *testing.T) {
+	previous := desiredRecords([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("same.example.org", endpoint.RecordTypeA, "1.1.1.1", "2.2.2.2"),
+		endpoint.NewEndpoint("updated.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("deleted.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	})
+	current := desiredRecords([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("same.example.org", endpoint.RecordTypeA, "2.2.2.2", "1.1.1.1"),
+		endpoint.NewEndpoint("updated.example.org", endpoint.RecordTypeA, "3.3.3.3"),
+		endpoint.NewEndpoint("created.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	})
+
+	assert.Equal(t, []string{"created.example.org", "deleted.example.org", "updated.example.org"}, changedDNSNames(previous, current))
+}
+
+// statusSource returns fixed endpoints and records the reported statuses.
+type statusSource struct {
+	endpoints []*endpoint.Endpoint
+	statuses  map[string][]source.EndpointStatus
+}
+
+func (s *statusSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return s.endpoints, nil
+}
+
+func (s *statusSource) AddEventHandler(_ context.Context, _ func()) {}
+
+func (s *statusSource) ReportStatus(_ context.Context, statuses map[string][]source.EndpointStatus) {
+	s.statuses = statuses
+}
+
+func TestRunOnceReportsStatus(t *testing.T) {
+	src := &statusSource{
+		endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/default/a"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/default/a"),
+			endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	}
+	provider := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	domainFilter := endpoint.NewDomainFilter([]string{"example.org"})
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		DomainFilter:       domainFilter,
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	require.Len(t, src.statuses, 1)
+	statuses := src.statuses["httproute/default/a"]
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "a.example.org", statuses[0].Endpoint.DNSName)
+	assert.True(t, statuses[0].Programmed())
+	assert.Equal(t, "b.example.com", statuses[1].Endpoint.DNSName)
+	assert.Equal(t, source.StatusReasonFiltered, statuses[1].Reason)
+}
+
+func TestEndpointStatuses(t *testing.T) {
+	resource := "httproute/default/route"
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("programmed.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+		endpoint.NewEndpoint("Conflicted.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+		endpoint.NewEndpoint("filtered.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+		endpoint.NewEndpoint("unmanaged.example.org", endpoint.RecordTypeTXT, "text").WithLabel(endpoint.ResourceLabelKey, resource),
+		endpoint.NewEndpoint("failed.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+	}
+	p := &plan.Plan{
+		Current: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("programmed.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.OwnerLabelKey, "me"),
+			endpoint.NewEndpoint("conflicted.example.org.", endpoint.RecordTypeA, "5.6.7.8").WithLabel(endpoint.OwnerLabelKey, "other"),
+		},
+		Desired:        desired,
+		DomainFilter:   endpoint.MatchAllDomainFilters{endpoint.NewDomainFilter([]string{"example.org"})},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "me",
+	}
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{desired[4]}}
+
+	statuses := endpointStatuses(p, changes, errors.New("provider unavailable"))
+	require.Len(t, statuses[resource], len(desired))
+
+	var reasons []string
+	for _, status := range statuses[resource] {
+		reasons = append(reasons, status.Reason)
+	}
+	assert.Equal(t, []string{
+		source.StatusReasonProgrammed,
+		source.StatusReasonConflicted,
+		source.StatusReasonFiltered,
+		source.StatusReasonFiltered,
+		source.StatusReasonFailed,
+	}, reasons)
+	assert.Equal(t, `DNS name Conflicted.example.org is owned by "other"`, statuses[resource][1].Message)
+	assert.Contains(t, statuses[resource][4].Message, "provider unavailable")
+
+	// without failure, changed names are programmed
+	statuses = endpointStatuses(p, changes, nil)
+	assert.True(t, statuses[resource][4].Programmed())
+}
+
+// trackingStatusSource is a trackingSource recording the reported statuses.
+type trackingStatusSource struct {
+	trackingSource
+	statuses map[string][]source.EndpointStatus
+}
+
+func (s *trackingStatusSource) ReportStatus(_ context.Context, statuses map[string][]source.EndpointStatus) {
+	s.statuses = statuses
+}
+
+func TestIncrementalReconcileReportsStatus(t *testing.T) {
+	src := &trackingStatusSource{
+		trackingSource: trackingSource{
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/default/a"),
+				endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/default/a"),
+			},
+		},
+	}
+	provider := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:               src,
+		Registry:             r,
+		Policy:               &plan.SyncPolicy{},
+		DomainFilter:         endpoint.NewDomainFilter([]string{"example.org"}),
+		ManagedRecordTypes:   []string{endpoint.RecordTypeA},
+		IncrementalReconcile: true,
+		FullResyncInterval:   time.Hour,
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.Len(t, src.statuses["httproute/default/a"], 2)
+
+	// the endpoints moved to another route without changing the desired records,
+	// so the status is reported with the outcome of the previous reconciliation
+	src.tracked = true
+	provider.RecordsStore = src.endpoints[:1]
+	src.endpoints = []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/default/b"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/default/b"),
+	}
+	src.keys = []string{"httproute/default/a", "httproute/default/b"}
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 1, provider.RecordsCallCount)
+	require.Len(t, src.statuses, 1)
+	statuses := src.statuses["httproute/default/b"]
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Programmed())
+	assert.Equal(t, source.StatusReasonFiltered, statuses[1].Reason)
+	assert.Equal(t, "DNS name b.example.com does not match the domain filter", statuses[1].Message)
+}
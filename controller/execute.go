@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
@@ -31,14 +32,17 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/admission"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/adguard"
 	"sigs.k8s.io/external-dns/provider/akamai"
 	"sigs.k8s.io/external-dns/provider/alibabacloud"
 	"sigs.k8s.io/external-dns/provider/aws"
@@ -49,10 +53,12 @@ import (
 	"sigs.k8s.io/external-dns/provider/coredns"
 	"sigs.k8s.io/external-dns/provider/digitalocean"
 	"sigs.k8s.io/external-dns/provider/dnsimple"
+	"sigs.k8s.io/external-dns/provider/dnsserver"
 	"sigs.k8s.io/external-dns/provider/exoscale"
 	"sigs.k8s.io/external-dns/provider/gandi"
 	"sigs.k8s.io/external-dns/provider/godaddy"
 	"sigs.k8s.io/external-dns/provider/google"
+	"sigs.k8s.io/external-dns/provider/hosts"
 	"sigs.k8s.io/external-dns/provider/inmemory"
 	"sigs.k8s.io/external-dns/provider/linode"
 	"sigs.k8s.io/external-dns/provider/ns1"
@@ -63,9 +69,11 @@ import (
 	"sigs.k8s.io/external-dns/provider/plural"
 	"sigs.k8s.io/external-dns/provider/rfc2136"
 	"sigs.k8s.io/external-dns/provider/scaleway"
+	"sigs.k8s.io/external-dns/provider/technitium"
 	"sigs.k8s.io/external-dns/provider/transip"
 	"sigs.k8s.io/external-dns/provider/webhook"
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+	"sigs.k8s.io/external-dns/provider/zonefile"
 	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/source"
 )
@@ -100,13 +108,21 @@ func Execute() {
 	go serveMetrics(cfg.MetricsAddress)
 	go handleSigterm(cancel)
 
+	domainFilter := createDomainFilter(cfg)
+
+	if cfg.AdmissionWebhookServer {
+		validator := admission.NewValidator(domainFilter)
+		if err := admission.Serve(ctx, validator, cfg.AdmissionWebhookAddress, cfg.AdmissionWebhookTLSCertFile, cfg.AdmissionWebhookTLSKeyFile); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
 	endpointsSource, err := buildSource(ctx, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	domainFilter := createDomainFilter(cfg)
-
 	prvdr, err := buildProvider(ctx, cfg, domainFilter)
 	if err != nil {
 		log.Fatal(err)
@@ -142,6 +158,10 @@ func Execute() {
 	ctrl.Run(ctx)
 }
 
+// requestRateLimitedProviders are the providers whose API requests are rate limited by --provider-rate-limit,
+// rather than their calls.
+var requestRateLimitedProviders = []string{"aws", "google", "ovh"}
+
 func buildProvider(
 	ctx context.Context,
 	cfg *externaldns.Config,
@@ -155,6 +175,12 @@ func buildProvider(
 	zoneTypeFilter := provider.NewZoneTypeFilter(cfg.AWSZoneType)
 	zoneTagFilter := provider.NewZoneTagFilter(cfg.AWSZoneTagFilter)
 
+	// the providers supporting it rate limit their API requests, the calls to the others are rate limited below
+	var rateLimiter *rate.Limiter
+	if cfg.ProviderRateLimit > 0 && slices.Contains(requestRateLimitedProviders, cfg.Provider) {
+		rateLimiter = provider.NewRateLimiter(cfg.ProviderRateLimit, cfg.ProviderRateLimitBurst)
+	}
+
 	switch cfg.Provider {
 	case "akamai":
 		p, err = akamai.NewAkamaiProvider(
@@ -172,7 +198,7 @@ func buildProvider(
 	case "alibabacloud":
 		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.DryRun)
 	case "aws":
-		configs := aws.CreateV2Configs(cfg)
+		configs := aws.CreateV2Configs(cfg, rateLimiter)
 		clients := make(map[string]aws.Route53API, len(configs))
 		for profile, config := range configs {
 			clients[profile] = route53.NewFromConfig(config)
@@ -229,17 +255,25 @@ func buildProvider(
 				Comment: cfg.CloudflareDNSRecordsComment,
 			})
 	case "google":
-		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun, rateLimiter)
 	case "digitalocean":
 		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DryRun, cfg.DigitalOceanAPIPageSize)
 	case "ovh":
-		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.DryRun)
+		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.DryRun, rateLimiter)
 	case "linode":
 		p, err = linode.NewLinodeProvider(domainFilter, cfg.DryRun)
 	case "dnsimple":
 		p, err = dnsimple.NewDnsimpleProvider(domainFilter, zoneIDFilter, cfg.DryRun)
 	case "coredns", "skydns":
-		p, err = coredns.NewCoreDNSProvider(domainFilter, cfg.CoreDNSPrefix, cfg.DryRun)
+		if cfg.CoreDNSBackend == "configmap" {
+			kubeClient, kubeErr := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+			if kubeErr != nil {
+				return nil, kubeErr
+			}
+			p, err = coredns.NewCoreDNSConfigMapProvider(kubeClient, cfg.CoreDNSConfigMap, domainFilter, cfg.CoreDNSPrefix, cfg.DryRun)
+		} else {
+			p, err = coredns.NewCoreDNSProvider(domainFilter, cfg.CoreDNSPrefix, cfg.CoreDNSLeaseTTL, cfg.DryRun)
+		}
 	case "exoscale":
 		p, err = exoscale.NewExoscaleProvider(
 			cfg.ExoscaleAPIEnvironment,
@@ -267,6 +301,12 @@ func buildProvider(
 					ClientCertFilePath:    cfg.TLSClientCert,
 					ClientCertKeyFilePath: cfg.TLSClientCertKey,
 				},
+				ZoneParent:      cfg.PDNSZoneParent,
+				ZoneNameservers: cfg.PDNSZoneNameservers,
+				ZoneKind:        cfg.PDNSZoneKind,
+				DNSSEC:          cfg.PDNSDNSSEC,
+				Rectify:         cfg.PDNSRectify,
+				RRSetComments:   cfg.PDNSRRSetComments,
 			},
 		)
 	case "oci":
@@ -295,7 +335,7 @@ func buildProvider(
 			ClientCertFilePath:    cfg.TLSClientCert,
 			ClientCertKeyFilePath: cfg.TLSClientCertKey,
 		}
-		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, cfg.RFC2136LoadBalancingStrategy, nil)
+		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, cfg.RFC2136LoadBalancingStrategy, cfg.RFC2136Notify, cfg.RFC2136IXFR, cfg.RFC2136Prerequisites, nil)
 	case "ns1":
 		p, err = ns1.NewNS1Provider(
 			ns1.NS1Config{
@@ -328,11 +368,86 @@ func buildProvider(
 		)
 	case "plural":
 		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider)
+	case "dnsserver":
+		p, err = dnsserver.NewDNSServerProvider(ctx, dnsserver.Config{
+			Zones:         cfg.DNSServerZones,
+			Nameservers:   cfg.DNSServerNameservers,
+			ListenAddress: cfg.DNSServerListenAddress,
+		})
+	case "hosts":
+		p, err = hosts.NewHostsProvider(hosts.Config{
+			File:          cfg.HostsFile,
+			Format:        cfg.HostsFormat,
+			OwnerID:       cfg.TXTOwnerID,
+			ReloadCommand: cfg.HostsReloadCommand,
+			ReloadPIDFile: cfg.HostsReloadPIDFile,
+			DomainFilter:  domainFilter,
+			DryRun:        cfg.DryRun,
+		})
+	case "adguard":
+		p, err = adguard.NewAdGuardProvider(adguard.AdGuardConfig{
+			Server:                cfg.AdGuardServer,
+			Username:              cfg.AdGuardUsername,
+			Password:              cfg.AdGuardPassword,
+			TLSInsecureSkipVerify: cfg.AdGuardTLSInsecureSkipVerify,
+			OwnerID:               cfg.TXTOwnerID,
+			DomainFilter:          domainFilter,
+			DryRun:                cfg.DryRun,
+		})
+	case "technitium":
+		p, err = technitium.NewTechnitiumProvider(technitium.TechnitiumConfig{
+			Server: cfg.TechnitiumServer,
+			Token:  cfg.TechnitiumToken,
+			TLSConfig: technitium.TLSConfig{
+				SkipTLSVerify:         cfg.TechnitiumSkipTLSVerify,
+				CAFilePath:            cfg.TLSCA,
+				ClientCertFilePath:    cfg.TLSClientCert,
+				ClientCertKeyFilePath: cfg.TLSClientCertKey,
+			},
+			DomainFilter: domainFilter,
+			DryRun:       cfg.DryRun,
+		})
+	case "zonefile":
+		zoneFileConfig := zonefile.Config{
+			Directory:    cfg.ZoneFileDirectory,
+			ConfigMap:    cfg.ZoneFileConfigMap,
+			DomainFilter: domainFilter,
+			DryRun:       cfg.DryRun,
+		}
+		if cfg.ZoneFileConfigMap != "" {
+			zoneFileConfig.KubeClient, err = source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+		}
+		if err == nil {
+			p, err = zonefile.NewZoneFileProvider(zoneFileConfig)
+		}
 	case "webhook":
 		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL)
 	default:
 		err = fmt.Errorf("unknown dns provider: %s", cfg.Provider)
 	}
+	// the zone partitioned provider wraps the throttled one, so that every per-zone call is rate limited
+	if p != nil && (cfg.ProviderRateLimit > 0 || cfg.ProviderMaxRetries > 0) {
+		requestsPerSecond := cfg.ProviderRateLimit
+		if rateLimiter != nil {
+			requestsPerSecond = 0
+		}
+		p = provider.NewThrottledProvider(p, cfg.Provider, provider.ThrottleConfig{
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             cfg.ProviderRateLimitBurst,
+			MaxRetries:        uint(max(cfg.ProviderMaxRetries, 0)),
+			InitialBackoff:    cfg.ProviderRetryInitialInterval,
+			MaxBackoff:        cfg.ProviderRetryMaxInterval,
+		})
+	}
+	if p != nil && cfg.ProviderZoneConcurrency > 0 {
+		p, err = provider.NewZonePartitionedProvider(p, cfg.ProviderZoneConcurrency)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p != nil && cfg.ProviderCircuitBreakerThreshold > 0 {
+		p = provider.NewCircuitBreakerProvider(p, cfg.ProviderCircuitBreakerThreshold, cfg.ProviderCircuitBreakerCooldown)
+	}
 	if p != nil && cfg.ProviderCacheTime > 0 {
 		p = provider.NewCachedProvider(
 			p,
@@ -360,6 +475,8 @@ func buildController(cfg *externaldns.Config, src source.Source, p provider.Prov
 		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
 		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
 		MinEventSyncInterval: cfg.MinEventSyncInterval,
+		IncrementalReconcile: cfg.IncrementalReconcile,
+		FullResyncInterval:   cfg.FullResyncInterval,
 	}, nil
 }
 
@@ -409,7 +526,7 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 // deduplicated source. Returns the combined source or an error if source creation fails.
 func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, error) {
 	sourceCfg := source.NewSourceConfig(cfg)
-	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
+	clients := &source.SingletonClientGenerator{
 		KubeConfig:   cfg.KubeConfig,
 		APIServerURL: cfg.APIServerURL,
 		RequestTimeout: func() time.Duration {
@@ -418,7 +535,8 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 			}
 			return cfg.RequestTimeout
 		}(),
-	}, cfg.Sources, sourceCfg)
+	}
+	sources, err := source.ByNames(ctx, clients, cfg.Sources, sourceCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -428,6 +546,16 @@ func buildSource(ctx context.Context, cfg *externaldns.Config) (source.Source, e
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
 	combinedSource = source.NewNAT64Source(combinedSource, cfg.NAT64Networks)
 	combinedSource = source.NewTargetFilterSource(combinedSource, targetFilter)
+	if cfg.ClaimPolicyFile != "" {
+		policy, err := source.LoadClaimPolicy(cfg.ClaimPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		combinedSource, err = source.NewClaimPolicySource(ctx, combinedSource, policy, clients)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return combinedSource, nil
 }
 
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/apis/v1beta1"
+)
+
+// ConvertPath is the path serving the conversion webhook of the DNSEndpoint CRD.
+const ConvertPath = "/convert"
+
+// conversionReview mirrors the apiextensions.k8s.io/v1 ConversionReview wire format,
+// without depending on k8s.io/apiextensions-apiserver.
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+func serveConvert(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var review conversionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes)).Decode(&review); err != nil {
+		log.Errorf("Failed to decode conversion review: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		log.Error("Failed to decode conversion review: missing request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := &conversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, object := range review.Request.Objects {
+		converted, err := convertDNSEndpoint(object.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			log.Errorf("Failed to convert DNSEndpoint to %s: %v", review.Request.DesiredAPIVersion, err)
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	review.Request = nil
+	review.Response = response
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Errorf("Failed to encode conversion review: %v", err)
+	}
+}
+
+// convertDNSEndpoint converts a serialized DNSEndpoint to the desired API version, through the v1alpha1 hub version.
+func convertDNSEndpoint(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+	if typeMeta.Kind != "DNSEndpoint" {
+		return nil, fmt.Errorf("unsupported kind %q", typeMeta.Kind)
+	}
+
+	hub := &v1alpha1.DNSEndpoint{}
+	switch typeMeta.APIVersion {
+	case v1alpha1.GroupVersion.String():
+		if err := json.Unmarshal(raw, hub); err != nil {
+			return nil, err
+		}
+	case v1beta1.GroupVersion.String():
+		src := &v1beta1.DNSEndpoint{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, err
+		}
+		if err := src.ConvertTo(hub); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported API version %q", typeMeta.APIVersion)
+	}
+
+	var dst runtime.Object
+	switch desiredAPIVersion {
+	case v1alpha1.GroupVersion.String():
+		dst = hub
+	case v1beta1.GroupVersion.String():
+		converted := &v1beta1.DNSEndpoint{}
+		if err := converted.ConvertFrom(hub); err != nil {
+			return nil, err
+		}
+		dst = converted
+	default:
+		return nil, fmt.Errorf("unsupported API version %q", desiredAPIVersion)
+	}
+	dst.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(desiredAPIVersion, "DNSEndpoint"))
+	return json.Marshal(dst)
+}
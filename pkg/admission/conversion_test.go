@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func conversion(t *testing.T, desiredAPIVersion string, objects ...string) *conversionResponse {
+	t.Helper()
+
+	request := &conversionRequest{UID: "uid", DesiredAPIVersion: desiredAPIVersion}
+	for _, object := range objects {
+		request.Objects = append(request.Objects, runtime.RawExtension{Raw: []byte(object)})
+	}
+	body, err := json.Marshal(conversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request:  request,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	NewValidator(nil).Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ConvertPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var review conversionReview
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&review))
+	require.NotNil(t, review.Response)
+	assert.Equal(t, "ConversionReview", review.Kind)
+	assert.EqualValues(t, "uid", review.Response.UID)
+	return review.Response
+}
+
+func TestConvertHandler(t *testing.T) {
+	alpha := `{"apiVersion": "externaldns.k8s.io/v1alpha1", "kind": "DNSEndpoint", "metadata": {"name": "test"}, "spec": {"endpoints": [
+		{"dnsName": "www.example.com", "recordType": "A", "targets": ["1.2.3.4"], "recordTTL": 60, "setIdentifier": "blue",
+		 "providerSpecific": [{"name": "aws/weight", "value": "10"}]}]}}`
+	beta := `{"apiVersion": "externaldns.k8s.io/v1beta1", "kind": "DNSEndpoint", "metadata": {"name": "test"}, "spec": {"endpoints": [
+		{"dnsName": "www.example.com", "recordType": "A", "targets": ["1.2.3.4"], "ttl": "1m0s", "setIdentifier": "blue",
+		 "routingPolicy": {"weight": 10}}]}}`
+
+	response := conversion(t, "externaldns.k8s.io/v1beta1", alpha)
+	assert.Equal(t, metav1.StatusSuccess, response.Result.Status)
+	require.Len(t, response.ConvertedObjects, 1)
+	assert.JSONEq(t, beta, withoutEmptyFields(t, response.ConvertedObjects[0].Raw))
+
+	response = conversion(t, "externaldns.k8s.io/v1alpha1", beta)
+	assert.Equal(t, metav1.StatusSuccess, response.Result.Status)
+	require.Len(t, response.ConvertedObjects, 1)
+	assert.JSONEq(t, alpha, withoutEmptyFields(t, response.ConvertedObjects[0].Raw))
+
+	response = conversion(t, "externaldns.k8s.io/v1alpha1", alpha, `{"apiVersion": "externaldns.k8s.io/v2", "kind": "DNSEndpoint"}`)
+	assert.Equal(t, metav1.StatusFailure, response.Result.Status)
+	assert.Equal(t, `unsupported API version "externaldns.k8s.io/v2"`, response.Result.Message)
+	assert.Empty(t, response.ConvertedObjects)
+}
+
+// withoutEmptyFields removes the empty metadata and status fields added by the serialization of the converted objects.
+func withoutEmptyFields(t *testing.T, raw []byte) string {
+	t.Helper()
+	var object map[string]any
+	require.NoError(t, json.Unmarshal(raw, &object))
+	delete(object["metadata"].(map[string]any), "creationTimestamp")
+	delete(object, "status")
+	data, err := json.Marshal(object)
+	require.NoError(t, err)
+	return string(data)
+}
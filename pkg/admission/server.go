@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/apis/v1beta1"
+)
+
+const (
+	// ValidatePath is the path serving the validating admission webhook.
+	ValidatePath = "/validate"
+
+	maxRequestBytes = 3 * 1024 * 1024
+)
+
+// Handler returns the HTTP handler of the validating admission webhook and of the DNSEndpoint conversion webhook.
+func (v *Validator) Handler() http.Handler {
+	m := http.NewServeMux()
+	m.HandleFunc(ValidatePath, v.serveValidate)
+	m.HandleFunc(ConvertPath, serveConvert)
+	m.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	return m
+}
+
+func (v *Validator) serveValidate(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes)).Decode(&review); err != nil {
+		log.Errorf("Failed to decode admission review: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		log.Error("Failed to decode admission review: missing request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	review.Response = v.review(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Errorf("Failed to encode admission review: %v", err)
+	}
+}
+
+func (v *Validator) review(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if request.Operation == admissionv1.Delete || request.Operation == admissionv1.Connect {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+
+	var result *Result
+	if request.Kind.Group == v1alpha1.GroupVersion.Group && request.Kind.Kind == "DNSEndpoint" {
+		var dnsEndpoint v1alpha1.DNSEndpoint
+		if err := decodeDNSEndpoint(request, &dnsEndpoint); err != nil {
+			return deny(http.StatusBadRequest, fmt.Sprintf("failed to decode DNSEndpoint: %v", err))
+		}
+		result = v.ValidateDNSEndpoint(&dnsEndpoint)
+		result.merge(v.ValidateAnnotations(dnsEndpoint.Annotations, resourceName(request)))
+	} else {
+		var object metav1.PartialObjectMetadata
+		if err := json.Unmarshal(request.Object.Raw, &object); err != nil {
+			return deny(http.StatusBadRequest, fmt.Sprintf("failed to decode %s: %v", request.Kind.Kind, err))
+		}
+		result = v.ValidateAnnotations(object.Annotations, resourceName(request))
+	}
+
+	if !result.Allowed() {
+		log.Debugf("Rejected %s: %s", resourceName(request), strings.Join(result.Errors, "; "))
+		response := deny(http.StatusUnprocessableEntity, strings.Join(result.Errors, "; "))
+		response.Warnings = result.Warnings
+		return response
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true, Warnings: result.Warnings}
+}
+
+// decodeDNSEndpoint decodes the DNSEndpoint of the request, converted to v1alpha1 if needed.
+func decodeDNSEndpoint(request *admissionv1.AdmissionRequest, dnsEndpoint *v1alpha1.DNSEndpoint) error {
+	if request.Kind.Version != v1beta1.GroupVersion.Version {
+		return json.Unmarshal(request.Object.Raw, dnsEndpoint)
+	}
+	var src v1beta1.DNSEndpoint
+	if err := json.Unmarshal(request.Object.Raw, &src); err != nil {
+		return err
+	}
+	return src.ConvertTo(dnsEndpoint)
+}
+
+func deny(code int32, message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    code,
+			Message: message,
+		},
+	}
+}
+
+func resourceName(request *admissionv1.AdmissionRequest) string {
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(request.Kind.Kind), request.Namespace, request.Name)
+}
+
+// Serve serves the validating admission webhook over TLS on address until ctx is done.
+func Serve(ctx context.Context, validator *Validator, address, certFile, keyFile string) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           validator.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Infof("Serving the validating admission webhook on %s%s", address, ValidatePath)
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func admissionReview(t *testing.T, handler http.Handler, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	t.Helper()
+
+	body, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request:  request,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var review admissionv1.AdmissionReview
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&review))
+	require.NotNil(t, review.Response)
+	assert.Equal(t, request.UID, review.Response.UID)
+	return review.Response
+}
+
+func TestValidateHandler(t *testing.T) {
+	handler := NewValidator(endpoint.NewDomainFilter([]string{"example.com"})).Handler()
+
+	for _, tt := range []struct {
+		name     string
+		request  *admissionv1.AdmissionRequest
+		allowed  bool
+		message  string
+		warnings []string
+	}{
+		{
+			name: "valid ingress",
+			request: &admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "test", "annotations": {
+					"external-dns.alpha.kubernetes.io/hostname": "a.example.com,a.example.org"}}}`)},
+			},
+			allowed:  true,
+			warnings: []string{`annotation external-dns.alpha.kubernetes.io/hostname: "a.example.org" is outside of the domains managed by external-dns and will be ignored`},
+		},
+		{
+			name: "invalid service",
+			request: &admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Service"},
+				Operation: admissionv1.Update,
+				Object: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "test", "annotations": {
+					"external-dns.alpha.kubernetes.io/ttl": "-1"}}}`)},
+			},
+			message: `annotation external-dns.alpha.kubernetes.io/ttl: "-1" is not a valid TTL`,
+		},
+		{
+			name: "invalid DNSEndpoint",
+			request: &admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"},
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "test"}, "spec": {"endpoints": [
+					{"dnsName": "example.com", "recordType": "MX", "targets": ["mail.example.com"]}]}}`)},
+			},
+			message: `spec.endpoints[0].targets: ["mail.example.com"] are not valid MX targets`,
+		},
+		{
+			name: "invalid v1beta1 DNSEndpoint",
+			request: &admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1beta1", Kind: "DNSEndpoint"},
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "test"}, "spec": {"endpoints": [
+					{"dnsName": "example.com", "recordType": "MX", "targets": ["mail.example.com"], "ttl": "1m"}]}}`)},
+			},
+			message: `spec.endpoints[0].targets: ["mail.example.com"] are not valid MX targets`,
+		},
+		{
+			name: "deletion",
+			request: &admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Service"},
+				Operation: admissionv1.Delete,
+			},
+			allowed: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.UID = types.UID("uid-" + tt.name)
+			response := admissionReview(t, handler, tt.request)
+			assert.Equal(t, tt.allowed, response.Allowed)
+			assert.Equal(t, tt.warnings, response.Warnings)
+			if !tt.allowed {
+				require.NotNil(t, response.Result)
+				assert.Equal(t, tt.message, response.Result.Message)
+			}
+		})
+	}
+}
+
+func TestValidateHandlerBadRequest(t *testing.T) {
+	handler := NewValidator(nil).Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ValidatePath, bytes.NewReader([]byte(`{}`))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ValidatePath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider/aws"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+const (
+	maxHostnameLength = 253
+	maxLabelLength    = 63
+)
+
+// cloudflareKeys are the cloudflare- annotations supported by the Cloudflare provider.
+var cloudflareKeys = []string{
+	annotations.CloudflareProxiedKey,
+	annotations.CloudflareCustomHostnameKey,
+	annotations.CloudflareRegionKey,
+	annotations.CloudflareRecordCommentKey,
+}
+
+// Result holds the outcome of a validation: errors reject the object, warnings are returned to the client.
+type Result struct {
+	Errors   []string
+	Warnings []string
+}
+
+// Allowed reports whether the validated object is accepted.
+func (r *Result) Allowed() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *Result) errorf(format string, a ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, a...))
+}
+
+func (r *Result) warnf(format string, a ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, a...))
+}
+
+func (r *Result) merge(other *Result) {
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+}
+
+// Validator validates external-dns annotations and DNSEndpoint objects.
+type Validator struct {
+	domainFilter endpoint.DomainFilterInterface
+}
+
+// NewValidator returns a Validator warning about hostnames not matched by domainFilter.
+func NewValidator(domainFilter endpoint.DomainFilterInterface) *Validator {
+	return &Validator{domainFilter: domainFilter}
+}
+
+// ValidateAnnotations validates the external-dns annotations of the given resource.
+func (v *Validator) ValidateAnnotations(objAnnotations map[string]string, resource string) *Result {
+	result := &Result{}
+
+	// the TTL is checked as TTLFromAnnotations does, without logging invalid values
+	if value, ok := objAnnotations[annotations.TtlKey]; ok {
+		if ttl, err := annotations.ParseTTL(value); err != nil || ttl < annotations.TTLMinimum || ttl > annotations.TTLMaximum {
+			result.errorf("annotation %s: %q is not a valid TTL", annotations.TtlKey, value)
+		}
+	}
+
+	for key, hostnames := range map[string][]string{
+		annotations.HostnameKey:         annotations.HostnamesFromAnnotations(objAnnotations),
+		annotations.InternalHostnameKey: annotations.InternalHostnamesFromAnnotations(objAnnotations),
+	} {
+		for _, hostname := range hostnames {
+			v.validateHostname(result, "annotation "+key, hostname)
+		}
+	}
+
+	if value, ok := objAnnotations[annotations.HostnameOverridesKey]; ok && annotations.HostnameOverridesFromAnnotations(objAnnotations, resource) == nil {
+		result.errorf("annotation %s: %q is not a valid object keyed by hostname", annotations.HostnameOverridesKey, value)
+	}
+
+	for key := range objAnnotations {
+		switch {
+		case strings.HasPrefix(key, annotations.AWSPrefix):
+			property := "aws/" + strings.TrimPrefix(key, annotations.AWSPrefix)
+			if !slices.Contains(aws.ProviderSpecificProperties, property) {
+				result.errorf("annotation %s is not supported by the AWS provider", key)
+			}
+		case strings.HasPrefix(key, annotations.CloudflarePrefix):
+			if !slices.Contains(cloudflareKeys, key) {
+				result.errorf("annotation %s is not supported by the Cloudflare provider", key)
+			}
+		}
+	}
+
+	slices.Sort(result.Errors)
+	slices.Sort(result.Warnings)
+	return result
+}
+
+// ValidateDNSEndpoint validates the endpoints of a DNSEndpoint.
+func (v *Validator) ValidateDNSEndpoint(dnsEndpoint *v1alpha1.DNSEndpoint) *Result {
+	result := &Result{}
+	for i, ep := range dnsEndpoint.Spec.Endpoints {
+		field := fmt.Sprintf("spec.endpoints[%d]", i)
+		if ep == nil {
+			result.errorf("%s: must not be null", field)
+			continue
+		}
+		v.validateHostname(result, field+".dnsName", ep.DNSName)
+		if ep.RecordType == "" {
+			result.errorf("%s.recordType: must not be empty", field)
+		}
+		if len(ep.Targets) == 0 {
+			result.errorf("%s.targets: must not be empty", field)
+		} else if !ep.CheckEndpoint() {
+			result.errorf("%s.targets: %q are not valid %s targets", field, []string(ep.Targets), ep.RecordType)
+		}
+		if ep.RecordTTL < 0 {
+			result.errorf("%s.recordTTL: must not be negative", field)
+		}
+	}
+	return result
+}
+
+func (v *Validator) validateHostname(result *Result, field, hostname string) {
+	if err := validateHostname(hostname); err != nil {
+		result.errorf("%s: %q %v", field, hostname, err)
+		return
+	}
+	if v.domainFilter != nil && !v.domainFilter.Match(hostname) {
+		result.warnf("%s: %q is outside of the domains managed by external-dns and will be ignored", field, hostname)
+	}
+}
+
+// validateHostname checks that hostname is a valid DNS name, optionally starting with a wildcard label.
+// Underscores are accepted, as they are commonly used by service records. Internationalized labels are
+// validated in their ASCII form, as they are published by the providers.
+func validateHostname(hostname string) error {
+	name := strings.TrimSuffix(hostname, ".")
+	if name == "" {
+		return errors.New("is empty")
+	}
+	name, err := toASCII(name)
+	if err != nil {
+		return err
+	}
+	if len(name) > maxHostnameLength {
+		return fmt.Errorf("is longer than %d characters", maxHostnameLength)
+	}
+	name = strings.TrimPrefix(name, "*.")
+	for label := range strings.SplitSeq(name, ".") {
+		if label == "" {
+			return errors.New("contains an empty label")
+		}
+		if len(label) > maxLabelLength {
+			return fmt.Errorf("contains label %q longer than %d characters", label, maxLabelLength)
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("contains label %q starting or ending with a hyphen", label)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return fmt.Errorf("contains invalid character %q", c)
+			}
+		}
+	}
+	return nil
+}
+
+// toASCII converts the internationalized labels of name to punycode. ASCII labels are kept as is, since
+// the IDNA lookup profile rejects the wildcard and underscore labels which are accepted in hostnames.
+func toASCII(name string) (string, error) {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		converted, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", fmt.Errorf("contains invalid internationalized label %q", label)
+		}
+		labels[i] = converted
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+func TestValidateAnnotations(t *testing.T) {
+	validator := NewValidator(endpoint.NewDomainFilter([]string{"example.com"}))
+
+	for _, tt := range []struct {
+		name        string
+		annotations map[string]string
+		errors      []string
+		warnings    []string
+	}{
+		{
+			name: "valid",
+			annotations: map[string]string{
+				annotations.HostnameKey:                 "a.example.com,*.b.example.com",
+				annotations.InternalHostnameKey:         "_sip._tcp.example.com.,bücher.example.com,*.例え.example.com",
+				annotations.TtlKey:                      "1m",
+				annotations.AWSPrefix + "weight":        "10",
+				annotations.CloudflareProxiedKey:        "true",
+				annotations.HostnameOverridesKey:        `a.example.com: {ttl: 60}`,
+				"kubernetes.io/ingress.class":           "nginx",
+				"external-dns.alpha.kubernetes.io/test": "ignored",
+			},
+		},
+		{
+			name:        "no annotations",
+			annotations: nil,
+		},
+		{
+			name:        "invalid ttl",
+			annotations: map[string]string{annotations.TtlKey: "one minute"},
+			errors:      []string{`annotation external-dns.alpha.kubernetes.io/ttl: "one minute" is not a valid TTL`},
+		},
+		{
+			name:        "out of range ttl",
+			annotations: map[string]string{annotations.TtlKey: "0s"},
+			errors:      []string{`annotation external-dns.alpha.kubernetes.io/ttl: "0s" is not a valid TTL`},
+		},
+		{
+			name:        "invalid hostnames",
+			annotations: map[string]string{annotations.HostnameKey: "a.example.com,-a.example.com,a..example.com,a_b!.example.com," + strings.Repeat("a", 64) + ".example.com"},
+			errors: []string{
+				`annotation external-dns.alpha.kubernetes.io/hostname: "-a.example.com" contains label "-a" starting or ending with a hyphen`,
+				`annotation external-dns.alpha.kubernetes.io/hostname: "a..example.com" contains an empty label`,
+				`annotation external-dns.alpha.kubernetes.io/hostname: "a_b!.example.com" contains invalid character '!'`,
+				`annotation external-dns.alpha.kubernetes.io/hostname: "` + strings.Repeat("a", 64) + `.example.com" contains label "` + strings.Repeat("a", 64) + `" longer than 63 characters`,
+			},
+		},
+		{
+			name:        "invalid internationalized hostnames",
+			annotations: map[string]string{annotations.HostnameKey: "a\u200d.example.com," + strings.Repeat("ü", 60) + ".example.com"},
+			errors: []string{
+				`annotation external-dns.alpha.kubernetes.io/hostname: "a\u200d.example.com" contains invalid internationalized label "a\u200d"`,
+				`annotation external-dns.alpha.kubernetes.io/hostname: "` + strings.Repeat("ü", 60) + `.example.com" contains label "xn--td` + strings.Repeat("a", 60) + `" longer than 63 characters`,
+			},
+		},
+		{
+			name:        "hostname outside of the domain filter",
+			annotations: map[string]string{annotations.HostnameKey: "a.example.org"},
+			warnings:    []string{`annotation external-dns.alpha.kubernetes.io/hostname: "a.example.org" is outside of the domains managed by external-dns and will be ignored`},
+		},
+		{
+			name: "unknown provider-specific annotations",
+			annotations: map[string]string{
+				annotations.AWSPrefix + "wieght":                    "10",
+				"external-dns.alpha.kubernetes.io/cloudflare-proxy": "true",
+			},
+			errors: []string{
+				"annotation external-dns.alpha.kubernetes.io/aws-wieght is not supported by the AWS provider",
+				"annotation external-dns.alpha.kubernetes.io/cloudflare-proxy is not supported by the Cloudflare provider",
+			},
+		},
+		{
+			name:        "invalid hostname overrides",
+			annotations: map[string]string{annotations.HostnameOverridesKey: "a.example.com"},
+			errors:      []string{`annotation external-dns.alpha.kubernetes.io/hostname-overrides: "a.example.com" is not a valid object keyed by hostname`},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.ValidateAnnotations(tt.annotations, "ingress/default/test")
+			assert.Equal(t, tt.errors, result.Errors)
+			assert.Equal(t, tt.warnings, result.Warnings)
+			assert.Equal(t, len(tt.errors) == 0, result.Allowed())
+		})
+	}
+}
+
+func TestValidateAnnotationsInvalidTTLIsNotLogged(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	for _, ttl := range []string{"one minute", "-1"} {
+		result := NewValidator(nil).ValidateAnnotations(map[string]string{annotations.TtlKey: ttl}, "ingress/default/test")
+		assert.False(t, result.Allowed())
+	}
+	assert.Empty(t, hook.AllEntries())
+}
+
+func TestValidateDNSEndpoint(t *testing.T) {
+	validator := NewValidator(endpoint.NewDomainFilter([]string{"example.com"}))
+
+	result := validator.ValidateDNSEndpoint(&v1alpha1.DNSEndpoint{
+		Spec: v1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com"),
+				endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5060"),
+				endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+				{DNSName: "b.example.com", RecordType: endpoint.RecordTypeA, RecordTTL: -1},
+				{DNSName: "bad name", Targets: endpoint.Targets{"1.2.3.4"}},
+				nil,
+			},
+		},
+	})
+
+	assert.Equal(t, []string{
+		`spec.endpoints[2].targets: ["mail.example.com"] are not valid MX targets`,
+		`spec.endpoints[3].targets: ["10 5 5060"] are not valid SRV targets`,
+		"spec.endpoints[5].targets: must not be empty",
+		"spec.endpoints[5].recordTTL: must not be negative",
+		`spec.endpoints[6].dnsName: "bad name" contains invalid character ' '`,
+		"spec.endpoints[6].recordType: must not be empty",
+		"spec.endpoints[7]: must not be null",
+	}, result.Errors)
+	assert.Equal(t, []string{
+		`spec.endpoints[4].dnsName: "a.example.org" is outside of the domains managed by external-dns and will be ignored`,
+	}, result.Warnings)
+}
@@ -60,6 +60,8 @@ type Config struct {
 	GatewayName                                   string
 	GatewayNamespace                              string
 	GatewayLabelFilter                            string
+	GatewayListenerSets                           bool
+	GatewayRouteStatus                            bool
 	Compatibility                                 string
 	PodSourceDomain                               string
 	PublishInternal                               bool
@@ -68,6 +70,14 @@ type Config struct {
 	ConnectorSourceServer                         string
 	Provider                                      string
 	ProviderCacheTime                             time.Duration
+	ProviderZoneConcurrency                       int
+	ProviderRateLimit                             float64
+	ProviderRateLimitBurst                        int
+	ProviderMaxRetries                            int
+	ProviderRetryInitialInterval                  time.Duration
+	ProviderRetryMaxInterval                      time.Duration
+	ProviderCircuitBreakerThreshold               int
+	ProviderCircuitBreakerCooldown                time.Duration
 	GoogleProject                                 string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
@@ -80,6 +90,7 @@ type Config struct {
 	ZoneIDFilter                                  []string
 	TargetNetFilter                               []string
 	ExcludeTargetNets                             []string
+	ClaimPolicyFile                               string
 	AlibabaCloudConfigFile                        string
 	AlibabaCloudZoneType                          string
 	AWSZoneType                                   string
@@ -117,6 +128,9 @@ type Config struct {
 	CloudflareRegionKey                           string
 	CloudflareRecordComment                       string
 	CoreDNSPrefix                                 string
+	CoreDNSBackend                                string
+	CoreDNSConfigMap                              string
+	CoreDNSLeaseTTL                               time.Duration
 	AkamaiServiceConsumerDomain                   string
 	AkamaiClientToken                             string
 	AkamaiClientSecret                            string
@@ -136,6 +150,12 @@ type Config struct {
 	PDNSServerID                                  string
 	PDNSAPIKey                                    string `secure:"yes"`
 	PDNSSkipTLSVerify                             bool
+	PDNSZoneParent                                string
+	PDNSZoneNameservers                           []string
+	PDNSZoneKind                                  string
+	PDNSDNSSEC                                    bool
+	PDNSRectify                                   bool
+	PDNSRRSetComments                             bool
 	TLSCA                                         string
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
@@ -151,6 +171,8 @@ type Config struct {
 	Once                                          bool
 	DryRun                                        bool
 	UpdateEvents                                  bool
+	IncrementalReconcile                          bool
+	FullResyncInterval                            time.Duration
 	LogFormat                                     string
 	MetricsAddress                                string
 	LogLevel                                      string
@@ -163,6 +185,8 @@ type Config struct {
 	ExoscaleAPIZone                               string
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	CRDZoneDelegation                             bool
+	UnstructuredSourceConfig                      string
 	ServiceTypeFilter                             []string
 	CFAPIEndpoint                                 string
 	CFUsername                                    string
@@ -186,6 +210,9 @@ type Config struct {
 	RFC2136BatchChangeSize                        int
 	RFC2136UseTLS                                 bool
 	RFC2136SkipTLSVerify                          bool
+	RFC2136Notify                                 []string
+	RFC2136IXFR                                   bool
+	RFC2136Prerequisites                          bool
 	NS1Endpoint                                   string
 	NS1IgnoreSSL                                  bool
 	NS1MinTTLSeconds                              int
@@ -205,10 +232,30 @@ type Config struct {
 	PiholeApiVersion                              string
 	PluralCluster                                 string
 	PluralProvider                                string
+	ZoneFileDirectory                             string
+	ZoneFileConfigMap                             string
+	DNSServerZones                                []string
+	DNSServerNameservers                          []string
+	DNSServerListenAddress                        string
+	HostsFile                                     string
+	HostsFormat                                   string
+	HostsReloadCommand                            string
+	HostsReloadPIDFile                            string
+	TechnitiumServer                              string
+	TechnitiumToken                               string `secure:"yes"`
+	TechnitiumSkipTLSVerify                       bool
+	AdGuardServer                                 string
+	AdGuardUsername                               string
+	AdGuardPassword                               string `secure:"yes"`
+	AdGuardTLSInsecureSkipVerify                  bool
 	WebhookProviderURL                            string
 	WebhookProviderReadTimeout                    time.Duration
 	WebhookProviderWriteTimeout                   time.Duration
 	WebhookServer                                 bool
+	AdmissionWebhookServer                        bool
+	AdmissionWebhookAddress                       string
+	AdmissionWebhookTLSCertFile                   string
+	AdmissionWebhookTLSKeyFile                    string
 	TraefikDisableLegacy                          bool
 	TraefikDisableNew                             bool
 	NAT64Networks                                 []string
@@ -259,124 +306,156 @@ var defaultConfig = &Config{
 	CloudflareRegionalServices:                    false,
 	CloudflareRegionKey:                           "earth",
 
-	CombineFQDNAndAnnotation:     false,
-	Compatibility:                "",
-	ConnectorSourceServer:        "localhost:8080",
-	CoreDNSPrefix:                "/skydns/",
-	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
-	CRDSourceKind:                "DNSEndpoint",
-	DefaultTargets:               []string{},
-	DigitalOceanAPIPageSize:      50,
-	DomainFilter:                 []string{},
-	DryRun:                       false,
-	ExcludeDNSRecordTypes:        []string{},
-	ExcludeDomains:               []string{},
-	ExcludeTargetNets:            []string{},
-	ExcludeUnschedulable:         true,
-	ExoscaleAPIEnvironment:       "api",
-	ExoscaleAPIKey:               "",
-	ExoscaleAPISecret:            "",
-	ExoscaleAPIZone:              "ch-gva-2",
-	ExposeInternalIPV6:           true,
-	FQDNTemplate:                 "",
-	GatewayLabelFilter:           "",
-	GatewayName:                  "",
-	GatewayNamespace:             "",
-	GlooNamespaces:               []string{"gloo-system"},
-	GoDaddyAPIKey:                "",
-	GoDaddyOTE:                   false,
-	GoDaddySecretKey:             "",
-	GoDaddyTTL:                   600,
-	GoogleBatchChangeInterval:    time.Second,
-	GoogleBatchChangeSize:        1000,
-	GoogleProject:                "",
-	GoogleZoneVisibility:         "",
-	IgnoreHostnameAnnotation:     false,
-	IgnoreIngressRulesSpec:       false,
-	IgnoreIngressTLSSpec:         false,
-	IngressClassNames:            nil,
-	InMemoryZones:                []string{},
-	Interval:                     time.Minute,
-	KubeConfig:                   "",
-	LabelFilter:                  labels.Everything().String(),
-	LogFormat:                    "text",
-	LogLevel:                     logrus.InfoLevel.String(),
-	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
-	MetricsAddress:               ":7979",
-	MinEventSyncInterval:         5 * time.Second,
-	Namespace:                    "",
-	NAT64Networks:                []string{},
-	NS1Endpoint:                  "",
-	NS1IgnoreSSL:                 false,
-	OCIConfigFile:                "/etc/kubernetes/oci.yaml",
-	OCIZoneCacheDuration:         0 * time.Second,
-	OCIZoneScope:                 "GLOBAL",
-	Once:                         false,
-	OVHApiRateLimit:              20,
-	OVHEnableCNAMERelative:       false,
-	OVHEndpoint:                  "ovh-eu",
-	PDNSAPIKey:                   "",
-	PDNSServer:                   "http://localhost:8081",
-	PDNSServerID:                 "localhost",
-	PDNSSkipTLSVerify:            false,
-	PiholeApiVersion:             "5",
-	PiholePassword:               "",
-	PiholeServer:                 "",
-	PiholeTLSInsecureSkipVerify:  false,
-	PluralCluster:                "",
-	PluralProvider:               "",
-	PodSourceDomain:              "",
-	Policy:                       "sync",
-	Provider:                     "",
-	ProviderCacheTime:            0,
-	PublishHostIP:                false,
-	PublishInternal:              false,
-	RegexDomainExclusion:         regexp.MustCompile(""),
-	RegexDomainFilter:            regexp.MustCompile(""),
-	Registry:                     "txt",
-	RequestTimeout:               time.Second * 30,
-	RFC2136BatchChangeSize:       50,
-	RFC2136GSSTSIG:               false,
-	RFC2136Host:                  []string{""},
-	RFC2136Insecure:              false,
-	RFC2136KerberosPassword:      "",
-	RFC2136KerberosRealm:         "",
-	RFC2136KerberosUsername:      "",
-	RFC2136LoadBalancingStrategy: "disabled",
-	RFC2136MinTTL:                0,
-	RFC2136Port:                  0,
-	RFC2136SkipTLSVerify:         false,
-	RFC2136TAXFR:                 true,
-	RFC2136TSIGKeyName:           "",
-	RFC2136TSIGSecret:            "",
-	RFC2136TSIGSecretAlg:         "",
-	RFC2136UseTLS:                false,
-	RFC2136Zone:                  []string{},
-	ServiceTypeFilter:            []string{},
-	SkipperRouteGroupVersion:     "zalando.org/v1",
-	Sources:                      nil,
-	TargetNetFilter:              []string{},
-	TLSCA:                        "",
-	TLSClientCert:                "",
-	TLSClientCertKey:             "",
-	TraefikDisableLegacy:         false,
-	TraefikDisableNew:            false,
-	TransIPAccountName:           "",
-	TransIPPrivateKeyFile:        "",
-	TXTCacheInterval:             0,
-	TXTEncryptAESKey:             "",
-	TXTEncryptEnabled:            false,
-	TXTOwnerID:                   "default",
-	TXTPrefix:                    "",
-	TXTSuffix:                    "",
-	TXTWildcardReplacement:       "",
-	UpdateEvents:                 false,
-	WebhookProviderReadTimeout:   5 * time.Second,
-	WebhookProviderURL:           "http://localhost:8888",
-	WebhookProviderWriteTimeout:  10 * time.Second,
-	WebhookServer:                false,
-	ZoneIDFilter:                 []string{},
-	ForceDefaultTargets:          false,
+	CombineFQDNAndAnnotation:       false,
+	Compatibility:                  "",
+	ConnectorSourceServer:          "localhost:8080",
+	CoreDNSPrefix:                  "/skydns/",
+	CoreDNSBackend:                 "etcd",
+	CoreDNSConfigMap:               "",
+	CoreDNSLeaseTTL:                0,
+	CRDSourceAPIVersion:            "externaldns.k8s.io/v1alpha1",
+	CRDSourceKind:                  "DNSEndpoint",
+	DefaultTargets:                 []string{},
+	DigitalOceanAPIPageSize:        50,
+	DomainFilter:                   []string{},
+	DryRun:                         false,
+	ExcludeDNSRecordTypes:          []string{},
+	ExcludeDomains:                 []string{},
+	ExcludeTargetNets:              []string{},
+	ExcludeUnschedulable:           true,
+	ExoscaleAPIEnvironment:         "api",
+	ExoscaleAPIKey:                 "",
+	ExoscaleAPISecret:              "",
+	ExoscaleAPIZone:                "ch-gva-2",
+	ExposeInternalIPV6:             true,
+	FQDNTemplate:                   "",
+	GatewayLabelFilter:             "",
+	GatewayName:                    "",
+	GatewayNamespace:               "",
+	GlooNamespaces:                 []string{"gloo-system"},
+	GoDaddyAPIKey:                  "",
+	GoDaddyOTE:                     false,
+	GoDaddySecretKey:               "",
+	GoDaddyTTL:                     600,
+	GoogleBatchChangeInterval:      time.Second,
+	GoogleBatchChangeSize:          1000,
+	GoogleProject:                  "",
+	GoogleZoneVisibility:           "",
+	IgnoreHostnameAnnotation:       false,
+	IgnoreIngressRulesSpec:         false,
+	IgnoreIngressTLSSpec:           false,
+	IngressClassNames:              nil,
+	InMemoryZones:                  []string{},
+	Interval:                       time.Minute,
+	KubeConfig:                     "",
+	LabelFilter:                    labels.Everything().String(),
+	LogFormat:                      "text",
+	LogLevel:                       logrus.InfoLevel.String(),
+	ManagedDNSRecordTypes:          []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	MetricsAddress:                 ":7979",
+	MinEventSyncInterval:           5 * time.Second,
+	FullResyncInterval:             time.Hour,
+	Namespace:                      "",
+	NAT64Networks:                  []string{},
+	NS1Endpoint:                    "",
+	NS1IgnoreSSL:                   false,
+	OCIConfigFile:                  "/etc/kubernetes/oci.yaml",
+	OCIZoneCacheDuration:           0 * time.Second,
+	OCIZoneScope:                   "GLOBAL",
+	Once:                           false,
+	OVHApiRateLimit:                20,
+	OVHEnableCNAMERelative:         false,
+	OVHEndpoint:                    "ovh-eu",
+	PDNSAPIKey:                     "",
+	PDNSServer:                     "http://localhost:8081",
+	PDNSServerID:                   "localhost",
+	PDNSSkipTLSVerify:              false,
+	PDNSZoneParent:                 "",
+	PDNSZoneKind:                   "Native",
+	PDNSDNSSEC:                     false,
+	PDNSRectify:                    false,
+	PDNSRRSetComments:              false,
+	PiholeApiVersion:               "5",
+	PiholePassword:                 "",
+	PiholeServer:                   "",
+	PiholeTLSInsecureSkipVerify:    false,
+	PluralCluster:                  "",
+	PluralProvider:                 "",
+	PodSourceDomain:                "",
+	Policy:                         "sync",
+	Provider:                       "",
+	ProviderCacheTime:              0,
+	ProviderRateLimitBurst:         1,
+	ProviderRetryInitialInterval:   time.Second,
+	ProviderRetryMaxInterval:       30 * time.Second,
+	ProviderCircuitBreakerCooldown: 5 * time.Minute,
+	PublishHostIP:                  false,
+	PublishInternal:                false,
+	RegexDomainExclusion:           regexp.MustCompile(""),
+	RegexDomainFilter:              regexp.MustCompile(""),
+	Registry:                       "txt",
+	RequestTimeout:                 time.Second * 30,
+	RFC2136BatchChangeSize:         50,
+	RFC2136GSSTSIG:                 false,
+	RFC2136Host:                    []string{""},
+	RFC2136Insecure:                false,
+	RFC2136IXFR:                    false,
+	RFC2136KerberosPassword:        "",
+	RFC2136KerberosRealm:           "",
+	RFC2136KerberosUsername:        "",
+	RFC2136LoadBalancingStrategy:   "disabled",
+	RFC2136MinTTL:                  0,
+	RFC2136Port:                    0,
+	RFC2136Prerequisites:           false,
+	RFC2136SkipTLSVerify:           false,
+	RFC2136TAXFR:                   true,
+	RFC2136TSIGKeyName:             "",
+	RFC2136TSIGSecret:              "",
+	RFC2136TSIGSecretAlg:           "",
+	RFC2136UseTLS:                  false,
+	RFC2136Zone:                    []string{},
+	ServiceTypeFilter:              []string{},
+	SkipperRouteGroupVersion:       "zalando.org/v1",
+	Sources:                        nil,
+	TargetNetFilter:                []string{},
+	TLSCA:                          "",
+	TLSClientCert:                  "",
+	TLSClientCertKey:               "",
+	TraefikDisableLegacy:           false,
+	TraefikDisableNew:              false,
+	TransIPAccountName:             "",
+	TransIPPrivateKeyFile:          "",
+	TXTCacheInterval:               0,
+	TXTEncryptAESKey:               "",
+	TXTEncryptEnabled:              false,
+	TXTOwnerID:                     "default",
+	TXTPrefix:                      "",
+	TXTSuffix:                      "",
+	TXTWildcardReplacement:         "",
+	UpdateEvents:                   false,
+	WebhookProviderReadTimeout:     5 * time.Second,
+	WebhookProviderURL:             "http://localhost:8888",
+	WebhookProviderWriteTimeout:    10 * time.Second,
+	WebhookServer:                  false,
+	AdmissionWebhookAddress:        ":9443",
+	ZoneIDFilter:                   []string{},
+	ZoneFileDirectory:              "",
+	ZoneFileConfigMap:              "",
+	DNSServerZones:                 []string{},
+	DNSServerNameservers:           []string{},
+	DNSServerListenAddress:         ":53",
+	HostsFile:                      "",
+	HostsFormat:                    "hosts",
+	HostsReloadCommand:             "",
+	HostsReloadPIDFile:             "",
+	TechnitiumServer:               "",
+	TechnitiumToken:                "",
+	TechnitiumSkipTLSVerify:        false,
+	AdGuardServer:                  "",
+	AdGuardUsername:                "",
+	AdGuardPassword:                "",
+	AdGuardTLSInsecureSkipVerify:   false,
+	ForceDefaultTargets:            false,
 }
 
 // NewConfig returns new Config object
@@ -457,16 +536,21 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("combine-fqdn-annotation", "Combine FQDN template and Annotations instead of overwriting (default: false)").BoolVar(&cfg.CombineFQDNAndAnnotation)
 	app.Flag("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)").Default(defaultConfig.Compatibility).EnumVar(&cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
 	app.Flag("connector-source-server", "The server to connect for connector source, valid only when using connector source").Default(defaultConfig.ConnectorSourceServer).StringVar(&cfg.ConnectorSourceServer)
-	app.Flag("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source").Default(defaultConfig.CRDSourceAPIVersion).StringVar(&cfg.CRDSourceAPIVersion)
+	app.Flag("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1` or `externaldns.k8s.io/v1beta1`, valid only when using crd source").Default(defaultConfig.CRDSourceAPIVersion).StringVar(&cfg.CRDSourceAPIVersion)
 	app.Flag("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion").Default(defaultConfig.CRDSourceKind).StringVar(&cfg.CRDSourceKind)
+	app.Flag("crd-zone-delegation", "Only publish the DNSEndpoints falling within a DNSZoneDelegation of their namespace, within its allowed record types and quota; valid only when using crd source (default: disabled)").BoolVar(&cfg.CRDZoneDelegation)
+	app.Flag("unstructured-source-config", "Path to the YAML file defining the resources watched by the unstructured source and the JSONPath expressions extracting their endpoints; valid only when using unstructured source").Default(defaultConfig.UnstructuredSourceConfig).StringVar(&cfg.UnstructuredSourceConfig)
 	app.Flag("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)").StringsVar(&cfg.DefaultTargets)
 	app.Flag("force-default-targets", "Force the application of --default-targets, overriding any targets provided by the source (DEPRECATED: This reverts to (improved) legacy behavior which allows empty CRD targets for migration to new state)").Default(strconv.FormatBool(defaultConfig.ForceDefaultTargets)).BoolVar(&cfg.ForceDefaultTargets)
 	app.Flag("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
 	app.Flag("exclude-target-net", "Exclude target nets (optional)").StringsVar(&cfg.ExcludeTargetNets)
+	app.Flag("claim-policy-file", "When set, only publish the hostnames each namespace is allowed to claim by the policies of this YAML file (optional)").Default(defaultConfig.ClaimPolicyFile).StringVar(&cfg.ClaimPolicyFile)
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional). Default is true.").BoolVar(&cfg.ExposeInternalIPV6)
-	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
+	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN. A CEL expression may be used instead when prefixed with 'cel:'.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
+	app.Flag("gateway-listener-sets", "When using the gateway source, also publish the hostnames of the listeners of the XListenerSets attached to the Gateways; requires the experimental XListenerSet CRD (default: disabled)").BoolVar(&cfg.GatewayListenerSets)
+	app.Flag("gateway-route-status", "When using the Gateway API route sources, write a dns.external-dns.io/Programmed condition on the status parents of the routes once their DNS records are programmed (default: disabled)").BoolVar(&cfg.GatewayRouteStatus)
 	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)").StringVar(&cfg.GatewayName)
 	app.Flag("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)").StringVar(&cfg.GatewayNamespace)
 	app.Flag("ignore-hostname-annotation", "Ignore hostname annotation when generating DNS names, valid only when --fqdn-template is set (default: false)").BoolVar(&cfg.IgnoreHostnameAnnotation)
@@ -484,15 +568,23 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, unstructured, gateway)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "unstructured", "gateway")
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
 	app.Flag("traefik-disable-legacy", "Disable listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableLegacy)).BoolVar(&cfg.TraefikDisableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
 
 	// Flags related to providers
-	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
+	providers := []string{"adguard", "akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "dnsserver", "exoscale", "gandi", "godaddy", "google", "hosts", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "technitium", "transip", "webhook", "zonefile"}
 	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
+	app.Flag("provider-zone-concurrency", "When greater than 0, apply the changes of each zone independently, with at most this many zones at a time; only supported by the aws and cloudflare providers, others apply their changes serially (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ProviderZoneConcurrency)).IntVar(&cfg.ProviderZoneConcurrency)
+	app.Flag("provider-rate-limit", "When greater than 0, limit the API requests of the aws, google and ovh providers to this many per second; for other providers, limit the calls made by each reconciliation, to list the records or apply the changes of a zone, instead (default: 0, disabled)").Default(strconv.FormatFloat(defaultConfig.ProviderRateLimit, 'f', -1, 64)).Float64Var(&cfg.ProviderRateLimit)
+	app.Flag("provider-rate-limit-burst", "The number of API requests or calls to the DNS provider allowed to exceed --provider-rate-limit at once").Default(strconv.Itoa(defaultConfig.ProviderRateLimitBurst)).IntVar(&cfg.ProviderRateLimitBurst)
+	app.Flag("provider-max-retries", "The number of times listing the DNS provider records or zones is retried on transient errors (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ProviderMaxRetries)).IntVar(&cfg.ProviderMaxRetries)
+	app.Flag("provider-retry-initial-interval", "The delay before the first retry of a DNS provider call, doubled on every further retry with random jitter").Default(defaultConfig.ProviderRetryInitialInterval.String()).DurationVar(&cfg.ProviderRetryInitialInterval)
+	app.Flag("provider-retry-max-interval", "The maximum delay between retries of a DNS provider call").Default(defaultConfig.ProviderRetryMaxInterval.String()).DurationVar(&cfg.ProviderRetryMaxInterval)
+	app.Flag("provider-circuit-breaker-threshold", "When greater than 0, stop applying changes to the DNS provider after this many consecutive transient failures and serve the last known records until the cooldown has elapsed (default: 0, disabled)").Default(strconv.Itoa(defaultConfig.ProviderCircuitBreakerThreshold)).IntVar(&cfg.ProviderCircuitBreakerThreshold)
+	app.Flag("provider-circuit-breaker-cooldown", "The time the DNS provider is left alone once the circuit breaker opened, before probing whether it recovered").Default(defaultConfig.ProviderCircuitBreakerCooldown.String()).DurationVar(&cfg.ProviderCircuitBreakerCooldown)
 	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
 	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
 	app.Flag("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)").Default(defaultConfig.RegexDomainFilter.String()).RegexpVar(&cfg.RegexDomainFilter)
@@ -538,6 +630,9 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("cloudflare-record-comment", "When using the Cloudflare provider, specify the comment for the DNS records (default: '')").Default("").StringVar(&cfg.CloudflareRecordComment)
 
 	app.Flag("coredns-prefix", "When using the CoreDNS provider, specify the prefix name").Default(defaultConfig.CoreDNSPrefix).StringVar(&cfg.CoreDNSPrefix)
+	app.Flag("coredns-backend", "When using the CoreDNS provider, specify where the records are stored (default: etcd, options: etcd, configmap)").Default(defaultConfig.CoreDNSBackend).EnumVar(&cfg.CoreDNSBackend, "etcd", "configmap")
+	app.Flag("coredns-configmap", "When using the CoreDNS provider with the configmap backend, the namespace/name of the ConfigMap storing the records and the zone files of the CoreDNS file plugin (required when --coredns-backend=configmap)").Default(defaultConfig.CoreDNSConfigMap).StringVar(&cfg.CoreDNSConfigMap)
+	app.Flag("coredns-lease-ttl", "When using the CoreDNS provider with the etcd backend, attach the records to an etcd lease of this TTL, refreshed when the records are listed, so that they expire when ExternalDNS stops; must be greater than --interval, --txt-cache-interval and --provider-cache-time, and than --full-resync-interval plus --interval with --incremental-reconcile (default: disabled)").Default(defaultConfig.CoreDNSLeaseTTL.String()).DurationVar(&cfg.CoreDNSLeaseTTL)
 	app.Flag("akamai-serviceconsumerdomain", "When using the Akamai provider, specify the base URL (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiServiceConsumerDomain).StringVar(&cfg.AkamaiServiceConsumerDomain)
 	app.Flag("akamai-client-token", "When using the Akamai provider, specify the client token (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiClientToken).StringVar(&cfg.AkamaiClientToken)
 	app.Flag("akamai-client-secret", "When using the Akamai provider, specify the client secret (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiClientSecret).StringVar(&cfg.AkamaiClientSecret)
@@ -557,6 +652,12 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("pdns-server-id", "When using the PowerDNS/PDNS provider, specify the id of the server to retrieve. Should be `localhost` except when the server is behind a proxy (optional when --provider=pdns) (default: localhost)").Default(defaultConfig.PDNSServerID).StringVar(&cfg.PDNSServerID)
 	app.Flag("pdns-api-key", "When using the PowerDNS/PDNS provider, specify the API key to use to authorize requests (required when --provider=pdns)").Default(defaultConfig.PDNSAPIKey).StringVar(&cfg.PDNSAPIKey)
 	app.Flag("pdns-skip-tls-verify", "When using the PowerDNS/PDNS provider, disable verification of any TLS certificates (optional when --provider=pdns) (default: false)").Default(strconv.FormatBool(defaultConfig.PDNSSkipTLSVerify)).BoolVar(&cfg.PDNSSkipTLSVerify)
+	app.Flag("pdns-zone-parent", "When using the PowerDNS/PDNS provider, create the missing zones of new records directly below this domain, and delegate them from its zone if it exists (optional)").Default(defaultConfig.PDNSZoneParent).StringVar(&cfg.PDNSZoneParent)
+	app.Flag("pdns-zone-nameserver", "When using the PowerDNS/PDNS provider, the name servers of the zones created below --pdns-zone-parent; specify multiple times for multiple name servers (required when --pdns-zone-parent is set)").StringsVar(&cfg.PDNSZoneNameservers)
+	app.Flag("pdns-zone-kind", "When using the PowerDNS/PDNS provider, the kind of the zones created below --pdns-zone-parent (default: Native, options: Native, Master)").Default(defaultConfig.PDNSZoneKind).EnumVar(&cfg.PDNSZoneKind, "Native", "Master")
+	app.Flag("pdns-dnssec", "When using the PowerDNS/PDNS provider, secure the zones created below --pdns-zone-parent with DNSSEC, and publish their DS records in the parent zone (default: false)").BoolVar(&cfg.PDNSDNSSEC)
+	app.Flag("pdns-rectify", "When using the PowerDNS/PDNS provider, rectify the DNSSEC signed zones after their records have changed (default: false)").BoolVar(&cfg.PDNSRectify)
+	app.Flag("pdns-rrset-comments", "When using the PowerDNS/PDNS provider, set a comment with the owner and resource of the records on their RRsets (default: false)").BoolVar(&cfg.PDNSRRSetComments)
 	app.Flag("ns1-endpoint", "When using the NS1 provider, specify the URL of the API endpoint to target (default: https://api.nsone.net/v1/)").Default(defaultConfig.NS1Endpoint).StringVar(&cfg.NS1Endpoint)
 	app.Flag("ns1-ignoressl", "When using the NS1 provider, specify whether to verify the SSL certificate (default: false)").Default(strconv.FormatBool(defaultConfig.NS1IgnoreSSL)).BoolVar(&cfg.NS1IgnoreSSL)
 	app.Flag("ns1-min-ttl", "Minimal TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is lower than this.").IntVar(&cfg.NS1MinTTLSeconds)
@@ -597,6 +698,9 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("rfc2136-use-tls", "When using the RFC2136 provider, communicate with name server over tls").BoolVar(&cfg.RFC2136UseTLS)
 	app.Flag("rfc2136-skip-tls-verify", "When using TLS with the RFC2136 provider, disable verification of any TLS certificates").BoolVar(&cfg.RFC2136SkipTLSVerify)
 	app.Flag("rfc2136-load-balancing-strategy", "When using the RFC2136 provider, specify the load balancing strategy (default: disabled, options: random, round-robin, disabled)").Default(defaultConfig.RFC2136LoadBalancingStrategy).EnumVar(&cfg.RFC2136LoadBalancingStrategy, "random", "round-robin", "disabled")
+	app.Flag("rfc2136-notify", "When using the RFC2136 provider, specify a secondary name server (host or host:port) to send a NOTIFY message to after the changes of a zone (optional, can be specified multiple times)").StringsVar(&cfg.RFC2136Notify)
+	app.Flag("rfc2136-ixfr", "When using the RFC2136 provider, fetch only the changes of the zones since the previous sync with IXFR, instead of transferring them entirely with AXFR (default: false, requires --rfc2136-tsig-axfr)").Default(strconv.FormatBool(defaultConfig.RFC2136IXFR)).BoolVar(&cfg.RFC2136IXFR)
+	app.Flag("rfc2136-prerequisites", "When using the RFC2136 provider, add prerequisites to the updates so that the DNS server rejects the changes of the records changed since they were read, which are then read again (default: false, requires --rfc2136-tsig-axfr)").Default(strconv.FormatBool(defaultConfig.RFC2136Prerequisites)).BoolVar(&cfg.RFC2136Prerequisites)
 
 	// Flags related to TransIP provider
 	app.Flag("transip-account", "When using the TransIP provider, specify the account name (required when --provider=transip)").Default(defaultConfig.TransIPAccountName).StringVar(&cfg.TransIPAccountName)
@@ -612,6 +716,32 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("plural-cluster", "When using the plural provider, specify the cluster name you're running with").Default(defaultConfig.PluralCluster).StringVar(&cfg.PluralCluster)
 	app.Flag("plural-provider", "When using the plural provider, specify the provider name you're running with").Default(defaultConfig.PluralProvider).StringVar(&cfg.PluralProvider)
 
+	// Flags related to the zone file provider
+	app.Flag("zonefile-directory", "When using the zone file provider, the directory containing the zone files (mutually exclusive with --zonefile-configmap)").Default(defaultConfig.ZoneFileDirectory).StringVar(&cfg.ZoneFileDirectory)
+	app.Flag("zonefile-configmap", "When using the zone file provider, the namespace/name of the ConfigMap containing the zone files (mutually exclusive with --zonefile-directory)").Default(defaultConfig.ZoneFileConfigMap).StringVar(&cfg.ZoneFileConfigMap)
+
+	// Flags related to the DNS server provider
+	app.Flag("dnsserver-zone", "When using the DNS server provider, a zone the server is authoritative for; specify multiple times for multiple zones (required when --provider=dnsserver)").StringsVar(&cfg.DNSServerZones)
+	app.Flag("dnsserver-nameserver", "When using the DNS server provider, the host name of a name server of the zones, published as their NS records; specify multiple times for multiple name servers (optional)").StringsVar(&cfg.DNSServerNameservers)
+	app.Flag("dnsserver-listen-address", "When using the DNS server provider, the address the server listens on over UDP and TCP (default: :53)").Default(defaultConfig.DNSServerListenAddress).StringVar(&cfg.DNSServerListenAddress)
+
+	// Flags related to the hosts file provider
+	app.Flag("hosts-file", "When using the hosts file provider, the hosts file or dnsmasq configuration file containing the block of records managed by ExternalDNS (required when --provider=hosts)").Default(defaultConfig.HostsFile).StringVar(&cfg.HostsFile)
+	app.Flag("hosts-format", "When using the hosts file provider, the format of the file (default: hosts, options: hosts, dnsmasq)").Default(defaultConfig.HostsFormat).EnumVar(&cfg.HostsFormat, "hosts", "dnsmasq")
+	app.Flag("hosts-reload-command", "When using the hosts file provider, a shell command run after the file has changed to reload the resolver (optional)").Default(defaultConfig.HostsReloadCommand).StringVar(&cfg.HostsReloadCommand)
+	app.Flag("hosts-reload-pid-file", "When using the hosts file provider, a file containing the PID of the resolver, sent a SIGHUP after the file has changed (optional)").Default(defaultConfig.HostsReloadPIDFile).StringVar(&cfg.HostsReloadPIDFile)
+
+	// Flags related to the Technitium provider
+	app.Flag("technitium-server", "When using the Technitium provider, the base URL of the web service of the Technitium DNS Server (required when --provider=technitium)").Default(defaultConfig.TechnitiumServer).StringVar(&cfg.TechnitiumServer)
+	app.Flag("technitium-token", "When using the Technitium provider, the API token used to authenticate to the server (required when --provider=technitium)").Default(defaultConfig.TechnitiumToken).StringVar(&cfg.TechnitiumToken)
+	app.Flag("technitium-skip-tls-verify", "When using the Technitium provider, disable verification of the TLS certificate of the server; the CA and client certificate are specified with --tls-ca, --tls-client-cert and --tls-client-cert-key").BoolVar(&cfg.TechnitiumSkipTLSVerify)
+
+	// Flags related to the AdGuard Home provider
+	app.Flag("adguard-server", "When using the AdGuard Home provider, the base URL of the AdGuard Home web server (required when --provider=adguard)").Default(defaultConfig.AdGuardServer).StringVar(&cfg.AdGuardServer)
+	app.Flag("adguard-username", "When using the AdGuard Home provider, the username to the server if it is protected").Default(defaultConfig.AdGuardUsername).StringVar(&cfg.AdGuardUsername)
+	app.Flag("adguard-password", "When using the AdGuard Home provider, the password to the server if it is protected").Default(defaultConfig.AdGuardPassword).StringVar(&cfg.AdGuardPassword)
+	app.Flag("adguard-tls-skip-verify", "When using the AdGuard Home provider, disable verification of any TLS certificates").BoolVar(&cfg.AdGuardTLSInsecureSkipVerify)
+
 	// Flags related to policies
 	app.Flag("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)").Default(defaultConfig.Policy).EnumVar(&cfg.Policy, "sync", "upsert-only", "create-only")
 
@@ -633,6 +763,8 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
+	app.Flag("incremental-reconcile", "When enabled and all sources can track their changes, skip the reconciliations without changes and only list the DNS records when the desired records changed (default: disabled)").BoolVar(&cfg.IncrementalReconcile)
+	app.Flag("full-resync-interval", "The maximum interval between two full reconciliations when incremental reconciliation is enabled in duration format (default: 1h)").Default(defaultConfig.FullResyncInterval.String()).DurationVar(&cfg.FullResyncInterval)
 
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
@@ -646,5 +778,10 @@ func App(cfg *Config) *kingpin.Application {
 
 	app.Flag("webhook-server", "When enabled, runs as a webhook server instead of a controller. (default: false).").BoolVar(&cfg.WebhookServer)
 
+	app.Flag("admission-webhook-server", "When enabled, runs as a validating admission webhook for external-dns annotations and DNSEndpoint objects, and as the DNSEndpoint conversion webhook, instead of a controller. (default: false).").BoolVar(&cfg.AdmissionWebhookServer)
+	app.Flag("admission-webhook-address", "The address the validating admission webhook listens on (default: :9443)").Default(defaultConfig.AdmissionWebhookAddress).StringVar(&cfg.AdmissionWebhookAddress)
+	app.Flag("admission-webhook-tls-cert-file", "The TLS certificate file of the validating admission webhook").Default(defaultConfig.AdmissionWebhookTLSCertFile).StringVar(&cfg.AdmissionWebhookTLSCertFile)
+	app.Flag("admission-webhook-tls-key-file", "The TLS private key file of the validating admission webhook").Default(defaultConfig.AdmissionWebhookTLSKeyFile).StringVar(&cfg.AdmissionWebhookTLSKeyFile)
+
 	return app
 }
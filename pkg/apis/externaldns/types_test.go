@@ -81,6 +81,7 @@ var (
 		CloudflareDNSRecordsComment:                   "",
 		CloudflareRegionKey:                           "",
 		CoreDNSPrefix:                                 "/skydns/",
+		CoreDNSBackend:                                "etcd",
 		AkamaiServiceConsumerDomain:                   "",
 		AkamaiClientToken:                             "",
 		AkamaiClientSecret:                            "",
@@ -96,6 +97,7 @@ var (
 		PDNSServer:                                    "http://localhost:8081",
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
+		PDNSZoneKind:                                  "Native",
 		Policy:                                        "sync",
 		Registry:                                      "txt",
 		TXTOwnerID:                                    "default",
@@ -106,6 +108,12 @@ var (
 		Once:                                          false,
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
+		FullResyncInterval:                            time.Hour,
+		ProviderRateLimitBurst:                        1,
+		ProviderRetryInitialInterval:                  time.Second,
+		ProviderRetryMaxInterval:                      30 * time.Second,
+		ProviderCircuitBreakerCooldown:                5 * time.Minute,
+		AdmissionWebhookAddress:                       ":9443",
 		LogFormat:                                     "text",
 		MetricsAddress:                                ":7979",
 		LogLevel:                                      logrus.InfoLevel.String(),
@@ -125,6 +133,8 @@ var (
 		RFC2136LoadBalancingStrategy:                  "disabled",
 		OCPRouterName:                                 "default",
 		PiholeApiVersion:                              "5",
+		DNSServerListenAddress:                        ":53",
+		HostsFormat:                                   "hosts",
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
@@ -188,6 +198,9 @@ var (
 		CloudflareRegionalServices:                    true,
 		CloudflareRegionKey:                           "us",
 		CoreDNSPrefix:                                 "/coredns/",
+		CoreDNSBackend:                                "configmap",
+		CoreDNSConfigMap:                              "kube-system/external-dns-coredns",
+		CoreDNSLeaseTTL:                               10 * time.Minute,
 		AkamaiServiceConsumerDomain:                   "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 		AkamaiClientToken:                             "o184671d5307a388180fbf7f11dbdf46",
 		AkamaiClientSecret:                            "o184671d5307a388180fbf7f11dbdf46",
@@ -204,6 +217,12 @@ var (
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "some-secret-key",
 		PDNSSkipTLSVerify:                             true,
+		PDNSZoneParent:                                "tenants.example.com",
+		PDNSZoneNameservers:                           []string{"ns1.example.com", "ns2.example.com"},
+		PDNSZoneKind:                                  "Master",
+		PDNSDNSSEC:                                    true,
+		PDNSRectify:                                   true,
+		PDNSRRSetComments:                             true,
 		TLSCA:                                         "/path/to/ca.crt",
 		TLSClientCert:                                 "/path/to/cert.pem",
 		TLSClientCertKey:                              "/path/to/key.pem",
@@ -218,6 +237,20 @@ var (
 		Once:                                          true,
 		DryRun:                                        true,
 		UpdateEvents:                                  true,
+		IncrementalReconcile:                          true,
+		FullResyncInterval:                            30 * time.Minute,
+		ProviderRateLimit:                             2.5,
+		ProviderRateLimitBurst:                        5,
+		ProviderMaxRetries:                            3,
+		ProviderRetryInitialInterval:                  500 * time.Millisecond,
+		ProviderRetryMaxInterval:                      10 * time.Second,
+		ProviderCircuitBreakerThreshold:               5,
+		ProviderCircuitBreakerCooldown:                time.Minute,
+		AdmissionWebhookServer:                        true,
+		AdmissionWebhookAddress:                       ":8443",
+		AdmissionWebhookTLSCertFile:                   "/etc/tls/tls.crt",
+		AdmissionWebhookTLSKeyFile:                    "/etc/tls/tls.key",
+		ClaimPolicyFile:                               "/etc/external-dns/claims.yaml",
 		LogFormat:                                     "json",
 		MetricsAddress:                                "127.0.0.1:9099",
 		LogLevel:                                      logrus.DebugLevel.String(),
@@ -228,6 +261,10 @@ var (
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		CRDZoneDelegation:                             true,
+		UnstructuredSourceConfig:                      "/etc/external-dns/unstructured.yaml",
+		GatewayListenerSets:                           true,
+		GatewayRouteStatus:                            true,
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		TransIPAccountName:                            "transip",
@@ -237,7 +274,25 @@ var (
 		RFC2136BatchChangeSize:                        100,
 		RFC2136Host:                                   []string{"rfc2136-host1", "rfc2136-host2"},
 		RFC2136LoadBalancingStrategy:                  "round-robin",
+		RFC2136Notify:                                 []string{"rfc2136-secondary1", "rfc2136-secondary2:5353"},
+		RFC2136IXFR:                                   true,
+		RFC2136Prerequisites:                          true,
 		PiholeApiVersion:                              "6",
+		ZoneFileDirectory:                             "/etc/zones",
+		DNSServerZones:                                []string{"example.com", "example.org"},
+		DNSServerNameservers:                          []string{"ns1.example.com"},
+		DNSServerListenAddress:                        ":5353",
+		HostsFile:                                     "/etc/dnsmasq.d/external-dns.conf",
+		HostsFormat:                                   "dnsmasq",
+		HostsReloadCommand:                            "systemctl restart dnsmasq",
+		HostsReloadPIDFile:                            "/run/dnsmasq.pid",
+		TechnitiumServer:                              "https://dns.example.com:53443",
+		TechnitiumToken:                               "technitium-token",
+		TechnitiumSkipTLSVerify:                       true,
+		AdGuardServer:                                 "http://adguard.example.com:3000",
+		AdGuardUsername:                               "admin",
+		AdGuardPassword:                               "adguard-password",
+		AdGuardTLSInsecureSkipVerify:                  true,
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
@@ -298,6 +353,9 @@ func TestParseFlags(t *testing.T) {
 				"--cloudflare-regional-services",
 				"--cloudflare-region-key=us",
 				"--coredns-prefix=/coredns/",
+				"--coredns-backend=configmap",
+				"--coredns-configmap=kube-system/external-dns-coredns",
+				"--coredns-lease-ttl=10m",
 				"--akamai-serviceconsumerdomain=oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 				"--akamai-client-token=o184671d5307a388180fbf7f11dbdf46",
 				"--akamai-client-secret=o184671d5307a388180fbf7f11dbdf46",
@@ -312,6 +370,13 @@ func TestParseFlags(t *testing.T) {
 				"--pdns-server-id=localhost",
 				"--pdns-api-key=some-secret-key",
 				"--pdns-skip-tls-verify",
+				"--pdns-zone-parent=tenants.example.com",
+				"--pdns-zone-nameserver=ns1.example.com",
+				"--pdns-zone-nameserver=ns2.example.com",
+				"--pdns-zone-kind=Master",
+				"--pdns-dnssec",
+				"--pdns-rectify",
+				"--pdns-rrset-comments",
 				"--oci-config-file=oci.yaml",
 				"--oci-zone-scope=PRIVATE",
 				"--oci-zones-cache-duration=30s",
@@ -352,6 +417,22 @@ func TestParseFlags(t *testing.T) {
 				"--aws-sd-create-tag=key2=value2",
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
+				"--zonefile-directory=/etc/zones",
+				"--dnsserver-zone=example.com",
+				"--dnsserver-zone=example.org",
+				"--dnsserver-nameserver=ns1.example.com",
+				"--dnsserver-listen-address=:5353",
+				"--hosts-file=/etc/dnsmasq.d/external-dns.conf",
+				"--hosts-format=dnsmasq",
+				"--hosts-reload-command=systemctl restart dnsmasq",
+				"--hosts-reload-pid-file=/run/dnsmasq.pid",
+				"--technitium-server=https://dns.example.com:53443",
+				"--technitium-token=technitium-token",
+				"--technitium-skip-tls-verify",
+				"--adguard-server=http://adguard.example.com:3000",
+				"--adguard-username=admin",
+				"--adguard-password=adguard-password",
+				"--adguard-tls-skip-verify",
 				"--policy=upsert-only",
 				"--registry=noop",
 				"--txt-owner-id=owner-1",
@@ -363,6 +444,20 @@ func TestParseFlags(t *testing.T) {
 				"--once",
 				"--dry-run",
 				"--events",
+				"--incremental-reconcile",
+				"--full-resync-interval=30m",
+				"--provider-rate-limit=2.5",
+				"--provider-rate-limit-burst=5",
+				"--provider-max-retries=3",
+				"--provider-retry-initial-interval=500ms",
+				"--provider-retry-max-interval=10s",
+				"--provider-circuit-breaker-threshold=5",
+				"--provider-circuit-breaker-cooldown=1m",
+				"--admission-webhook-server",
+				"--admission-webhook-address=:8443",
+				"--admission-webhook-tls-cert-file=/etc/tls/tls.crt",
+				"--admission-webhook-tls-key-file=/etc/tls/tls.key",
+				"--claim-policy-file=/etc/external-dns/claims.yaml",
 				"--log-format=json",
 				"--metrics-address=127.0.0.1:9099",
 				"--log-level=debug",
@@ -373,6 +468,10 @@ func TestParseFlags(t *testing.T) {
 				"--exoscale-apisecret=2",
 				"--crd-source-apiversion=test.k8s.io/v1alpha1",
 				"--crd-source-kind=Endpoint",
+				"--crd-zone-delegation",
+				"--unstructured-source-config=/etc/external-dns/unstructured.yaml",
+				"--gateway-listener-sets",
+				"--gateway-route-status",
 				"--ns1-endpoint=https://api.example.com/v1",
 				"--ns1-ignoressl",
 				"--transip-account=transip",
@@ -387,6 +486,10 @@ func TestParseFlags(t *testing.T) {
 				"--rfc2136-load-balancing-strategy=round-robin",
 				"--rfc2136-host=rfc2136-host1",
 				"--rfc2136-host=rfc2136-host2",
+				"--rfc2136-notify=rfc2136-secondary1",
+				"--rfc2136-notify=rfc2136-secondary2:5353",
+				"--rfc2136-ixfr",
+				"--rfc2136-prerequisites",
 			},
 			envVars:  map[string]string{},
 			expected: overriddenConfig,
@@ -426,6 +529,9 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_CLOUDFLARE_REGIONAL_SERVICES":                      "1",
 				"EXTERNAL_DNS_CLOUDFLARE_REGION_KEY":                             "us",
 				"EXTERNAL_DNS_COREDNS_PREFIX":                                    "/coredns/",
+				"EXTERNAL_DNS_COREDNS_BACKEND":                                   "configmap",
+				"EXTERNAL_DNS_COREDNS_CONFIGMAP":                                 "kube-system/external-dns-coredns",
+				"EXTERNAL_DNS_COREDNS_LEASE_TTL":                                 "10m",
 				"EXTERNAL_DNS_AKAMAI_SERVICECONSUMERDOMAIN":                      "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 				"EXTERNAL_DNS_AKAMAI_CLIENT_TOKEN":                               "o184671d5307a388180fbf7f11dbdf46",
 				"EXTERNAL_DNS_AKAMAI_CLIENT_SECRET":                              "o184671d5307a388180fbf7f11dbdf46",
@@ -449,6 +555,12 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_PDNS_ID":                                           "localhost",
 				"EXTERNAL_DNS_PDNS_API_KEY":                                      "some-secret-key",
 				"EXTERNAL_DNS_PDNS_SKIP_TLS_VERIFY":                              "1",
+				"EXTERNAL_DNS_PDNS_ZONE_PARENT":                                  "tenants.example.com",
+				"EXTERNAL_DNS_PDNS_ZONE_NAMESERVER":                              "ns1.example.com\nns2.example.com",
+				"EXTERNAL_DNS_PDNS_ZONE_KIND":                                    "Master",
+				"EXTERNAL_DNS_PDNS_DNSSEC":                                       "1",
+				"EXTERNAL_DNS_PDNS_RECTIFY":                                      "1",
+				"EXTERNAL_DNS_PDNS_RRSET_COMMENTS":                               "1",
 				"EXTERNAL_DNS_RDNS_ROOT_DOMAIN":                                  "lb.rancher.cloud",
 				"EXTERNAL_DNS_TLS_CA":                                            "/path/to/ca.crt",
 				"EXTERNAL_DNS_TLS_CLIENT_CERT":                                   "/path/to/cert.pem",
@@ -473,6 +585,21 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
+				"EXTERNAL_DNS_ZONEFILE_DIRECTORY":                                "/etc/zones",
+				"EXTERNAL_DNS_DNSSERVER_ZONE":                                    "example.com\nexample.org",
+				"EXTERNAL_DNS_DNSSERVER_NAMESERVER":                              "ns1.example.com",
+				"EXTERNAL_DNS_DNSSERVER_LISTEN_ADDRESS":                          ":5353",
+				"EXTERNAL_DNS_HOSTS_FILE":                                        "/etc/dnsmasq.d/external-dns.conf",
+				"EXTERNAL_DNS_HOSTS_FORMAT":                                      "dnsmasq",
+				"EXTERNAL_DNS_HOSTS_RELOAD_COMMAND":                              "systemctl restart dnsmasq",
+				"EXTERNAL_DNS_HOSTS_RELOAD_PID_FILE":                             "/run/dnsmasq.pid",
+				"EXTERNAL_DNS_TECHNITIUM_SERVER":                                 "https://dns.example.com:53443",
+				"EXTERNAL_DNS_TECHNITIUM_TOKEN":                                  "technitium-token",
+				"EXTERNAL_DNS_TECHNITIUM_SKIP_TLS_VERIFY":                        "1",
+				"EXTERNAL_DNS_ADGUARD_SERVER":                                    "http://adguard.example.com:3000",
+				"EXTERNAL_DNS_ADGUARD_USERNAME":                                  "admin",
+				"EXTERNAL_DNS_ADGUARD_PASSWORD":                                  "adguard-password",
+				"EXTERNAL_DNS_ADGUARD_TLS_SKIP_VERIFY":                           "1",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
 				"EXTERNAL_DNS_REGISTRY":                                          "noop",
 				"EXTERNAL_DNS_TXT_OWNER_ID":                                      "owner-1",
@@ -484,6 +611,20 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_ONCE":                                              "1",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
+				"EXTERNAL_DNS_INCREMENTAL_RECONCILE":                             "1",
+				"EXTERNAL_DNS_FULL_RESYNC_INTERVAL":                              "30m",
+				"EXTERNAL_DNS_PROVIDER_RATE_LIMIT":                               "2.5",
+				"EXTERNAL_DNS_PROVIDER_RATE_LIMIT_BURST":                         "5",
+				"EXTERNAL_DNS_PROVIDER_MAX_RETRIES":                              "3",
+				"EXTERNAL_DNS_PROVIDER_RETRY_INITIAL_INTERVAL":                   "500ms",
+				"EXTERNAL_DNS_PROVIDER_RETRY_MAX_INTERVAL":                       "10s",
+				"EXTERNAL_DNS_PROVIDER_CIRCUIT_BREAKER_THRESHOLD":                "5",
+				"EXTERNAL_DNS_PROVIDER_CIRCUIT_BREAKER_COOLDOWN":                 "1m",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_SERVER":                          "1",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_ADDRESS":                         ":8443",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_TLS_CERT_FILE":                   "/etc/tls/tls.crt",
+				"EXTERNAL_DNS_ADMISSION_WEBHOOK_TLS_KEY_FILE":                    "/etc/tls/tls.key",
+				"EXTERNAL_DNS_CLAIM_POLICY_FILE":                                 "/etc/external-dns/claims.yaml",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
@@ -494,6 +635,10 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_EXOSCALE_APISECRET":                                "2",
 				"EXTERNAL_DNS_CRD_SOURCE_APIVERSION":                             "test.k8s.io/v1alpha1",
 				"EXTERNAL_DNS_CRD_SOURCE_KIND":                                   "Endpoint",
+				"EXTERNAL_DNS_CRD_ZONE_DELEGATION":                               "1",
+				"EXTERNAL_DNS_UNSTRUCTURED_SOURCE_CONFIG":                        "/etc/external-dns/unstructured.yaml",
+				"EXTERNAL_DNS_GATEWAY_LISTENER_SETS":                             "1",
+				"EXTERNAL_DNS_GATEWAY_ROUTE_STATUS":                              "1",
 				"EXTERNAL_DNS_NS1_ENDPOINT":                                      "https://api.example.com/v1",
 				"EXTERNAL_DNS_NS1_IGNORESSL":                                     "1",
 				"EXTERNAL_DNS_TRANSIP_ACCOUNT":                                   "transip",
@@ -504,6 +649,9 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_RFC2136_BATCH_CHANGE_SIZE":                         "100",
 				"EXTERNAL_DNS_RFC2136_LOAD_BALANCING_STRATEGY":                   "round-robin",
 				"EXTERNAL_DNS_RFC2136_HOST":                                      "rfc2136-host1\nrfc2136-host2",
+				"EXTERNAL_DNS_RFC2136_NOTIFY":                                    "rfc2136-secondary1\nrfc2136-secondary2:5353",
+				"EXTERNAL_DNS_RFC2136_IXFR":                                      "1",
+				"EXTERNAL_DNS_RFC2136_PREREQUISITES":                             "1",
 			},
 			expected: overriddenConfig,
 		},
@@ -45,6 +45,10 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if cfg.AdmissionWebhookServer && (cfg.AdmissionWebhookTLSCertFile == "" || cfg.AdmissionWebhookTLSKeyFile == "") {
+		return errors.New("--admission-webhook-tls-cert-file and --admission-webhook-tls-key-file must be set when running as an admission webhook")
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
@@ -73,6 +77,20 @@ func validateConfigForProvider(cfg *externaldns.Config) error {
 		return validateConfigForAkamai(cfg)
 	case "rfc2136":
 		return validateConfigForRfc2136(cfg)
+	case "zonefile":
+		return validateConfigForZoneFile(cfg)
+	case "hosts":
+		return validateConfigForHosts(cfg)
+	case "technitium":
+		return validateConfigForTechnitium(cfg)
+	case "adguard":
+		return validateConfigForAdGuard(cfg)
+	case "pdns":
+		return validateConfigForPDNS(cfg)
+	case "dnsserver":
+		return validateConfigForDNSServer(cfg)
+	case "coredns", "skydns":
+		return validateConfigForCoreDNS(cfg)
 	default:
 		return nil
 	}
@@ -116,5 +134,85 @@ func validateConfigForRfc2136(cfg *externaldns.Config) error {
 	if cfg.RFC2136BatchChangeSize < 1 {
 		return errors.New("batch size specified for rfc2136 cannot be less than 1")
 	}
+	if cfg.RFC2136IXFR && !cfg.RFC2136TAXFR {
+		return errors.New("--rfc2136-ixfr requires --rfc2136-tsig-axfr, as the zones are first transferred entirely")
+	}
+	if cfg.RFC2136Prerequisites && !cfg.RFC2136TAXFR {
+		return errors.New("--rfc2136-prerequisites requires --rfc2136-tsig-axfr, as the prerequisites are the records read from the zones")
+	}
+	return nil
+}
+
+func validateConfigForZoneFile(cfg *externaldns.Config) error {
+	if (cfg.ZoneFileDirectory == "") == (cfg.ZoneFileConfigMap == "") {
+		return errors.New("exactly one of --zonefile-directory and --zonefile-configmap must be specified")
+	}
+	return nil
+}
+
+func validateConfigForDNSServer(cfg *externaldns.Config) error {
+	if len(cfg.DNSServerZones) == 0 {
+		return errors.New("no zones specified for the DNS server, use --dnsserver-zone")
+	}
+	return nil
+}
+
+func validateConfigForHosts(cfg *externaldns.Config) error {
+	if cfg.HostsFile == "" {
+		return errors.New("no hosts file specified, use --hosts-file")
+	}
+	return nil
+}
+
+func validateConfigForTechnitium(cfg *externaldns.Config) error {
+	if cfg.TechnitiumServer == "" {
+		return errors.New("no Technitium server specified, use --technitium-server")
+	}
+	if cfg.TechnitiumToken == "" {
+		return errors.New("no Technitium API token specified, use --technitium-token")
+	}
+	return nil
+}
+
+func validateConfigForPDNS(cfg *externaldns.Config) error {
+	if cfg.PDNSZoneParent == "" {
+		if len(cfg.PDNSZoneNameservers) > 0 || cfg.PDNSDNSSEC {
+			return errors.New("--pdns-zone-nameserver and --pdns-dnssec apply to the zones created below --pdns-zone-parent, which is not specified")
+		}
+		return nil
+	}
+	if len(cfg.PDNSZoneNameservers) == 0 {
+		return errors.New("no name servers specified for the zones created below --pdns-zone-parent, use --pdns-zone-nameserver")
+	}
+	return nil
+}
+
+func validateConfigForAdGuard(cfg *externaldns.Config) error {
+	if cfg.AdGuardServer == "" {
+		return errors.New("no AdGuard Home server specified, use --adguard-server")
+	}
+	if cfg.Registry != "noop" {
+		return errors.New("the AdGuard Home provider tracks ownership with companion rewrites, use --registry=noop")
+	}
+	return nil
+}
+
+func validateConfigForCoreDNS(cfg *externaldns.Config) error {
+	if cfg.CoreDNSBackend == "configmap" && cfg.CoreDNSConfigMap == "" {
+		return errors.New("no ConfigMap specified for the CoreDNS configmap backend, use --coredns-configmap")
+	}
+	if cfg.CoreDNSLeaseTTL > 0 {
+		if cfg.CoreDNSBackend != "etcd" {
+			return errors.New("--coredns-lease-ttl is only supported by the CoreDNS etcd backend")
+		}
+		// The lease is refreshed when the records are listed, which is skipped while they are cached,
+		// and by incremental reconciliations until the first synchronization after the full resync interval.
+		if cfg.CoreDNSLeaseTTL <= max(cfg.Interval, cfg.TXTCacheInterval, cfg.ProviderCacheTime) {
+			return errors.New("--coredns-lease-ttl must be greater than --interval, --txt-cache-interval and --provider-cache-time")
+		}
+		if cfg.IncrementalReconcile && cfg.CoreDNSLeaseTTL <= cfg.FullResyncInterval+cfg.Interval {
+			return errors.New("--coredns-lease-ttl must be greater than --full-resync-interval plus --interval with --incremental-reconcile")
+		}
+	}
 	return nil
 }
@@ -18,6 +18,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 
@@ -94,6 +95,16 @@ func TestValidateBadIgnoreHostnameAnnotationsConfig(t *testing.T) {
 	assert.Error(t, ValidateConfig(cfg))
 }
 
+func TestValidateAdmissionWebhookConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.AdmissionWebhookServer = true
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.AdmissionWebhookTLSCertFile = "/etc/tls/tls.crt"
+	cfg.AdmissionWebhookTLSKeyFile = "/etc/tls/tls.key"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
 func TestValidateBadRfc2136Config(t *testing.T) {
 	cfg := externaldns.NewConfig()
 
@@ -137,6 +148,40 @@ func TestValidateGoodRfc2136Config(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateBadRfc2136IXFRConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "rfc2136"
+	cfg.RFC2136BatchChangeSize = 50
+	cfg.RFC2136IXFR = true
+	cfg.RFC2136TAXFR = false
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "--rfc2136-ixfr requires --rfc2136-tsig-axfr")
+
+	cfg.RFC2136TAXFR = true
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateBadRfc2136PrerequisitesConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "rfc2136"
+	cfg.RFC2136BatchChangeSize = 50
+	cfg.RFC2136Prerequisites = true
+	cfg.RFC2136TAXFR = false
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "--rfc2136-prerequisites requires --rfc2136-tsig-axfr")
+
+	cfg.RFC2136TAXFR = true
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
 func TestValidateBadRfc2136GssTsigConfig(t *testing.T) {
 	invalidRfc2136GssTsigConfigs := []*externaldns.Config{
 		{
@@ -351,3 +396,143 @@ func TestValidateGoodAzureConfig(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestValidateBadZoneFileConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "zonefile"
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.ZoneFileDirectory = "/etc/zones"
+	cfg.ZoneFileConfigMap = "dns/zones"
+
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateGoodZoneFileConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "zonefile"
+	cfg.ZoneFileConfigMap = "dns/zones"
+
+	err := ValidateConfig(cfg)
+
+	assert.NoError(t, err)
+}
+
+func TestValidateDNSServerConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "dnsserver"
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.DNSServerZones = []string{"example.com"}
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateHostsConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = "hosts"
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "no hosts file specified")
+
+	cfg.HostsFile = "/etc/hosts"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateTechnitiumConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = "technitium"
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "no Technitium server specified")
+
+	cfg.TechnitiumServer = "https://dns.example.com:53443"
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "no Technitium API token specified")
+
+	cfg.TechnitiumToken = "technitium-token"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidatePDNSConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = "pdns"
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.PDNSDNSSEC = true
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "--pdns-zone-parent, which is not specified")
+
+	cfg.PDNSZoneParent = "tenants.example.com"
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "use --pdns-zone-nameserver")
+
+	cfg.PDNSZoneNameservers = []string{"ns1.example.com"}
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateAdGuardConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = "adguard"
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "no AdGuard Home server specified")
+
+	cfg.AdGuardServer = "http://adguard.example.com:3000"
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "use --registry=noop")
+
+	cfg.Registry = "noop"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateCoreDNSConfig(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = "coredns"
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.CoreDNSBackend = "configmap"
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.CoreDNSConfigMap = "kube-system/external-dns-coredns"
+
+	assert.NoError(t, ValidateConfig(cfg))
+	cfg.CoreDNSLeaseTTL = 5 * time.Minute
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "only supported by the CoreDNS etcd backend")
+
+	cfg.CoreDNSBackend = "etcd"
+	cfg.Interval = time.Minute
+	cfg.TXTCacheInterval = 10 * time.Minute
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "must be greater than --interval")
+
+	cfg.TXTCacheInterval = 0
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	// Incremental reconciliations do not list the records until the next full one.
+	cfg.IncrementalReconcile = true
+	cfg.FullResyncInterval = time.Hour
+
+	assert.ErrorContains(t, ValidateConfig(cfg), "must be greater than --full-resync-interval plus --interval")
+
+	cfg.CoreDNSLeaseTTL = 2 * time.Hour
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
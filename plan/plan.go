@@ -50,6 +50,9 @@ type Plan struct {
 	ExcludeRecords []string
 	// OwnerID of records to manage
 	OwnerID string
+	// AffectedNames restricts the calculation to records with these DNS names.
+	// All records are considered if empty.
+	AffectedNames []string
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -172,11 +175,18 @@ func (p *Plan) Calculate() *Plan {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
 	}
 
-	for _, current := range filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
-		t.addCurrent(current)
+	current := filterRecordsForPlan(p.Current, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords)
+	desired := filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords)
+	if len(p.AffectedNames) > 0 {
+		current = filterRecordsByName(current, p.AffectedNames)
+		desired = filterRecordsByName(desired, p.AffectedNames)
 	}
-	for _, desired := range filterRecordsForPlan(p.Desired, p.DomainFilter, p.ManagedRecords, p.ExcludeRecords) {
-		t.addCandidate(desired)
+
+	for _, record := range current {
+		t.addCurrent(record)
+	}
+	for _, record := range desired {
+		t.addCandidate(record)
 	}
 
 	changes := &Changes{}
@@ -339,6 +349,22 @@ func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.Ma
 	return filtered
 }
 
+// filterRecordsByName removes records whose DNS name is not one of the given names.
+func filterRecordsByName(records []*endpoint.Endpoint, names []string) []*endpoint.Endpoint {
+	allowed := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		allowed[normalizeDNSName(name)] = struct{}{}
+	}
+
+	filtered := []*endpoint.Endpoint{}
+	for _, record := range records {
+		if _, ok := allowed[normalizeDNSName(record.DNSName)]; ok {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
 // normalizeDNSName converts a DNS name to a canonical form, so that we can use string equality
 // it: removes space, get ASCII version of dnsName complient with Section 5 of RFC 5891, ensures there is a trailing dot
 func normalizeDNSName(dnsName string) string {
@@ -1028,6 +1028,34 @@ func validateEntries(t *testing.T, entries, expected []*endpoint.Endpoint) {
 	}
 }
 
+func TestPlanAffectedNames(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("drifted.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("deleted.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("orphaned.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("changed.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("drifted.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("created.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		AffectedNames:  []string{"changed.example.org", "created.example.org.", "deleted.example.org"},
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(t, changes.Create, []*endpoint.Endpoint{desired[2]})
+	validateEntries(t, changes.UpdateOld, []*endpoint.Endpoint{current[0]})
+	validateEntries(t, changes.UpdateNew, []*endpoint.Endpoint{desired[0]})
+	validateEntries(t, changes.Delete, []*endpoint.Endpoint{current[2]})
+}
+
 func TestNormalizeDNSName(t *testing.T) {
 	records := []struct {
 		dnsName string
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ErrNoAdGuardServer is returned when there is no AdGuard Home server configured
+// in the environment.
+var ErrNoAdGuardServer = errors.New("no AdGuard Home server found in the environment or flags")
+
+const (
+	// The companion rewrite of a domain owned by ExternalDNS is named
+	// externaldns-<owner>.<domain>, or externaldns-<owner>-wildcard.<domain> for *.<domain>.
+	companionPrefix          = "externaldns-"
+	companionWildcardSuffix  = "-wildcard"
+	companionAnswer          = "external-dns.invalid"
+	rewriteAnswerKeepA       = "A"
+	rewriteAnswerKeepAAAA    = "AAAA"
+	wildcardPrefix           = "*."
+	ownerIDValidationPattern = `^[a-z0-9]([a-z0-9-]{0,40}[a-z0-9])?$`
+)
+
+var ownerIDRegexp = regexp.MustCompile(ownerIDValidationPattern)
+
+// AdGuardProvider is an implementation of Provider for AdGuard Home DNS rewrites.
+type AdGuardProvider struct {
+	provider.BaseProvider
+	api          adguardAPI
+	ownerID      string
+	domainFilter *endpoint.DomainFilter
+	dryRun       bool
+}
+
+// AdGuardConfig is used for configuring an AdGuardProvider.
+type AdGuardConfig struct {
+	// The root URL of the AdGuard Home server.
+	Server string
+	// The username and password of the server, if it is protected.
+	Username string
+	Password string
+	// Disable verification of TLS certificates.
+	TLSInsecureSkipVerify bool
+	// The owner ID naming the companion rewrites of the domains managed by this instance.
+	OwnerID string
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// NewAdGuardProvider initializes a new AdGuard Home DNS rewrites based Provider.
+func NewAdGuardProvider(cfg AdGuardConfig) (*AdGuardProvider, error) {
+	if !ownerIDRegexp.MatchString(cfg.OwnerID) {
+		return nil, fmt.Errorf("owner ID %q must be a lower case DNS label of at most 42 characters to name the companion rewrites", cfg.OwnerID)
+	}
+	api, err := newAdGuardClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	domainFilter := cfg.DomainFilter
+	if domainFilter == nil {
+		domainFilter = &endpoint.DomainFilter{}
+	}
+	return &AdGuardProvider{
+		api:          api,
+		ownerID:      cfg.OwnerID,
+		domainFilter: domainFilter,
+		dryRun:       cfg.DryRun,
+	}, nil
+}
+
+// companionDomain returns the domain of the companion rewrite marking a domain as owned.
+func (p *AdGuardProvider) companionDomain(domain string) string {
+	if rest, ok := strings.CutPrefix(domain, wildcardPrefix); ok {
+		return companionPrefix + p.ownerID + companionWildcardSuffix + "." + rest
+	}
+	return companionPrefix + p.ownerID + "." + domain
+}
+
+// isCompanion returns whether a rewrite is the companion rewrite of a domain, of any owner.
+func isCompanion(r rewrite) bool {
+	return r.Answer == companionAnswer && strings.HasPrefix(r.Domain, companionPrefix)
+}
+
+// recordType returns the record type of the answer of a rewrite, or an empty string for the
+// answers keeping the records of the upstream servers.
+func recordType(answer string) string {
+	if answer == rewriteAnswerKeepA || answer == rewriteAnswerKeepAAAA {
+		return ""
+	}
+	addr, err := netip.ParseAddr(answer)
+	switch {
+	case err != nil:
+		return endpoint.RecordTypeCNAME
+	case addr.Is4():
+		return endpoint.RecordTypeA
+	default:
+		return endpoint.RecordTypeAAAA
+	}
+}
+
+// supportedRecordType returns whether records of the type can be written as DNS rewrites.
+func supportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME:
+		return true
+	}
+	return false
+}
+
+// rewriteSet is the state of the rewrites of the server.
+type rewriteSet struct {
+	rewrites   map[rewrite]bool
+	companions map[string]bool
+}
+
+func newRewriteSet(rewrites []rewrite) *rewriteSet {
+	s := &rewriteSet{rewrites: map[rewrite]bool{}, companions: map[string]bool{}}
+	for _, r := range rewrites {
+		if isCompanion(r) {
+			s.companions[r.Domain] = true
+		} else {
+			s.rewrites[r] = true
+		}
+	}
+	return s
+}
+
+// hasRewrites returns whether a domain has rewrites, other than its companion rewrites.
+func (s *rewriteSet) hasRewrites(domain string) bool {
+	for r := range s.rewrites {
+		if r.Domain == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// Records implements Provider, populating a slice of endpoints from the
+// AdGuard Home DNS rewrites of the domains owned by this instance.
+func (p *AdGuardProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	rewrites, err := p.api.listRewrites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	companions := newRewriteSet(rewrites).companions
+
+	var endpoints []*endpoint.Endpoint
+	byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, r := range rewrites {
+		if isCompanion(r) || !companions[p.companionDomain(r.Domain)] || !p.domainFilter.Match(r.Domain) {
+			continue
+		}
+		rtype := recordType(r.Answer)
+		if rtype == "" {
+			continue
+		}
+		key := endpoint.EndpointKey{DNSName: r.Domain, RecordType: rtype}
+		if ep, ok := byKey[key]; ok {
+			ep.Targets = append(ep.Targets, r.Answer)
+			continue
+		}
+		ep := endpoint.NewEndpoint(r.Domain, rtype, r.Answer)
+		byKey[key] = ep
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints drops the records that cannot be written as DNS rewrites, which are A,
+// AAAA and CNAME records without TTL.
+func (p *AdGuardProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !supportedRecordType(ep.RecordType) {
+			log.Debugf("Skipping record %s of type %s unsupported by AdGuard Home", ep.DNSName, ep.RecordType)
+			continue
+		}
+		ep.RecordTTL = 0
+		adjusted = append(adjusted, ep)
+	}
+	return adjusted, nil
+}
+
+// ApplyChanges implements Provider, syncing desired state with the AdGuard Home DNS rewrites.
+// The companion rewrite of a domain is added before its first rewrite, and deleted after its
+// last one, so that the rewrites of a domain are never left without owner.
+func (p *AdGuardProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if !changes.HasChanges() {
+		return nil
+	}
+	rewrites, err := p.api.listRewrites(ctx)
+	if err != nil {
+		return err
+	}
+	current := newRewriteSet(rewrites)
+
+	deleted := p.rewritesOf(changes.Delete, changes.UpdateOld)
+	added := p.rewritesOf(changes.Create, changes.UpdateNew)
+	// The rewrites both deleted and added are left as they are.
+	var toDelete, toAdd []rewrite
+	for _, r := range deleted {
+		if !slices.Contains(added, r) {
+			toDelete = append(toDelete, r)
+		}
+	}
+	for _, r := range added {
+		if slices.Contains(deleted, r) {
+			continue
+		}
+		owned := current.companions[p.companionDomain(r.Domain)]
+		if !owned && current.hasRewrites(r.Domain) {
+			log.Warnf("Skipping rewrite %s -> %s because %s has rewrites not owned by %s", r.Domain, r.Answer, r.Domain, p.ownerID)
+			continue
+		}
+		toAdd = append(toAdd, r)
+	}
+
+	var addedCompanions, deletedCompanions []rewrite
+	final := newRewriteSet(rewrites)
+	for _, r := range toDelete {
+		delete(final.rewrites, r)
+	}
+	for _, r := range toAdd {
+		final.rewrites[r] = true
+		companion := rewrite{Domain: p.companionDomain(r.Domain), Answer: companionAnswer}
+		if !current.companions[companion.Domain] && !slices.Contains(addedCompanions, companion) {
+			addedCompanions = append(addedCompanions, companion)
+		}
+	}
+	for _, r := range toDelete {
+		companion := rewrite{Domain: p.companionDomain(r.Domain), Answer: companionAnswer}
+		if current.companions[companion.Domain] && !final.hasRewrites(r.Domain) && !slices.Contains(deletedCompanions, companion) {
+			deletedCompanions = append(deletedCompanions, companion)
+		}
+	}
+
+	steps := []struct {
+		action   string
+		rewrites []rewrite
+		apply    func(context.Context, rewrite) error
+	}{
+		{"Adding companion", addedCompanions, p.api.addRewrite},
+		{"Deleting", toDelete, p.api.deleteRewrite},
+		{"Adding", toAdd, p.api.addRewrite},
+		{"Deleting companion", deletedCompanions, p.api.deleteRewrite},
+	}
+	for _, step := range steps {
+		for _, r := range step.rewrites {
+			if p.dryRun {
+				log.Infof("DRY RUN: %s rewrite %s -> %s", step.action, r.Domain, r.Answer)
+				continue
+			}
+			log.Infof("%s rewrite %s -> %s", step.action, r.Domain, r.Answer)
+			if err := step.apply(ctx, r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewritesOf returns the rewrites of the targets of the endpoints matching the domain filter.
+func (p *AdGuardProvider) rewritesOf(endpoints ...[]*endpoint.Endpoint) []rewrite {
+	var rewrites []rewrite
+	for _, eps := range endpoints {
+		for _, ep := range eps {
+			if !p.domainFilter.Match(ep.DNSName) {
+				log.Debugf("Skipping %s that does not match domain filter", ep.DNSName)
+				continue
+			}
+			if !supportedRecordType(ep.RecordType) {
+				log.Debugf("Skipping record %s of type %s unsupported by AdGuard Home", ep.DNSName, ep.RecordType)
+				continue
+			}
+			for _, target := range ep.Targets {
+				r := rewrite{Domain: ep.DNSName, Answer: target}
+				if !slices.Contains(rewrites, r) {
+					rewrites = append(rewrites, r)
+				}
+			}
+		}
+	}
+	return rewrites
+}
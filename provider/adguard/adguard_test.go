@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type testAdGuardClient struct {
+	rewrites []rewrite
+	requests []string
+}
+
+func (t *testAdGuardClient) listRewrites(ctx context.Context) ([]rewrite, error) {
+	return slices.Clone(t.rewrites), nil
+}
+
+func (t *testAdGuardClient) addRewrite(ctx context.Context, r rewrite) error {
+	t.rewrites = append(t.rewrites, r)
+	t.requests = append(t.requests, "add "+r.Domain+" "+r.Answer)
+	return nil
+}
+
+func (t *testAdGuardClient) deleteRewrite(ctx context.Context, r rewrite) error {
+	t.rewrites = slices.DeleteFunc(t.rewrites, func(existing rewrite) bool { return existing == r })
+	t.requests = append(t.requests, "delete "+r.Domain+" "+r.Answer)
+	return nil
+}
+
+func newTestProvider(client *testAdGuardClient, domainFilter *endpoint.DomainFilter) *AdGuardProvider {
+	if domainFilter == nil {
+		domainFilter = &endpoint.DomainFilter{}
+	}
+	return &AdGuardProvider{api: client, ownerID: "default", domainFilter: domainFilter}
+}
+
+func TestNewAdGuardProvider(t *testing.T) {
+	_, err := NewAdGuardProvider(AdGuardConfig{OwnerID: "default"})
+	assert.ErrorIs(t, err, ErrNoAdGuardServer)
+
+	for _, ownerID := range []string{"", "my.cluster", "My-Cluster", "-cluster"} {
+		_, err = NewAdGuardProvider(AdGuardConfig{Server: "http://adguard.example.com", OwnerID: ownerID})
+		assert.ErrorContains(t, err, "must be a lower case DNS label", ownerID)
+	}
+
+	p, err := NewAdGuardProvider(AdGuardConfig{Server: "http://adguard.example.com", OwnerID: "my-cluster"})
+	require.NoError(t, err)
+	assert.Equal(t, "externaldns-my-cluster.www.example.com", p.companionDomain("www.example.com"))
+	assert.Equal(t, "externaldns-my-cluster-wildcard.apps.example.com", p.companionDomain("*.apps.example.com"))
+}
+
+func TestAdGuardProviderRecords(t *testing.T) {
+	client := &testAdGuardClient{rewrites: []rewrite{
+		{Domain: "externaldns-default.www.example.com", Answer: companionAnswer},
+		{Domain: "www.example.com", Answer: "192.0.2.1"},
+		{Domain: "www.example.com", Answer: "192.0.2.2"},
+		{Domain: "www.example.com", Answer: "2001:db8::1"},
+		{Domain: "externaldns-default-wildcard.apps.example.com", Answer: companionAnswer},
+		{Domain: "*.apps.example.com", Answer: "www.example.com"},
+		{Domain: "externaldns-default.upstream.example.com", Answer: companionAnswer},
+		{Domain: "upstream.example.com", Answer: rewriteAnswerKeepAAAA},
+		{Domain: "externaldns-other.other.example.com", Answer: companionAnswer},
+		{Domain: "other.example.com", Answer: "192.0.2.3"},
+		{Domain: "manual.example.com", Answer: "192.0.2.4"},
+		{Domain: "externaldns-default.www.example.org", Answer: companionAnswer},
+		{Domain: "www.example.org", Answer: "192.0.2.5"},
+	}}
+	p := newTestProvider(client, endpoint.NewDomainFilter([]string{"example.com"}))
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+	}, records)
+}
+
+func TestAdGuardProviderApplyChanges(t *testing.T) {
+	client := &testAdGuardClient{rewrites: []rewrite{
+		{Domain: "externaldns-default.www.example.com", Answer: companionAnswer},
+		{Domain: "www.example.com", Answer: "192.0.2.1"},
+		{Domain: "www.example.com", Answer: "192.0.2.2"},
+		{Domain: "externaldns-default.old.example.com", Answer: companionAnswer},
+		{Domain: "old.example.com", Answer: "192.0.2.3"},
+		{Domain: "manual.example.com", Answer: "192.0.2.4"},
+	}}
+	p := newTestProvider(client, nil)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpoint("manual.example.com", endpoint.RecordTypeA, "192.0.2.5"),
+		},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2", "192.0.2.6")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.3")},
+	}))
+	assert.Equal(t, []string{
+		"add externaldns-default-wildcard.apps.example.com external-dns.invalid",
+		"delete old.example.com 192.0.2.3",
+		"delete www.example.com 192.0.2.1",
+		"add *.apps.example.com www.example.com",
+		"add www.example.com 192.0.2.6",
+		"delete externaldns-default.old.example.com external-dns.invalid",
+	}, client.requests)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2", "192.0.2.6"),
+		endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+	}, records)
+}
+
+func TestAdGuardProviderDryRun(t *testing.T) {
+	client := &testAdGuardClient{}
+	p := newTestProvider(client, nil)
+	p.dryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1")},
+	}))
+	assert.Empty(t, client.requests)
+}
+
+func TestAdGuardProviderAdjustEndpoints(t *testing.T) {
+	p := newTestProvider(&testAdGuardClient{}, nil)
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "text"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+	}, adjusted)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/linki/instrumented_http"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	apiRewriteList      = "/control/rewrite/list"
+	apiRewriteAdd       = "/control/rewrite/add"
+	apiRewriteDelete    = "/control/rewrite/delete"
+	maxErrorMessageSize = 1024
+)
+
+// rewrite is a DNS rewrite entry of AdGuard Home, answering for a domain with an IP address,
+// or with a domain name that is resolved in turn, like a CNAME record.
+type rewrite struct {
+	Domain string `json:"domain"`
+	Answer string `json:"answer"`
+}
+
+// adguardAPI declares the "API" actions performed against the AdGuard Home server.
+type adguardAPI interface {
+	// listRewrites returns all the DNS rewrites of the server.
+	listRewrites(ctx context.Context) ([]rewrite, error)
+	// addRewrite will add the given DNS rewrite.
+	addRewrite(ctx context.Context, r rewrite) error
+	// deleteRewrite will delete the given DNS rewrite.
+	deleteRewrite(ctx context.Context, r rewrite) error
+}
+
+// adguardClient implements the adguardAPI.
+type adguardClient struct {
+	cfg        AdGuardConfig
+	httpClient *http.Client
+}
+
+// newAdGuardClient creates a new AdGuard Home API client.
+func newAdGuardClient(cfg AdGuardConfig) (adguardAPI, error) {
+	if cfg.Server == "" {
+		return nil, ErrNoAdGuardServer
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+			},
+		},
+	}
+	cl := instrumented_http.NewClient(httpClient, &instrumented_http.Callbacks{})
+
+	return &adguardClient{
+		cfg:        cfg,
+		httpClient: cl,
+	}, nil
+}
+
+func (c *adguardClient) listRewrites(ctx context.Context) ([]rewrite, error) {
+	log.Debugf("Listing DNS rewrites from %s", c.cfg.Server)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(apiRewriteList), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewrites []rewrite
+	if err := json.Unmarshal(body, &rewrites); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal DNS rewrites: %w", err)
+	}
+	return rewrites, nil
+}
+
+func (c *adguardClient) addRewrite(ctx context.Context, r rewrite) error {
+	return c.post(ctx, apiRewriteAdd, r)
+}
+
+func (c *adguardClient) deleteRewrite(ctx context.Context, r rewrite) error {
+	return c.post(ctx, apiRewriteDelete, r)
+}
+
+func (c *adguardClient) post(ctx context.Context, path string, r rewrite) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url(path), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	_, err = c.do(req)
+	return err
+}
+
+func (c *adguardClient) url(path string) string {
+	return strings.TrimSuffix(c.cfg.Server, "/") + path
+}
+
+func (c *adguardClient) do(req *http.Request) ([]byte, error) {
+	if c.cfg.Username != "" || c.cfg.Password != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(body))
+		if len(message) > maxErrorMessageSize {
+			message = message[:maxErrorMessageSize]
+		}
+		return nil, fmt.Errorf("%s %s returned %s: %s", req.Method, req.URL.Path, res.Status, message)
+	}
+	return body, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adguard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, hdlr http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	svr := httptest.NewServer(hdlr)
+	t.Cleanup(svr.Close)
+	return svr
+}
+
+func TestNewAdGuardClient(t *testing.T) {
+	_, err := newAdGuardClient(AdGuardConfig{})
+	assert.True(t, errors.Is(err, ErrNoAdGuardServer), err)
+
+	cl, err := newAdGuardClient(AdGuardConfig{Server: "http://adguard.example.com"})
+	require.NoError(t, err)
+	assert.IsType(t, &adguardClient{}, cl)
+}
+
+func TestAdGuardClientListRewrites(t *testing.T) {
+	srvr := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != apiRewriteList {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.Write([]byte(`[{"domain":"www.example.com","answer":"192.0.2.1","enabled":true},{"domain":"api.example.com","answer":"www.example.com"}]`))
+	})
+
+	cl, err := newAdGuardClient(AdGuardConfig{Server: srvr.URL + "/", Username: "admin", Password: "secret"})
+	require.NoError(t, err)
+	rewrites, err := cl.listRewrites(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []rewrite{
+		{Domain: "www.example.com", Answer: "192.0.2.1"},
+		{Domain: "api.example.com", Answer: "www.example.com"},
+	}, rewrites)
+
+	cl, err = newAdGuardClient(AdGuardConfig{Server: srvr.URL, Username: "admin", Password: "wrong"})
+	require.NoError(t, err)
+	_, err = cl.listRewrites(context.Background())
+	assert.EqualError(t, err, "GET /control/rewrite/list returned 401 Unauthorized: ")
+}
+
+func TestAdGuardClientAddDeleteRewrite(t *testing.T) {
+	var requests []string
+	srvr := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != contentTypeJSON {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var rw rewrite
+		if err := json.NewDecoder(r.Body).Decode(&rw); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if rw.Domain == "invalid" {
+			http.Error(w, "json.Decode: invalid domain", http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, r.URL.Path+" "+rw.Domain+" "+rw.Answer)
+	})
+
+	cl, err := newAdGuardClient(AdGuardConfig{Server: srvr.URL})
+	require.NoError(t, err)
+	require.NoError(t, cl.addRewrite(context.Background(), rewrite{Domain: "www.example.com", Answer: "192.0.2.1"}))
+	require.NoError(t, cl.deleteRewrite(context.Background(), rewrite{Domain: "old.example.com", Answer: "192.0.2.2"}))
+	assert.Equal(t, []string{
+		"/control/rewrite/add www.example.com 192.0.2.1",
+		"/control/rewrite/delete old.example.com 192.0.2.2",
+	}, requests)
+
+	err = cl.addRewrite(context.Background(), rewrite{Domain: "invalid", Answer: "192.0.2.1"})
+	assert.EqualError(t, err, "POST /control/rewrite/add returned 400 Bad Request: json.Decode: invalid domain")
+}
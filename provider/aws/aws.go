@@ -25,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -68,6 +69,20 @@ const (
 	batchSize = 10
 )
 
+// ProviderSpecificProperties lists the provider-specific properties supported by the AWS provider.
+var ProviderSpecificProperties = []string{
+	providerSpecificTargetHostedZone,
+	providerSpecificEvaluateTargetHealth,
+	providerSpecificWeight,
+	providerSpecificRegion,
+	providerSpecificFailover,
+	providerSpecificGeolocationContinentCode,
+	providerSpecificGeolocationCountryCode,
+	providerSpecificGeolocationSubdivisionCode,
+	providerSpecificMultiValueAnswer,
+	providerSpecificHealthCheckID,
+}
+
 // see elb: https://docs.aws.amazon.com/general/latest/gr/elb.html
 var canonicalHostedZones = map[string]string{
 	// Application Load Balancers and Classic Load Balancers
@@ -292,6 +307,10 @@ type AWSProvider struct {
 	zonesCache      *zonesListCache
 	// queue for collecting changes to submit them in the next iteration, but after all other changes
 	failedChangesQueue map[string]Route53Changes
+	failedChangesMutex sync.Mutex
+	// zones listed by ZoneIDNames for applying the changes of each zone
+	zoneChangesZones map[string]*profiledZone
+	zoneChangesMutex sync.Mutex
 }
 
 // AWSConfig contains configuration to create a new AWS provider.
@@ -641,14 +660,97 @@ func (p *AWSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 		return provider.NewSoftErrorf("failed to list zones, not applying changes: %w", err)
 	}
 
+	return p.submitChanges(ctx, p.newRoute53Changes(changes), zones)
+}
+
+// ZoneIDNames implements provider.ZoneChangeApplier. The zones are kept for the following
+// SplitZoneChanges and ApplyZoneChanges calls, so that the hosted zones are listed once.
+func (p *AWSProvider) ZoneIDNames(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, provider.NewSoftErrorf("failed to list zones, not applying changes: %w", err)
+	}
+	p.zoneChangesMutex.Lock()
+	p.zoneChangesZones = zones
+	p.zoneChangesMutex.Unlock()
+
+	zoneIDNames := provider.ZoneIDName{}
+	for id, z := range zones {
+		zoneIDNames.Add(id, strings.TrimSuffix(*z.zone.Name, "."))
+	}
+	return zoneIDNames, nil
+}
+
+// SplitZoneChanges implements provider.ZoneChangeApplier. Like ApplyChanges, the changes of a DNS name
+// belong to its most specific public zone and to all of its private zones.
+func (p *AWSProvider) SplitZoneChanges(_ provider.ZoneIDName, changes *plan.Changes) map[string]*plan.Changes {
+	p.zoneChangesMutex.Lock()
+	zones := p.zoneChangesZones
+	p.zoneChangesMutex.Unlock()
+
+	changesByZone := map[string]*plan.Changes{}
+	zoneChanges := func(ep *endpoint.Endpoint) []*plan.Changes {
+		var result []*plan.Changes
+		for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+			if _, ok := changesByZone[*z.zone.Id]; !ok {
+				changesByZone[*z.zone.Id] = &plan.Changes{}
+			}
+			result = append(result, changesByZone[*z.zone.Id])
+		}
+		if len(result) == 0 {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+		}
+		return result
+	}
+	for _, ep := range changes.Create {
+		for _, c := range zoneChanges(ep) {
+			c.Create = append(c.Create, ep)
+		}
+	}
+	// UpdateOld and UpdateNew are matched by index and refer to the same DNS name
+	for i, ep := range changes.UpdateNew {
+		for _, c := range zoneChanges(ep) {
+			c.UpdateNew = append(c.UpdateNew, ep)
+			c.UpdateOld = append(c.UpdateOld, changes.UpdateOld[i])
+		}
+	}
+	for _, ep := range changes.Delete {
+		for _, c := range zoneChanges(ep) {
+			c.Delete = append(c.Delete, ep)
+		}
+	}
+	return changesByZone
+}
+
+// ApplyZoneChanges implements provider.ZoneChangeApplier, applying the changes of a zone returned by ZoneIDNames.
+func (p *AWSProvider) ApplyZoneChanges(ctx context.Context, zoneID string, changes *plan.Changes) error {
+	p.zoneChangesMutex.Lock()
+	zone, ok := p.zoneChangesZones[zoneID]
+	p.zoneChangesMutex.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown hosted zone %s", zoneID)
+	}
+
+	zones := map[string]*profiledZone{zoneID: zone}
+	cs := changesByZone(zones, p.newRoute53Changes(changes))[zoneID]
+	if len(cs) == 0 {
+		return nil
+	}
+	if p.submitZoneChanges(ctx, zoneID, zone, cs) {
+		return provider.NewSoftErrorf("failed to submit all changes for zone %s", zoneID)
+	}
+	return nil
+}
+
+// newRoute53Changes converts the changes of the plan into Route53 changes.
+func (p *AWSProvider) newRoute53Changes(changes *plan.Changes) Route53Changes {
 	updateChanges := p.createUpdateChanges(changes.UpdateNew, changes.UpdateOld)
 
 	combinedChanges := make(Route53Changes, 0, len(changes.Delete)+len(changes.Create)+len(updateChanges))
 	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionCreate, changes.Create)...)
 	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionDelete, changes.Delete)...)
 	combinedChanges = append(combinedChanges, updateChanges...)
-
-	return p.submitChanges(ctx, combinedChanges, zones)
+	return combinedChanges
 }
 
 // submitChanges takes a zone and a collection of Changes and sends them as a single transaction.
@@ -666,94 +768,104 @@ func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes,
 	}
 
 	var failedZones []string
-	debugLevel := log.DebugLevel
 	for z, cs := range changesByZone {
-		log := log.WithFields(log.Fields{
-			"zoneName": *zones[z].zone.Name,
-			"zoneID":   z,
-			"profile":  zones[z].profile,
-		})
+		if p.submitZoneChanges(ctx, z, zones[z], cs) {
+			failedZones = append(failedZones, z)
+		}
+	}
 
-		var failedUpdate bool
+	if len(failedZones) > 0 {
+		return provider.NewSoftErrorf("failed to submit all changes for the following zones: %v", failedZones)
+	}
 
-		// group changes into new changes and into changes that failed in a previous iteration and are retried
-		retriedChanges, newChanges := findChangesInQueue(cs, p.failedChangesQueue[z])
-		p.failedChangesQueue[z] = nil
+	return nil
+}
 
-		batchCs := append(batchChangeSet(newChanges, p.batchChangeSize, p.batchChangeSizeBytes, p.batchChangeSizeValues),
-			batchChangeSet(retriedChanges, p.batchChangeSize, p.batchChangeSizeBytes, p.batchChangeSizeValues)...)
-		for i, b := range batchCs {
-			if len(b) == 0 {
-				continue
-			}
+// submitZoneChanges sends the changes of a zone in batches, and reports whether some of them failed.
+// The failed changes are queued, to be retried in a separate batch by the next iteration.
+func (p *AWSProvider) submitZoneChanges(ctx context.Context, z string, zone *profiledZone, cs Route53Changes) bool {
+	debugLevel := log.DebugLevel
+	log := log.WithFields(log.Fields{
+		"zoneName": *zone.zone.Name,
+		"zoneID":   z,
+		"profile":  zone.profile,
+	})
 
-			for _, c := range b {
-				log.Infof("Desired change: %s %s %s", c.Action, *c.ResourceRecordSet.Name, c.ResourceRecordSet.Type)
-			}
+	var failedUpdate bool
 
-			if !p.dryRun {
-				params := &route53.ChangeResourceRecordSetsInput{
-					HostedZoneId: aws.String(z),
-					ChangeBatch: &route53types.ChangeBatch{
-						Changes: b.Route53Changes(),
-					},
-				}
+	// group changes into new changes and into changes that failed in a previous iteration and are retried
+	p.failedChangesMutex.Lock()
+	retriedChanges, newChanges := findChangesInQueue(cs, p.failedChangesQueue[z])
+	p.failedChangesQueue[z] = nil
+	p.failedChangesMutex.Unlock()
 
-				successfulChanges := 0
+	batchCs := append(batchChangeSet(newChanges, p.batchChangeSize, p.batchChangeSizeBytes, p.batchChangeSizeValues),
+		batchChangeSet(retriedChanges, p.batchChangeSize, p.batchChangeSizeBytes, p.batchChangeSizeValues)...)
+	for i, b := range batchCs {
+		if len(b) == 0 {
+			continue
+		}
 
-				client := p.clients[zones[z].profile]
-				if _, err := client.ChangeResourceRecordSets(ctx, params); err != nil {
-					log.Errorf("Failure in zone %s when submitting change batch: %v", *zones[z].zone.Name, err)
+		for _, c := range b {
+			log.Infof("Desired change: %s %s %s", c.Action, *c.ResourceRecordSet.Name, c.ResourceRecordSet.Type)
+		}
 
-					changesByOwnership := groupChangesByNameAndOwnershipRelation(b)
+		if !p.dryRun {
+			params := &route53.ChangeResourceRecordSetsInput{
+				HostedZoneId: aws.String(z),
+				ChangeBatch: &route53types.ChangeBatch{
+					Changes: b.Route53Changes(),
+				},
+			}
 
-					if len(changesByOwnership) > 1 {
-						log.Debug("Trying to submit change sets one-by-one instead")
-						for _, changes := range changesByOwnership {
-							if log.Logger.IsLevelEnabled(debugLevel) {
-								for _, c := range changes {
-									log.Debugf("Desired change: %s %s %s", c.Action, *c.ResourceRecordSet.Name, c.ResourceRecordSet.Type)
-								}
-							}
-							params.ChangeBatch = &route53types.ChangeBatch{
-								Changes: changes.Route53Changes(),
-							}
-							if _, err := client.ChangeResourceRecordSets(ctx, params); err != nil {
-								failedUpdate = true
-								log.Errorf("Failed submitting change (error: %v), it will be retried in a separate change batch in the next iteration", err)
-								p.failedChangesQueue[z] = append(p.failedChangesQueue[z], changes...)
-							} else {
-								successfulChanges = successfulChanges + len(changes)
+			successfulChanges := 0
+
+			client := p.clients[zone.profile]
+			if _, err := client.ChangeResourceRecordSets(ctx, params); err != nil {
+				log.Errorf("Failure in zone %s when submitting change batch: %v", *zone.zone.Name, err)
+
+				changesByOwnership := groupChangesByNameAndOwnershipRelation(b)
+
+				if len(changesByOwnership) > 1 {
+					log.Debug("Trying to submit change sets one-by-one instead")
+					for _, changes := range changesByOwnership {
+						if log.Logger.IsLevelEnabled(debugLevel) {
+							for _, c := range changes {
+								log.Debugf("Desired change: %s %s %s", c.Action, *c.ResourceRecordSet.Name, c.ResourceRecordSet.Type)
 							}
 						}
-					} else {
-						failedUpdate = true
+						params.ChangeBatch = &route53types.ChangeBatch{
+							Changes: changes.Route53Changes(),
+						}
+						if _, err := client.ChangeResourceRecordSets(ctx, params); err != nil {
+							failedUpdate = true
+							log.Errorf("Failed submitting change (error: %v), it will be retried in a separate change batch in the next iteration", err)
+							p.failedChangesMutex.Lock()
+							p.failedChangesQueue[z] = append(p.failedChangesQueue[z], changes...)
+							p.failedChangesMutex.Unlock()
+						} else {
+							successfulChanges = successfulChanges + len(changes)
+						}
 					}
 				} else {
-					successfulChanges = len(b)
-				}
-
-				if successfulChanges > 0 {
-					// z is the R53 Hosted Zone ID already as aws.StringValue
-					log.Infof("%d record(s) were successfully updated", successfulChanges)
+					failedUpdate = true
 				}
+			} else {
+				successfulChanges = len(b)
+			}
 
-				if i != len(batchCs)-1 {
-					time.Sleep(p.batchChangeInterval)
-				}
+			if successfulChanges > 0 {
+				// z is the R53 Hosted Zone ID already as aws.StringValue
+				log.Infof("%d record(s) were successfully updated", successfulChanges)
 			}
-		}
 
-		if failedUpdate {
-			failedZones = append(failedZones, z)
+			if i != len(batchCs)-1 {
+				time.Sleep(p.batchChangeInterval)
+			}
 		}
 	}
 
-	if len(failedZones) > 0 {
-		return provider.NewSoftErrorf("failed to submit all changes for the following zones: %v", failedZones)
-	}
-
-	return nil
+	return failedUpdate
 }
 
 // newChanges returns a collection of Changes based on the given records and action.
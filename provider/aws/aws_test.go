@@ -1556,6 +1556,51 @@ func TestAWSsubmitChangesRetryOnError(t *testing.T) {
 	require.True(t, containsRecordWithDNSName(records, "fail__edns_housekeeping.zone-1.ext-dns-test-2.teapot.zalan.do"))
 }
 
+func TestAWSApplyZoneChanges(t *testing.T) {
+	p, clientStub := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	ctx := context.Background()
+
+	zones, err := p.ZoneIDNames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, provider.ZoneIDName{
+		"/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.": "zone-1.ext-dns-test-2.teapot.zalan.do",
+		"/hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do.": "zone-2.ext-dns-test-2.teapot.zalan.do",
+		"/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do.": "zone-3.ext-dns-test-2.teapot.zalan.do",
+	}, zones)
+
+	zone1 := "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."
+	zone3 := "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("create.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.0.0.1"),
+			endpoint.NewEndpointWithTTL("create.zone-3.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.0.0.3"),
+			endpoint.NewEndpointWithTTL("create.example.org", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.0.0.4"),
+		},
+	}
+	hasRecord := func(zoneID, dnsName string) bool {
+		for _, r := range listAWSRecords(t, clientStub, zoneID) {
+			if *r.Name == dnsName {
+				return true
+			}
+		}
+		return false
+	}
+
+	changesByZone := p.SplitZoneChanges(zones, changes)
+	require.Len(t, changesByZone, 2)
+	assert.Equal(t, changes.Create[:1], changesByZone[zone1].Create)
+	assert.Equal(t, changes.Create[1:2], changesByZone[zone3].Create)
+
+	require.NoError(t, p.ApplyZoneChanges(ctx, zone3, changesByZone[zone3]))
+	assert.True(t, hasRecord(zone3, "create.zone-3.ext-dns-test-2.teapot.zalan.do."))
+	assert.False(t, hasRecord(zone1, "create.zone-1.ext-dns-test-2.teapot.zalan.do."))
+
+	require.NoError(t, p.ApplyZoneChanges(ctx, zone1, changesByZone[zone1]))
+	assert.True(t, hasRecord(zone1, "create.zone-1.ext-dns-test-2.teapot.zalan.do."))
+
+	require.Error(t, p.ApplyZoneChanges(ctx, "/hostedzone/zone-4.ext-dns-test-3.teapot.zalan.do.", changes))
+}
+
 func TestAWSBatchChangeSet(t *testing.T) {
 	var cs Route53Changes
 
@@ -29,8 +29,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/linki/instrumented_http"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
 )
 
 // AWSSessionConfig contains configuration to create a new AWS provider.
@@ -39,6 +41,8 @@ type AWSSessionConfig struct {
 	AssumeRoleExternalID string
 	APIRetries           int
 	Profile              string
+	// RateLimiter limits the API requests, if set
+	RateLimiter *rate.Limiter
 }
 
 func CreateDefaultV2Config(cfg *externaldns.Config) awsv2.Config {
@@ -55,10 +59,21 @@ func CreateDefaultV2Config(cfg *externaldns.Config) awsv2.Config {
 	return result
 }
 
-func CreateV2Configs(cfg *externaldns.Config) map[string]awsv2.Config {
+// CreateV2Configs returns the configs of the AWS profiles, whose API requests share rateLimiter if it is set.
+func CreateV2Configs(cfg *externaldns.Config, rateLimiter *rate.Limiter) map[string]awsv2.Config {
 	result := make(map[string]awsv2.Config)
 	if len(cfg.AWSProfiles) == 0 || (len(cfg.AWSProfiles) == 1 && cfg.AWSProfiles[0] == "") {
-		cfg := CreateDefaultV2Config(cfg)
+		cfg, err := newV2Config(
+			AWSSessionConfig{
+				AssumeRole:           cfg.AWSAssumeRole,
+				AssumeRoleExternalID: cfg.AWSAssumeRoleExternalID,
+				APIRetries:           cfg.AWSAPIRetries,
+				RateLimiter:          rateLimiter,
+			},
+		)
+		if err != nil {
+			logrus.Fatal(err)
+		}
 		result[defaultAWSProfile] = cfg
 	} else {
 		for _, profile := range cfg.AWSProfiles {
@@ -68,6 +83,7 @@ func CreateV2Configs(cfg *externaldns.Config) map[string]awsv2.Config {
 					AssumeRoleExternalID: cfg.AWSAssumeRoleExternalID,
 					APIRetries:           cfg.AWSAPIRetries,
 					Profile:              profile,
+					RateLimiter:          rateLimiter,
 				},
 			)
 			if err != nil {
@@ -84,7 +100,9 @@ func newV2Config(awsConfig AWSSessionConfig) (awsv2.Config, error) {
 		config.WithRetryer(func() awsv2.Retryer {
 			return retry.AddWithMaxAttempts(retry.NewStandard(), awsConfig.APIRetries)
 		}),
-		config.WithHTTPClient(instrumented_http.NewClient(&http.Client{}, &instrumented_http.Callbacks{
+		config.WithHTTPClient(instrumented_http.NewClient(&http.Client{
+			Transport: provider.NewRateLimitedTransport(awsConfig.RateLimiter, nil),
+		}, &instrumented_http.Callbacks{
 			PathProcessor: func(path string) string {
 				parts := strings.Split(path, "/")
 				return parts[len(parts)-1]
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var circuitBreakerState = metrics.NewGaugeWithOpts(
+	prometheus.GaugeOpts{
+		Namespace: "external_dns",
+		Subsystem: "provider",
+		Name:      "circuit_breaker_state",
+		Help:      "State of the provider circuit breaker: 0 closed, 1 open (read-only), 2 half-open.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(circuitBreakerState)
+}
+
+// CircuitState is the state of a CircuitBreakerProvider.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through to the provider.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen serves the last known records and refuses to apply changes.
+	CircuitOpen
+	// CircuitHalfOpen lets calls through to probe whether the provider recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// errCircuitOpen is returned while the circuit is open.
+var errCircuitOpen = errors.New("provider circuit breaker is open, DNS records are read-only")
+
+// CircuitBreakerProvider stops calling a failing provider after a number of consecutive failures.
+// While the circuit is open, Records serves the last records read successfully and ApplyChanges
+// is refused. Once the cooldown has elapsed, the circuit is half-open: the next call probes the
+// provider, closing the circuit on success or opening it again on failure.
+//
+// Only transient failures, reported as a SoftError, are counted. Other errors, such as invalid credentials,
+// are returned unchanged so that they fail fast. The failure of the changes to some of the zones of a
+// ZonePartitionedProvider is not counted either, as the provider applied the changes to the other zones.
+type CircuitBreakerProvider struct {
+	Provider
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mutex    sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	records  []*endpoint.Endpoint
+}
+
+// NewCircuitBreakerProvider opens the circuit after threshold consecutive failures, for the given cooldown.
+func NewCircuitBreakerProvider(provider Provider, threshold int, cooldown time.Duration) *CircuitBreakerProvider {
+	circuitBreakerState.Gauge.Set(float64(CircuitClosed))
+	return &CircuitBreakerProvider{
+		Provider:  provider,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State returns the current state of the circuit.
+func (c *CircuitBreakerProvider) State() CircuitState {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.allow()
+}
+
+// Records returns the records of the provider, or the last known records while the circuit is open.
+func (c *CircuitBreakerProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	c.mutex.Lock()
+	if c.allow() == CircuitOpen {
+		records := c.records
+		c.mutex.Unlock()
+		if records == nil {
+			return nil, NewSoftError(errCircuitOpen)
+		}
+		log.Debug("Provider circuit breaker is open, using the last known records")
+		return records, nil
+	}
+	c.mutex.Unlock()
+
+	records, err := c.Provider.Records(ctx)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err != nil {
+		return nil, c.failure(err)
+	}
+	c.success()
+	c.records = records
+	return records, nil
+}
+
+// ApplyChanges applies the changes using the provider, unless the circuit is open.
+func (c *CircuitBreakerProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	c.mutex.Lock()
+	if c.allow() == CircuitOpen {
+		c.mutex.Unlock()
+		return NewSoftError(errCircuitOpen)
+	}
+	c.mutex.Unlock()
+
+	err := c.Provider.ApplyChanges(ctx, changes)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err != nil {
+		return c.failure(err)
+	}
+	c.success()
+	return nil
+}
+
+// allow moves an open circuit to half-open once the cooldown has elapsed and returns the state.
+func (c *CircuitBreakerProvider) allow() CircuitState {
+	if c.state == CircuitOpen && c.now().Sub(c.openedAt) >= c.cooldown {
+		c.setState(CircuitHalfOpen)
+	}
+	return c.state
+}
+
+func (c *CircuitBreakerProvider) success() {
+	c.failures = 0
+	if c.state != CircuitClosed {
+		c.setState(CircuitClosed)
+	}
+}
+
+func (c *CircuitBreakerProvider) failure(err error) error {
+	if !errors.Is(err, SoftError) {
+		return err
+	}
+	if errors.As(err, new(partialZoneError)) {
+		c.success()
+		return err
+	}
+	c.failures++
+	if c.state == CircuitHalfOpen || (c.state == CircuitClosed && c.failures >= c.threshold) {
+		c.openedAt = c.now()
+		c.setState(CircuitOpen)
+	}
+	return err
+}
+
+func (c *CircuitBreakerProvider) setState(state CircuitState) {
+	switch state {
+	case CircuitOpen:
+		log.Warnf("Provider circuit breaker opened after %d consecutive failures, DNS records are read-only for %s", c.failures, c.cooldown)
+	case CircuitHalfOpen:
+		log.Info("Provider circuit breaker is half-open, probing the provider")
+	case CircuitClosed:
+		log.Info("Provider circuit breaker closed, the provider recovered")
+	}
+	c.state = state
+	circuitBreakerState.Gauge.Set(float64(state))
+}
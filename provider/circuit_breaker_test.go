@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestCircuitBreakerProvider(t *testing.T) {
+	ctx := context.Background()
+	records := []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")}
+	changes := &plan.Changes{Create: records}
+
+	var (
+		providerErr  error
+		recordCalls  int
+		applyCalls   int
+		now          = time.Now()
+		apiUnhealthy = errors.New("api unavailable")
+	)
+	p := NewCircuitBreakerProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			recordCalls++
+			if providerErr != nil {
+				return nil, providerErr
+			}
+			return records, nil
+		},
+		applyChanges: func(_ context.Context, _ *plan.Changes) error {
+			applyCalls++
+			return providerErr
+		},
+	}, 2, time.Minute)
+	p.now = func() time.Time { return now }
+
+	got, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+
+	// transient failures are counted until the threshold opens the circuit
+	providerErr = NewSoftError(apiUnhealthy)
+	err = p.ApplyChanges(ctx, changes)
+	require.ErrorIs(t, err, SoftError)
+	require.ErrorIs(t, err, apiUnhealthy)
+	assert.Equal(t, CircuitClosed, p.State())
+
+	_, err = p.Records(ctx)
+	require.ErrorIs(t, err, SoftError)
+	assert.Equal(t, CircuitOpen, p.State())
+
+	// an open circuit serves the last known records and refuses changes without calling the provider
+	recordCalls, applyCalls = 0, 0
+	got, err = p.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+	err = p.ApplyChanges(ctx, changes)
+	require.ErrorIs(t, err, SoftError)
+	require.ErrorIs(t, err, errCircuitOpen)
+	assert.Zero(t, recordCalls)
+	assert.Zero(t, applyCalls)
+
+	// a failing probe opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, p.State())
+	_, err = p.Records(ctx)
+	require.ErrorIs(t, err, apiUnhealthy)
+	assert.Equal(t, 1, recordCalls)
+	assert.Equal(t, CircuitOpen, p.State())
+
+	// a successful probe closes the circuit
+	now = now.Add(time.Minute)
+	providerErr = nil
+	require.NoError(t, p.ApplyChanges(ctx, changes))
+	assert.Equal(t, 1, applyCalls)
+	assert.Equal(t, CircuitClosed, p.State())
+}
+
+func TestCircuitBreakerProviderOpenWithoutRecords(t *testing.T) {
+	p := NewCircuitBreakerProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			return nil, NewSoftErrorf("api unavailable")
+		},
+	}, 1, time.Minute)
+
+	_, err := p.Records(context.Background())
+	require.Error(t, err)
+	require.Equal(t, CircuitOpen, p.State())
+
+	_, err = p.Records(context.Background())
+	require.ErrorIs(t, err, SoftError)
+	require.ErrorIs(t, err, errCircuitOpen)
+}
+
+func TestCircuitBreakerProviderIgnoredErrors(t *testing.T) {
+	var providerErr error
+	p := NewCircuitBreakerProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			return nil, providerErr
+		},
+		applyChanges: func(_ context.Context, _ *plan.Changes) error {
+			return providerErr
+		},
+	}, 1, time.Minute)
+
+	// other errors fail fast, without opening the circuit
+	providerErr = errors.New("invalid credentials")
+	_, err := p.Records(context.Background())
+	require.Equal(t, providerErr, err)
+	require.NotErrorIs(t, err, SoftError)
+	require.Equal(t, CircuitClosed, p.State())
+
+	// the failure of some of the zones does not open the circuit
+	zones, err := NewZonePartitionedProvider(newZoneApplierProvider(map[string]error{"zone-2": errors.New("api unavailable")}), 1)
+	require.NoError(t, err)
+	p = NewCircuitBreakerProvider(zones, 1, time.Minute)
+	err = p.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("a.sub.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}})
+	require.ErrorIs(t, err, SoftError)
+	require.ErrorContains(t, err, `zone "sub.example.org": api unavailable`)
+	require.Equal(t, CircuitClosed, p.State())
+}
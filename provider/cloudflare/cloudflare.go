@@ -402,6 +402,45 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return p.submitChanges(ctx, p.newCloudFlareChanges(changes))
+}
+
+// ZoneIDNames implements provider.ZoneChangeApplier.
+func (p *CloudFlareProvider) ZoneIDNames(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, z := range zones {
+		zoneNameIDMapper.Add(z.ID, z.Name)
+	}
+	return zoneNameIDMapper, nil
+}
+
+// SplitZoneChanges implements provider.ZoneChangeApplier.
+func (p *CloudFlareProvider) SplitZoneChanges(zones provider.ZoneIDName, changes *plan.Changes) map[string]*plan.Changes {
+	return provider.SplitChangesByZone(zones, changes)
+}
+
+// ApplyZoneChanges implements provider.ZoneChangeApplier.
+func (p *CloudFlareProvider) ApplyZoneChanges(ctx context.Context, zoneID string, changes *plan.Changes) error {
+	cloudflareChanges := p.newCloudFlareChanges(changes)
+	if len(cloudflareChanges) == 0 {
+		return nil
+	}
+	failedChange, err := p.submitZoneChanges(ctx, zoneID, cloudflareChanges)
+	if err != nil {
+		return err
+	}
+	if failedChange {
+		return fmt.Errorf("failed to submit all changes for zone %q", zoneID)
+	}
+	return nil
+}
+
+// newCloudFlareChanges converts a set of changes into the individual record changes of the API.
+func (p *CloudFlareProvider) newCloudFlareChanges(changes *plan.Changes) []*cloudFlareChange {
 	var cloudflareChanges []*cloudFlareChange
 
 	// if custom hostnames are enabled, deleting first allows to avoid conflicts with the new ones
@@ -476,7 +515,7 @@ func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Cha
 		}
 	}
 
-	return p.submitChanges(ctx, cloudflareChanges)
+	return cloudflareChanges
 }
 
 // submitCustomHostnameChanges implements Custom Hostname functionality for the Change, returns false if it fails
@@ -571,102 +610,111 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 
 	var failedZones []string
 	for zoneID, zoneChanges := range changesByZone {
-		var failedChange bool
-		resourceContainer := cloudflare.ZoneIdentifier(zoneID)
-
-		for _, change := range zoneChanges {
-			logFields := log.Fields{
-				"record": change.ResourceRecord.Name,
-				"type":   change.ResourceRecord.Type,
-				"ttl":    change.ResourceRecord.TTL,
-				"action": change.Action,
-				"zone":   zoneID,
-			}
+		failedChange, err := p.submitZoneChanges(ctx, zoneID, zoneChanges)
+		if err != nil {
+			return err
+		}
+		if failedChange {
+			failedZones = append(failedZones, zoneID)
+		}
+	}
+
+	if len(failedZones) > 0 {
+		return fmt.Errorf("failed to submit all changes for the following zones: %q", failedZones)
+	}
 
-			log.WithFields(logFields).Info("Changing record.")
+	return nil
+}
+
+// submitZoneChanges sends the changes of a single zone, it returns true if any of them failed.
+func (p *CloudFlareProvider) submitZoneChanges(ctx context.Context, zoneID string, zoneChanges []*cloudFlareChange) (bool, error) {
+	var failedChange bool
+	resourceContainer := cloudflare.ZoneIdentifier(zoneID)
+
+	for _, change := range zoneChanges {
+		logFields := log.Fields{
+			"record": change.ResourceRecord.Name,
+			"type":   change.ResourceRecord.Type,
+			"ttl":    change.ResourceRecord.TTL,
+			"action": change.Action,
+			"zone":   zoneID,
+		}
+
+		log.WithFields(logFields).Info("Changing record.")
 
-			if p.DryRun {
+		if p.DryRun {
+			continue
+		}
+
+		records, err := p.listDNSRecordsWithAutoPagination(ctx, zoneID)
+		if err != nil {
+			return false, fmt.Errorf("could not fetch records from zone, %w", err)
+		}
+		chs, chErr := p.listCustomHostnamesWithPagination(ctx, zoneID)
+		if chErr != nil {
+			return false, fmt.Errorf("could not fetch custom hostnames from zone, %w", chErr)
+		}
+		if change.Action == cloudFlareUpdate {
+			if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
+				failedChange = true
+			}
+			recordID := p.getRecordID(records, change.ResourceRecord)
+			if recordID == "" {
+				log.WithFields(logFields).Errorf("failed to find previous record: %v", change.ResourceRecord)
 				continue
 			}
-
-			records, err := p.listDNSRecordsWithAutoPagination(ctx, zoneID)
+			recordParam := updateDNSRecordParam(*change)
+			recordParam.ID = recordID
+			err := p.Client.UpdateDNSRecord(ctx, resourceContainer, recordParam)
 			if err != nil {
-				return fmt.Errorf("could not fetch records from zone, %w", err)
+				failedChange = true
+				log.WithFields(logFields).Errorf("failed to update record: %v", err)
 			}
-			chs, chErr := p.listCustomHostnamesWithPagination(ctx, zoneID)
-			if chErr != nil {
-				return fmt.Errorf("could not fetch custom hostnames from zone, %w", chErr)
+		} else if change.Action == cloudFlareDelete {
+			recordID := p.getRecordID(records, change.ResourceRecord)
+			if recordID == "" {
+				log.WithFields(logFields).Errorf("failed to find previous record: %v", change.ResourceRecord)
+				continue
 			}
-			if change.Action == cloudFlareUpdate {
-				if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
-					failedChange = true
-				}
-				recordID := p.getRecordID(records, change.ResourceRecord)
-				if recordID == "" {
-					log.WithFields(logFields).Errorf("failed to find previous record: %v", change.ResourceRecord)
-					continue
-				}
-				recordParam := updateDNSRecordParam(*change)
-				recordParam.ID = recordID
-				err := p.Client.UpdateDNSRecord(ctx, resourceContainer, recordParam)
-				if err != nil {
-					failedChange = true
-					log.WithFields(logFields).Errorf("failed to update record: %v", err)
-				}
-			} else if change.Action == cloudFlareDelete {
-				recordID := p.getRecordID(records, change.ResourceRecord)
-				if recordID == "" {
-					log.WithFields(logFields).Errorf("failed to find previous record: %v", change.ResourceRecord)
-					continue
-				}
-				err := p.Client.DeleteDNSRecord(ctx, resourceContainer, recordID)
-				if err != nil {
-					failedChange = true
-					log.WithFields(logFields).Errorf("failed to delete record: %v", err)
-				}
-				if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
-					failedChange = true
-				}
-			} else if change.Action == cloudFlareCreate {
-				recordParam := getCreateDNSRecordParam(*change)
-				_, err := p.Client.CreateDNSRecord(ctx, resourceContainer, recordParam)
-				if err != nil {
-					failedChange = true
-					log.WithFields(logFields).Errorf("failed to create record: %v", err)
-				}
-				if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
-					failedChange = true
-				}
+			err := p.Client.DeleteDNSRecord(ctx, resourceContainer, recordID)
+			if err != nil {
+				failedChange = true
+				log.WithFields(logFields).Errorf("failed to delete record: %v", err)
 			}
-		}
-
-		if p.RegionalServicesConfig.Enabled {
-			desiredRegionalHostnames, err := desiredRegionalHostnames(zoneChanges)
+			if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
+				failedChange = true
+			}
+		} else if change.Action == cloudFlareCreate {
+			recordParam := getCreateDNSRecordParam(*change)
+			_, err := p.Client.CreateDNSRecord(ctx, resourceContainer, recordParam)
 			if err != nil {
-				return fmt.Errorf("failed to build desired regional hostnames: %w", err)
+				failedChange = true
+				log.WithFields(logFields).Errorf("failed to create record: %v", err)
 			}
-			if len(desiredRegionalHostnames) > 0 {
-				regionalHostnames, err := p.listDataLocalisationRegionalHostnames(ctx, resourceContainer)
-				if err != nil {
-					return fmt.Errorf("could not fetch regional hostnames from zone, %w", err)
-				}
-				regionalHostnamesChanges := regionalHostnamesChanges(desiredRegionalHostnames, regionalHostnames)
-				if !p.submitRegionalHostnameChanges(ctx, regionalHostnamesChanges, resourceContainer) {
-					failedChange = true
-				}
+			if !p.submitCustomHostnameChanges(ctx, zoneID, change, chs, logFields) {
+				failedChange = true
 			}
 		}
-
-		if failedChange {
-			failedZones = append(failedZones, zoneID)
-		}
 	}
 
-	if len(failedZones) > 0 {
-		return fmt.Errorf("failed to submit all changes for the following zones: %q", failedZones)
+	if p.RegionalServicesConfig.Enabled {
+		desiredRegionalHostnames, err := desiredRegionalHostnames(zoneChanges)
+		if err != nil {
+			return false, fmt.Errorf("failed to build desired regional hostnames: %w", err)
+		}
+		if len(desiredRegionalHostnames) > 0 {
+			regionalHostnames, err := p.listDataLocalisationRegionalHostnames(ctx, resourceContainer)
+			if err != nil {
+				return false, fmt.Errorf("could not fetch regional hostnames from zone, %w", err)
+			}
+			regionalHostnamesChanges := regionalHostnamesChanges(desiredRegionalHostnames, regionalHostnames)
+			if !p.submitRegionalHostnameChanges(ctx, regionalHostnamesChanges, resourceContainer) {
+				failedChange = true
+			}
+		}
 	}
 
-	return nil
+	return failedChange, nil
 }
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
@@ -1134,6 +1134,43 @@ func TestCloudflareApplyChanges(t *testing.T) {
 	}
 }
 
+func TestCloudflareApplyZoneChanges(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client: client,
+	}
+
+	zones, err := p.ZoneIDNames(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, provider.ZoneIDName{"001": "bar.com", "002": "foo.com"}, zones)
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{{
+			DNSName: "new.foo.com",
+			Targets: endpoint.Targets{"target"},
+		}},
+	}
+	assert.NoError(t, p.ApplyZoneChanges(context.Background(), "002", changes))
+
+	td.Cmp(t, client.Actions, []MockAction{
+		{
+			Name:     "Create",
+			ZoneId:   "002",
+			RecordId: generateDNSRecordID("", "new.foo.com", "target"),
+			RecordData: cloudflare.DNSRecord{
+				ID:      generateDNSRecordID("", "new.foo.com", "target"),
+				Name:    "new.foo.com",
+				Content: "target",
+				TTL:     1,
+				Proxied: proxyDisabled,
+			},
+		},
+	})
+
+	// empty changes
+	assert.NoError(t, p.ApplyZoneChanges(context.Background(), "002", &plan.Changes{}))
+}
+
 func TestCloudflareDryRunApplyChanges(t *testing.T) {
 	changes := &plan.Changes{}
 	client := NewMockCloudFlareClient()
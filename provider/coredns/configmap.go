@@ -0,0 +1,269 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coredns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// configMapServicesKey is the key of the ConfigMap holding the Service records, keyed by their etcd key.
+	configMapServicesKey = "external-dns.json"
+	// configMapZoneTTL is the TTL of the records without TTL, as served by the CoreDNS etcd plugin.
+	configMapZoneTTL = 300
+)
+
+// configMapState is the content of the services key of the ConfigMap.
+type configMapState struct {
+	Serial    uint32               `json:"serial"`
+	Services  map[string]Service   `json:"services"`
+	Ownership map[string]Ownership `json:"ownership,omitempty"`
+}
+
+// configMapClient stores the Service records in a ConfigMap instead of etcd, and renders them as
+// zone files of the CoreDNS file plugin, in the db.<zone> keys of the ConfigMap.
+type configMapClient struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+	prefix    string
+	zones     []string
+	ctx       context.Context
+}
+
+var _ coreDNSClient = configMapClient{}
+
+// newConfigMapClient is a ConfigMap client constructor, the zones are rendered for the domains of the domain filter.
+func newConfigMapClient(kubeClient kubernetes.Interface, configMap, prefix string, domainFilter *endpoint.DomainFilter) (coreDNSClient, error) {
+	namespace, name, ok := strings.Cut(configMap, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid CoreDNS ConfigMap %q, expected namespace/name", configMap)
+	}
+	var zones []string
+	if domainFilter != nil {
+		for _, zone := range domainFilter.Filters {
+			if zone = strings.Trim(zone, "."); zone != "" {
+				zones = append(zones, zone)
+			}
+		}
+	}
+	if len(zones) == 0 {
+		return nil, errors.New("the CoreDNS ConfigMap backend requires the zones to be specified with --domain-filter")
+	}
+	return configMapClient{client: kubeClient, namespace: namespace, name: name, prefix: prefix, zones: zones, ctx: context.Background()}, nil
+}
+
+// GetServices returns all Service records stored in the ConfigMap under the given key prefix.
+func (c configMapClient) GetServices(prefix string) ([]*Service, error) {
+	cm, err := c.client.CoreV1().ConfigMaps(c.namespace).Get(c.ctx, c.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state, err := decodeConfigMapState(cm)
+	if err != nil {
+		return nil, err
+	}
+
+	var svcs []*Service
+	for key, svc := range state.Services {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		svc.Key = key
+		if svc.Priority == 0 {
+			svc.Priority = priority
+		}
+		if ownership, ok := state.Ownership[key]; ok {
+			svc.Ownership = &ownership
+		}
+		svcs = append(svcs, &svc)
+	}
+	sort.Slice(svcs, func(i, j int) bool { return svcs[i].Key < svcs[j].Key })
+	return svcs, nil
+}
+
+// SaveService persists service data into the ConfigMap
+func (c configMapClient) SaveService(service *Service) error {
+	return c.ApplyServices(nil, []*Service{service})
+}
+
+// DeleteService deletes the service records under the given key from the ConfigMap, like a prefixed etcd delete
+func (c configMapClient) DeleteService(key string) error {
+	return c.ApplyServices([]string{key}, nil)
+}
+
+// ApplyServices deletes the services under the given keys and saves the given services in a single update of the ConfigMap
+func (c configMapClient) ApplyServices(deletes []string, services []*Service) error {
+	return c.update(func(state *configMapState) {
+		for _, key := range deletes {
+			for k := range state.Services {
+				if strings.HasPrefix(k, key) {
+					delete(state.Services, k)
+					delete(state.Ownership, k)
+				}
+			}
+		}
+		for _, service := range services {
+			state.Services[service.Key] = *service
+			if service.Ownership != nil {
+				state.Ownership[service.Key] = *service.Ownership
+			}
+		}
+	})
+}
+
+// update applies a change to the Service records and renders the zone files, creating the ConfigMap if needed.
+func (c configMapClient) update(change func(state *configMapState)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMaps := c.client.CoreV1().ConfigMaps(c.namespace)
+		cm, err := configMaps.Get(c.ctx, c.name, metav1.GetOptions{})
+		create := apierrors.IsNotFound(err)
+		if create {
+			cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: c.namespace, Name: c.name}}
+		} else if err != nil {
+			return err
+		}
+		state, err := decodeConfigMapState(cm)
+		if err != nil {
+			return err
+		}
+
+		change(state)
+		// The file plugin of CoreDNS only reloads a zone file when its SOA serial increases.
+		state.Serial = max(state.Serial+1, uint32(time.Now().Unix()))
+
+		value, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[configMapServicesKey] = string(value)
+		for zone, text := range c.renderZones(state) {
+			cm.Data["db."+zone] = text
+		}
+
+		if create {
+			_, err = configMaps.Create(c.ctx, cm, metav1.CreateOptions{})
+		} else {
+			_, err = configMaps.Update(c.ctx, cm, metav1.UpdateOptions{})
+		}
+		return err
+	})
+}
+
+func decodeConfigMapState(cm *v1.ConfigMap) (*configMapState, error) {
+	state := &configMapState{}
+	if value, ok := cm.Data[configMapServicesKey]; ok {
+		if err := json.Unmarshal([]byte(value), state); err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", cm.Namespace, cm.Name, err)
+		}
+	}
+	if state.Services == nil {
+		state.Services = map[string]Service{}
+	}
+	if state.Ownership == nil {
+		state.Ownership = map[string]Ownership{}
+	}
+	return state, nil
+}
+
+// renderZones renders the Service records as a zone file for each zone, with the SOA and NS records
+// synthesized by the CoreDNS etcd plugin. A Service answers for its key without the TargetStrip labels.
+func (c configMapClient) renderZones(state *configMapState) map[string]string {
+	zoneIDName := provider.ZoneIDName{}
+	lines := map[string][]string{}
+	for _, zone := range c.zones {
+		zoneIDName.Add(zone, zone)
+		lines[zone] = nil
+	}
+
+	for key, svc := range state.Services {
+		if !strings.HasPrefix(key, c.prefix) {
+			continue
+		}
+		labels := strings.Split(strings.TrimPrefix(key, c.prefix), "/")
+		reverse(labels)
+		if svc.TargetStrip >= len(labels) {
+			continue
+		}
+		name := strings.Join(labels[svc.TargetStrip:], ".")
+		zone, _ := zoneIDName.FindZone(name)
+		if zone == "" {
+			continue
+		}
+		ttl := svc.TTL
+		if ttl == 0 {
+			ttl = configMapZoneTTL
+		}
+		if svc.Host != "" {
+			recordType, host := endpoint.RecordTypeCNAME, strings.TrimSuffix(svc.Host, ".")+"."
+			if ip := net.ParseIP(svc.Host); ip != nil {
+				recordType, host = endpoint.RecordTypeA, svc.Host
+				if ip.To4() == nil {
+					recordType = endpoint.RecordTypeAAAA
+				}
+			}
+			lines[zone] = append(lines[zone], fmt.Sprintf("%s.\t%d\tIN\t%s\t%s", name, ttl, recordType, host))
+		}
+		if svc.Text != "" {
+			lines[zone] = append(lines[zone], fmt.Sprintf("%s.\t%d\tIN\tTXT\t%s", name, ttl, quoteText(svc.Text)))
+		}
+	}
+
+	zones := map[string]string{}
+	for zone, records := range lines {
+		sort.Strings(records)
+		var b strings.Builder
+		fmt.Fprintf(&b, "$ORIGIN %s.\n", zone)
+		fmt.Fprintf(&b, "@\t%d\tIN\tSOA\tns.dns.%s. hostmaster.%s. %d 7200 1800 86400 30\n", configMapZoneTTL, zone, zone, state.Serial)
+		fmt.Fprintf(&b, "@\t%d\tIN\tNS\tns.dns.%s.\n", configMapZoneTTL, zone)
+		for _, record := range records {
+			b.WriteString(record + "\n")
+		}
+		zones[zone] = b.String()
+	}
+	return zones
+}
+
+// quoteText returns the text as a quoted character string of a zone file.
+func quoteText(text string) string {
+	if strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) && len(text) > 1 {
+		return text
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coredns
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestNewCoreDNSConfigMapProvider(t *testing.T) {
+	kubeClient := fake.NewClientset()
+
+	_, err := NewCoreDNSConfigMapProvider(kubeClient, "external-dns-coredns", endpoint.NewDomainFilter([]string{"example.org"}), defaultCoreDNSPrefix, false)
+	assert.ErrorContains(t, err, "expected namespace/name")
+
+	_, err = NewCoreDNSConfigMapProvider(kubeClient, "kube-system/external-dns-coredns", &endpoint.DomainFilter{}, defaultCoreDNSPrefix, false)
+	assert.ErrorContains(t, err, "requires the zones to be specified with --domain-filter")
+
+	p, err := NewCoreDNSConfigMapProvider(kubeClient, "kube-system/external-dns-coredns", endpoint.NewDomainFilter([]string{"example.org"}), defaultCoreDNSPrefix, false)
+	require.NoError(t, err)
+
+	// The ConfigMap is created by the first change.
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestConfigMapClientZoneFiles(t *testing.T) {
+	kubeClient := fake.NewClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "external-dns-coredns"},
+		Data:       map[string]string{"Corefile": "example.org {\n    file /etc/coredns/external-dns/db.example.org\n}\n"},
+	})
+	p, err := NewCoreDNSConfigMapProvider(kubeClient, "kube-system/external-dns-coredns", endpoint.NewDomainFilter([]string{"example.org", "example.net"}), defaultCoreDNSPrefix, false)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeA, 60, "192.0.2.1"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeTXT, `"heritage=external-dns,external-dns/owner=default"`),
+			endpoint.NewEndpoint("v6.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeCNAME, "www.example.org"),
+		},
+	}))
+
+	cm, err := kubeClient.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "external-dns-coredns", metav1.GetOptions{})
+	require.NoError(t, err)
+	state, err := decodeConfigMapState(cm)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.org {\n    file /etc/coredns/external-dns/db.example.org\n}\n", cm.Data["Corefile"])
+	assert.Equal(t, fmt.Sprintf(`$ORIGIN example.org.
+@	300	IN	SOA	ns.dns.example.org. hostmaster.example.org. %d 7200 1800 86400 30
+@	300	IN	NS	ns.dns.example.org.
+api.example.org.	300	IN	CNAME	www.example.org.
+v6.example.org.	300	IN	AAAA	2001:db8::1
+www.example.org.	60	IN	A	192.0.2.1
+www.example.org.	60	IN	TXT	"heritage=external-dns,external-dns/owner=default"
+`, state.Serial), cm.Data["db.example.org"])
+	assert.Equal(t, fmt.Sprintf(`$ORIGIN example.net.
+@	300	IN	SOA	ns.dns.example.net. hostmaster.example.net. %d 7200 1800 86400 30
+@	300	IN	NS	ns.dns.example.net.
+`, state.Serial), cm.Data["db.example.net"])
+
+	serial := state.Serial
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	var deletes []*endpoint.Endpoint
+	for _, ep := range records {
+		if ep.DNSName == "api.example.org" {
+			deletes = append(deletes, ep)
+		}
+	}
+	require.Len(t, deletes, 1)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Delete: deletes}))
+
+	cm, err = kubeClient.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "external-dns-coredns", metav1.GetOptions{})
+	require.NoError(t, err)
+	state, err = decodeConfigMapState(cm)
+	require.NoError(t, err)
+	assert.Greater(t, state.Serial, serial)
+	assert.NotContains(t, cm.Data["db.example.org"], "api.example.org.")
+}
+
+func TestConfigMapClientInvalidState(t *testing.T) {
+	kubeClient := fake.NewClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "external-dns-coredns"},
+		Data:       map[string]string{configMapServicesKey: "{"},
+	})
+	client, err := newConfigMapClient(kubeClient, "kube-system/external-dns-coredns", defaultCoreDNSPrefix, endpoint.NewDomainFilter([]string{"example.org"}))
+	require.NoError(t, err)
+
+	_, err = client.GetServices(defaultCoreDNSPrefix)
+	assert.ErrorContains(t, err, "kube-system/external-dns-coredns")
+
+	err = client.SaveService(&Service{Key: "/skydns/org/example/www", Host: "192.0.2.1"})
+	assert.ErrorContains(t, err, "kube-system/external-dns-coredns")
+}
@@ -24,11 +24,14 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	etcdcv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/external-dns/pkg/tlsutils"
 
@@ -40,15 +43,30 @@ import (
 const (
 	priority    = 10 // default priority when nothing is set
 	etcdTimeout = 5 * time.Second
+	// etcdLeaseBatchSize is the number of services attached to a new lease per transaction,
+	// below the default limit of 128 operations per transaction of etcd.
+	etcdLeaseBatchSize = 100
 
 	randomPrefixLabel = "prefix"
+
+	// ownershipPrefix is the prefix of the sibling keys storing the ownership of the services written
+	// by external-dns, outside of the key space served by the CoreDNS etcd plugin.
+	ownershipPrefix = "/external-dns/ownership"
 )
 
-// coreDNSClient is an interface to work with CoreDNS service records in etcd
+// coreDNSClient is an interface to work with CoreDNS service records in etcd or in a ConfigMap
 type coreDNSClient interface {
 	GetServices(prefix string) ([]*Service, error)
 	SaveService(value *Service) error
 	DeleteService(key string) error
+	// ApplyServices deletes the services under the given keys and saves the given services atomically
+	ApplyServices(deletes []string, services []*Service) error
+}
+
+// leaseKeeper is implemented by the clients attaching the services to a lease, which is refreshed
+// on every synchronization.
+type leaseKeeper interface {
+	KeepAlive(prefix string) error
 }
 
 type coreDNSProvider struct {
@@ -83,11 +101,27 @@ type Service struct {
 
 	// Etcd key where we found this service and ignored from json un-/marshaling
 	Key string `json:"-"`
+
+	// Ownership of the service when written by external-dns, stored in a sibling key
+	// and ignored from json un-/marshaling
+	Ownership *Ownership `json:"-"`
+}
+
+// Ownership is the metadata of a service written by external-dns
+type Ownership struct {
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type etcdClient struct {
 	client *etcdcv3.Client
 	ctx    context.Context
+	// lease the services are attached to, nil when disabled
+	lease *etcdLease
+}
+
+type etcdLease struct {
+	ttl time.Duration
+	id  etcdcv3.LeaseID
 }
 
 var _ coreDNSClient = etcdClient{}
@@ -124,32 +158,140 @@ func (c etcdClient) GetServices(prefix string) ([]*Service, error) {
 		}
 		svcs = append(svcs, svc)
 	}
+
+	// The ownership keys are read at the same revision as the services.
+	r, err = c.client.Get(ctx, ownershipKeyFor(path), etcdcv3.WithPrefix(), etcdcv3.WithRev(r.Header.GetRevision()))
+	if err != nil {
+		return nil, err
+	}
+	ownerships := make(map[string]*Ownership, len(r.Kvs))
+	for _, n := range r.Kvs {
+		ownership := new(Ownership)
+		if err := json.Unmarshal(n.Value, ownership); err != nil {
+			return nil, fmt.Errorf("%s: %w", n.Key, err)
+		}
+		ownerships[strings.TrimPrefix(string(n.Key), ownershipPrefix)] = ownership
+	}
+	for _, svc := range svcs {
+		svc.Ownership = ownerships[svc.Key]
+	}
 	return svcs, nil
 }
 
 // SaveService persists service data into etcd
 func (c etcdClient) SaveService(service *Service) error {
+	return c.ApplyServices(nil, []*Service{service})
+}
+
+// DeleteService deletes service record from etcd
+func (c etcdClient) DeleteService(key string) error {
+	return c.ApplyServices([]string{key}, nil)
+}
+
+// ApplyServices deletes the services under the given keys and saves the given services, with their
+// ownership keys, in a single etcd transaction.
+func (c etcdClient) ApplyServices(deletes []string, services []*Service) error {
+	var ops []etcdcv3.Op
+	for _, key := range deletes {
+		ops = append(ops,
+			etcdcv3.OpDelete(key, etcdcv3.WithPrefix()),
+			etcdcv3.OpDelete(ownershipKeyFor(key), etcdcv3.WithPrefix()),
+		)
+	}
+
+	var opts []etcdcv3.OpOption
+	if c.lease != nil && c.lease.id != etcdcv3.NoLease {
+		opts = append(opts, etcdcv3.WithLease(c.lease.id))
+	}
+	for _, service := range services {
+		value, err := json.Marshal(service)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, etcdcv3.OpPut(service.Key, string(value), opts...))
+		if service.Ownership == nil {
+			continue
+		}
+		value, err = json.Marshal(service.Ownership)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, etcdcv3.OpPut(ownershipKeyFor(service.Key), string(value), opts...))
+	}
+
 	ctx, cancel := context.WithTimeout(c.ctx, etcdTimeout)
 	defer cancel()
 
-	value, err := json.Marshal(&service)
+	_, err := c.client.Txn(ctx).Then(ops...).Commit()
+	return err
+}
+
+// KeepAlive refreshes the lease of the services. When there is no lease yet, or when it has expired,
+// a new lease is granted and attached to the services under the prefix which have an ownership key.
+func (c etcdClient) KeepAlive(prefix string) error {
+	if c.lease == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, etcdTimeout)
+	defer cancel()
+
+	if c.lease.id != etcdcv3.NoLease {
+		_, err := c.client.KeepAliveOnce(ctx, c.lease.id)
+		if !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			return err
+		}
+		log.Warnf("etcd lease %x has expired, attaching the records to a new lease", c.lease.id)
+	}
+
+	lease, err := c.client.Grant(ctx, int64(c.lease.ttl.Seconds()))
 	if err != nil {
 		return err
 	}
-	_, err = c.client.Put(ctx, service.Key, string(value))
-	if err != nil {
+	if err := c.attachLease(prefix, lease.ID); err != nil {
 		return err
 	}
+	c.lease.id = lease.ID
 	return nil
 }
 
-// DeleteService deletes service record from etcd
-func (c etcdClient) DeleteService(key string) error {
+// attachLease attaches the services under the prefix which have an ownership key to the lease,
+// in transactions of etcdLeaseBatchSize services. Each transaction has its own deadline, so that
+// the number of services does not make the attachment time out.
+func (c etcdClient) attachLease(prefix string, id etcdcv3.LeaseID) error {
 	ctx, cancel := context.WithTimeout(c.ctx, etcdTimeout)
-	defer cancel()
+	r, err := c.client.Get(ctx, ownershipKeyFor(prefix), etcdcv3.WithPrefix(), etcdcv3.WithKeysOnly())
+	cancel()
+	if err != nil {
+		return err
+	}
+	for batch := range slices.Chunk(r.Kvs, etcdLeaseBatchSize) {
+		ops := make([]etcdcv3.Op, 0, len(batch))
+		for _, n := range batch {
+			ownershipKey := string(n.Key)
+			key := strings.TrimPrefix(ownershipKey, ownershipPrefix)
+			// The values are kept as is, and the ownership keys of the services deleted meanwhile are removed.
+			ops = append(ops, etcdcv3.OpTxn(
+				[]etcdcv3.Cmp{etcdcv3.Compare(etcdcv3.CreateRevision(key), ">", 0)},
+				[]etcdcv3.Op{
+					etcdcv3.OpPut(key, "", etcdcv3.WithIgnoreValue(), etcdcv3.WithLease(id)),
+					etcdcv3.OpPut(ownershipKey, "", etcdcv3.WithIgnoreValue(), etcdcv3.WithLease(id)),
+				},
+				[]etcdcv3.Op{etcdcv3.OpDelete(ownershipKey)},
+			))
+		}
+		ctx, cancel := context.WithTimeout(c.ctx, etcdTimeout)
+		_, err := c.client.Txn(ctx).Then(ops...).Commit()
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	log.Debugf("Attached %d records to etcd lease %x", len(r.Kvs), id)
+	return nil
+}
 
-	_, err := c.client.Delete(ctx, key, etcdcv3.WithPrefix())
-	return err
+func ownershipKeyFor(key string) string {
+	return ownershipPrefix + key
 }
 
 // builds etcd client config depending on connection scheme and TLS parameters
@@ -181,8 +323,8 @@ func getETCDConfig() (*etcdcv3.Config, error) {
 	}
 }
 
-// the newETCDClient is an etcd client constructor
-func newETCDClient() (coreDNSClient, error) {
+// the newETCDClient is an etcd client constructor, the services are attached to a lease when leaseTTL is not zero
+func newETCDClient(leaseTTL time.Duration) (coreDNSClient, error) {
 	cfg, err := getETCDConfig()
 	if err != nil {
 		return nil, err
@@ -191,12 +333,34 @@ func newETCDClient() (coreDNSClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	return etcdClient{c, context.Background()}, nil
+	client := etcdClient{client: c, ctx: context.Background()}
+	if leaseTTL > 0 {
+		client.lease = &etcdLease{ttl: leaseTTL}
+	}
+	return client, nil
+}
+
+// NewCoreDNSProvider is a CoreDNS provider constructor. When leaseTTL is not zero, the records are attached
+// to an etcd lease of this TTL, refreshed on every synchronization, so that they expire when external-dns stops.
+func NewCoreDNSProvider(domainFilter *endpoint.DomainFilter, prefix string, leaseTTL time.Duration, dryRun bool) (provider.Provider, error) {
+	client, err := newETCDClient(leaseTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return coreDNSProvider{
+		client:        client,
+		dryRun:        dryRun,
+		coreDNSPrefix: prefix,
+		domainFilter:  domainFilter,
+	}, nil
 }
 
-// NewCoreDNSProvider is a CoreDNS provider constructor
-func NewCoreDNSProvider(domainFilter *endpoint.DomainFilter, prefix string, dryRun bool) (provider.Provider, error) {
-	client, err := newETCDClient()
+// NewCoreDNSConfigMapProvider is a constructor of a CoreDNS provider storing the records in a ConfigMap,
+// in the namespace/name format, instead of etcd. The zones of the domain filter are rendered as zone files
+// of the CoreDNS file plugin in the ConfigMap.
+func NewCoreDNSConfigMapProvider(kubeClient kubernetes.Interface, configMap string, domainFilter *endpoint.DomainFilter, prefix string, dryRun bool) (provider.Provider, error) {
+	client, err := newConfigMapClient(kubeClient, configMap, prefix, domainFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -234,6 +398,12 @@ func findLabelInTargets(targets []string, label string) (string, bool) {
 // Records returns all DNS records found in CoreDNS etcd backend. Depending on the record fields
 // it may be mapped to one or two records of type A, CNAME, TXT, A+TXT, CNAME+TXT
 func (p coreDNSProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	if keeper, ok := p.client.(leaseKeeper); ok && !p.dryRun {
+		if err := keeper.KeepAlive(p.coreDNSPrefix); err != nil {
+			return nil, fmt.Errorf("failed to refresh the etcd lease: %w", err)
+		}
+	}
+
 	var result []*endpoint.Endpoint
 	services, err := p.client.GetServices(p.coreDNSPrefix)
 	if err != nil {
@@ -265,6 +435,7 @@ func (p coreDNSProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error
 			ep.Labels["originalText"] = service.Text
 			ep.Labels[randomPrefixLabel] = prefix
 			ep.Labels[service.Host] = prefix
+			setOwnershipLabels(ep, service)
 			result = append(result, ep)
 		}
 		if service.Text != "" {
@@ -274,12 +445,34 @@ func (p coreDNSProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error
 				service.Text,
 			)
 			ep.Labels[randomPrefixLabel] = prefix
+			setOwnershipLabels(ep, service)
 			result = append(result, ep)
 		}
 	}
 	return result, nil
 }
 
+// setOwnershipLabels sets the labels stored in the ownership key of a service on its endpoint.
+func setOwnershipLabels(ep *endpoint.Endpoint, service *Service) {
+	if service.Ownership == nil {
+		return
+	}
+	for k, v := range service.Ownership.Labels {
+		ep.Labels[k] = v
+	}
+}
+
+// ownershipOf returns the ownership of the services created for an endpoint.
+func ownershipOf(ep *endpoint.Endpoint) *Ownership {
+	labels := map[string]string{}
+	for _, key := range []string{endpoint.OwnerLabelKey, endpoint.ResourceLabelKey} {
+		if value, ok := ep.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	return &Ownership{Labels: labels}
+}
+
 func (p coreDNSProvider) ApplyChanges(_ context.Context, changes *plan.Changes) error {
 	grouped := p.groupEndpoints(changes)
 
@@ -309,36 +502,38 @@ func (p coreDNSProvider) groupEndpoints(changes *plan.Changes) map[string][]*end
 	return grouped
 }
 
+// applyGroup saves the services of the endpoints of a DNS name and deletes its outdated services in a single change.
 func (p coreDNSProvider) applyGroup(dnsName string, group []*endpoint.Endpoint) error {
 	var services []*Service
+	var deletes []string
 
 	for _, ep := range group {
 		if ep.RecordType != endpoint.RecordTypeTXT {
-			srvs, err := p.createServicesForEndpoint(dnsName, ep)
-			if err != nil {
-				return err
-			}
+			srvs, outdated := p.createServicesForEndpoint(dnsName, ep)
 			services = append(services, srvs...)
+			deletes = append(deletes, outdated...)
 		}
 	}
 
 	services = p.updateTXTRecords(dnsName, group, services)
 
+	for _, key := range deletes {
+		log.Infof("Delete key %s", key)
+	}
 	for _, service := range services {
 		log.Infof("Add/set key %s to Host=%s, Text=%s, TTL=%d", service.Key, service.Host, service.Text, service.TTL)
-		if p.dryRun {
-			continue
-		}
-		if err := p.client.SaveService(service); err != nil {
-			return err
-		}
 	}
-
-	return nil
+	if p.dryRun {
+		return nil
+	}
+	return p.client.ApplyServices(deletes, services)
 }
 
-func (p coreDNSProvider) createServicesForEndpoint(dnsName string, ep *endpoint.Endpoint) ([]*Service, error) {
+// createServicesForEndpoint returns the services of the targets of an endpoint, and the keys of the services
+// of its outdated targets.
+func (p coreDNSProvider) createServicesForEndpoint(dnsName string, ep *endpoint.Endpoint) ([]*Service, []string) {
 	var services []*Service
+	var deletes []string
 
 	for _, target := range ep.Targets {
 		prefix := ep.Labels[target]
@@ -352,6 +547,7 @@ func (p coreDNSProvider) createServicesForEndpoint(dnsName string, ep *endpoint.
 			Key:         p.etcdKeyFor(prefix + "." + dnsName),
 			TargetStrip: strings.Count(prefix, ".") + 1,
 			TTL:         uint32(ep.RecordTTL),
+			Ownership:   ownershipOf(ep),
 		}
 		services = append(services, &service)
 		ep.Labels[target] = prefix
@@ -363,21 +559,14 @@ func (p coreDNSProvider) createServicesForEndpoint(dnsName string, ep *endpoint.
 			continue
 		}
 		if _, ok := findLabelInTargets(ep.Targets, label); !ok {
-			key := p.etcdKeyFor(labelPrefix + "." + dnsName)
-			log.Infof("Delete key %s", key)
-			if p.dryRun {
-				continue
-			}
-			if err := p.client.DeleteService(key); err != nil {
-				return nil, err
-			}
+			deletes = append(deletes, p.etcdKeyFor(labelPrefix+"."+dnsName))
 		}
 	}
-	return services, nil
+	return services, deletes
 }
 
 func shouldSkipLabel(label string) bool {
-	skip := []string{"originalText", "prefix", "resource"}
+	skip := []string{"originalText", "prefix", endpoint.OwnerLabelKey, endpoint.ResourceLabelKey}
 	_, ok := findLabelInTargets(skip, label)
 	return ok
 }
@@ -398,6 +587,7 @@ func (p coreDNSProvider) updateTXTRecords(dnsName string, group []*endpoint.Endp
 				Key:         p.etcdKeyFor(prefix + "." + dnsName),
 				TargetStrip: strings.Count(prefix, ".") + 1,
 				TTL:         uint32(ep.RecordTTL),
+				Ownership:   ownershipOf(ep),
 			})
 		}
 		services[index].Text = ep.Targets[0]
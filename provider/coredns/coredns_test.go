@@ -20,15 +20,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	etcdcv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
@@ -65,24 +69,112 @@ func (c fakeETCDClient) DeleteService(key string) error {
 	return nil
 }
 
+func (c fakeETCDClient) ApplyServices(deletes []string, services []*Service) error {
+	for _, key := range deletes {
+		delete(c.services, key)
+	}
+	for _, service := range services {
+		c.services[service.Key] = *service
+	}
+	return nil
+}
+
+// testClients returns a client of each backend, to run the same tests against all of them.
+func testClients(t *testing.T) map[string]coreDNSClient {
+	t.Helper()
+	configMap, err := newConfigMapClient(fake.NewClientset(), "kube-system/external-dns-coredns", defaultCoreDNSPrefix, endpoint.NewDomainFilter([]string{"local"}))
+	require.NoError(t, err)
+	return map[string]coreDNSClient{
+		"etcd":      fakeETCDClient{map[string]Service{}},
+		"configmap": configMap,
+	}
+}
+
+// servicesOf returns the services stored by a client, keyed by their key.
+func servicesOf(t *testing.T, client coreDNSClient) map[string]Service {
+	t.Helper()
+	svcs, err := client.GetServices(defaultCoreDNSPrefix)
+	require.NoError(t, err)
+	services := map[string]Service{}
+	for _, svc := range svcs {
+		services[svc.Key] = *svc
+	}
+	return services
+}
+
 type MockEtcdKV struct {
 	etcdcv3.KV
 	mock.Mock
 }
 
-func (m *MockEtcdKV) Put(ctx context.Context, key, input string, _ ...etcdcv3.OpOption) (*etcdcv3.PutResponse, error) {
-	args := m.Called(ctx, key, input)
-	return args.Get(0).(*etcdcv3.PutResponse), args.Error(1)
-}
-
 func (m *MockEtcdKV) Get(ctx context.Context, key string, _ ...etcdcv3.OpOption) (*etcdcv3.GetResponse, error) {
 	args := m.Called(ctx, key)
 	return args.Get(0).(*etcdcv3.GetResponse), args.Error(1)
 }
 
-func (m *MockEtcdKV) Delete(ctx context.Context, key string, opts ...etcdcv3.OpOption) (*etcdcv3.DeleteResponse, error) {
-	args := m.Called(ctx, key, opts[0])
-	return args.Get(0).(*etcdcv3.DeleteResponse), args.Error(1)
+func (m *MockEtcdKV) Txn(ctx context.Context) etcdcv3.Txn {
+	return &mockEtcdTxn{kv: m, ctx: ctx}
+}
+
+// mockEtcdTxn is committed as a Commit call of the KV mock, with the operations of both branches formatted by formatOps.
+type mockEtcdTxn struct {
+	kv        *MockEtcdKV
+	ctx       context.Context
+	then, els []etcdcv3.Op
+}
+
+func (t *mockEtcdTxn) If(...etcdcv3.Cmp) etcdcv3.Txn {
+	return t
+}
+
+func (t *mockEtcdTxn) Then(ops ...etcdcv3.Op) etcdcv3.Txn {
+	t.then = append(t.then, ops...)
+	return t
+}
+
+func (t *mockEtcdTxn) Else(ops ...etcdcv3.Op) etcdcv3.Txn {
+	t.els = append(t.els, ops...)
+	return t
+}
+
+func (t *mockEtcdTxn) Commit() (*etcdcv3.TxnResponse, error) {
+	args := t.kv.Called(t.ctx, formatOps(t.then), formatOps(t.els))
+	return args.Get(0).(*etcdcv3.TxnResponse), args.Error(1)
+}
+
+func formatOps(ops []etcdcv3.Op) []string {
+	var result []string
+	for _, op := range ops {
+		switch {
+		case op.IsPut() && len(op.ValueBytes()) > 0:
+			result = append(result, "put "+string(op.KeyBytes())+" "+string(op.ValueBytes()))
+		case op.IsPut():
+			result = append(result, "put "+string(op.KeyBytes()))
+		case op.IsDelete() && op.IsOptsWithPrefix():
+			result = append(result, "delete "+string(op.KeyBytes())+"/*")
+		case op.IsDelete():
+			result = append(result, "delete "+string(op.KeyBytes()))
+		case op.IsTxn():
+			_, then, els := op.Txn()
+			result = append(result, fmt.Sprintf("txn %v else %v", formatOps(then), formatOps(els)))
+		}
+	}
+	return result
+}
+
+type MockEtcdLease struct {
+	etcdcv3.Lease
+	mock.Mock
+}
+
+func (m *MockEtcdLease) Grant(ctx context.Context, ttl int64) (*etcdcv3.LeaseGrantResponse, error) {
+	args := m.Called(ctx, ttl)
+	return args.Get(0).(*etcdcv3.LeaseGrantResponse), args.Error(1)
+}
+
+func (m *MockEtcdLease) KeepAliveOnce(ctx context.Context, id etcdcv3.LeaseID) (*etcdcv3.LeaseKeepAliveResponse, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*etcdcv3.LeaseKeepAliveResponse), args.Error(1)
 }
 
 func TestETCDConfig(t *testing.T) {
@@ -326,9 +418,14 @@ func TestCNAMEWithTXTServiceTranslation(t *testing.T) {
 }
 
 func TestCoreDNSApplyChanges(t *testing.T) {
-	client := fakeETCDClient{
-		map[string]Service{},
+	for name, client := range testClients(t) {
+		t.Run(name, func(t *testing.T) {
+			testCoreDNSApplyChanges(t, client)
+		})
 	}
+}
+
+func testCoreDNSApplyChanges(t *testing.T, client coreDNSClient) {
 	coredns := coreDNSProvider{
 		client:        client,
 		coreDNSPrefix: defaultCoreDNSPrefix,
@@ -348,7 +445,7 @@ func TestCoreDNSApplyChanges(t *testing.T) {
 		"/skydns/local/domain1": {{Host: "5.5.5.5", Text: "string1"}},
 		"/skydns/local/domain2": {{Host: "site.local"}},
 	}
-	validateServices(client.services, expectedServices1, t, 1)
+	validateServices(servicesOf(t, client), expectedServices1, t, 1)
 
 	changes2 := &plan.Changes{
 		Create: []*endpoint.Endpoint{
@@ -372,7 +469,7 @@ func TestCoreDNSApplyChanges(t *testing.T) {
 		"/skydns/local/domain2": {{Host: "site.local"}},
 		"/skydns/local/domain3": {{Host: "7.7.7.7"}},
 	}
-	validateServices(client.services, expectedServices2, t, 2)
+	validateServices(servicesOf(t, client), expectedServices2, t, 2)
 
 	changes3 := &plan.Changes{
 		Delete: []*endpoint.Endpoint{
@@ -388,7 +485,7 @@ func TestCoreDNSApplyChanges(t *testing.T) {
 	expectedServices3 := map[string][]*Service{
 		"/skydns/local/domain2": {{Host: "site.local"}},
 	}
-	validateServices(client.services, expectedServices3, t, 3)
+	validateServices(servicesOf(t, client), expectedServices3, t, 3)
 
 	// Test for multiple A records for the same FQDN
 	changes4 := &plan.Changes{
@@ -405,7 +502,7 @@ func TestCoreDNSApplyChanges(t *testing.T) {
 		"/skydns/local/domain2": {{Host: "site.local"}},
 		"/skydns/local/domain1": {{Host: "5.5.5.5"}, {Host: "6.6.6.6"}, {Host: "7.7.7.7"}},
 	}
-	validateServices(client.services, expectedServices4, t, 4)
+	validateServices(servicesOf(t, client), expectedServices4, t, 4)
 }
 
 func TestCoreDNSApplyChanges_DomainDoNotMatch(t *testing.T) {
@@ -502,6 +599,14 @@ func TestGetServices_Success(t *testing.T) {
 			},
 		},
 	}, nil)
+	mockKV.On("Get", mock.Anything, "/external-dns/ownership/prefix").Return(&etcdcv3.GetResponse{
+		Kvs: []*mvccpb.KeyValue{
+			{
+				Key:   []byte("/external-dns/ownership/prefix/1"),
+				Value: []byte(`{"labels":{"owner":"default"}}`),
+			},
+		},
+	}, nil)
 
 	c := etcdClient{
 		client: &etcdcv3.Client{
@@ -514,6 +619,7 @@ func TestGetServices_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, "example.com", result[0].Host)
+	assert.Equal(t, &Ownership{Labels: map[string]string{endpoint.OwnerLabelKey: "default"}}, result[0].Ownership)
 }
 
 func TestGetServices_Duplicate(t *testing.T) {
@@ -541,6 +647,7 @@ func TestGetServices_Duplicate(t *testing.T) {
 			},
 		},
 	}, nil)
+	mockKV.On("Get", mock.Anything, "/external-dns/ownership/prefix").Return(&etcdcv3.GetResponse{}, nil)
 
 	result, err := c.GetServices("/prefix")
 	assert.NoError(t, err)
@@ -575,6 +682,7 @@ func TestGetServices_Multiple(t *testing.T) {
 			},
 		},
 	}, nil)
+	mockKV.On("Get", mock.Anything, "/external-dns/ownership/prefix").Return(&etcdcv3.GetResponse{}, nil)
 
 	result, err := c.GetServices("/prefix")
 	assert.NoError(t, err)
@@ -647,8 +755,8 @@ func TestDeleteService(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockKV := new(MockEtcdKV)
-			mockKV.On("Delete", mock.Anything, mock.Anything, mock.AnythingOfType("clientv3.OpOption")).
-				Return(&etcdcv3.DeleteResponse{}, tt.mockErr)
+			mockKV.On("Commit", mock.Anything, []string{"delete " + tt.key + "/*", "delete /external-dns/ownership" + tt.key + "/*"}, []string(nil)).
+				Return(&etcdcv3.TxnResponse{}, tt.mockErr)
 
 			c := etcdClient{
 				client: &etcdcv3.Client{
@@ -705,8 +813,8 @@ func TestSaveService(t *testing.T) {
 			mockKV := new(MockEtcdKV)
 			value, err := json.Marshal(&tt.service)
 			require.NoError(t, err)
-			mockKV.On("Put", mock.Anything, tt.service.Key, string(value)).
-				Return(&etcdcv3.PutResponse{}, tt.mockPutErr)
+			mockKV.On("Commit", mock.Anything, []string{"put " + tt.service.Key + " " + string(value)}, []string(nil)).
+				Return(&etcdcv3.TxnResponse{}, tt.mockPutErr)
 
 			c := etcdClient{
 				client: &etcdcv3.Client{
@@ -726,6 +834,160 @@ func TestSaveService(t *testing.T) {
 	}
 }
 
+func TestApplyServices(t *testing.T) {
+	mockKV := new(MockEtcdKV)
+	mockKV.On("Commit", mock.Anything, []string{
+		"delete /skydns/local/domain1/1a2b3c4d/*",
+		"delete /external-dns/ownership/skydns/local/domain1/1a2b3c4d/*",
+		`put /skydns/local/domain1/5e6f7a8b {"host":"5.5.5.5","targetstrip":1}`,
+		`put /external-dns/ownership/skydns/local/domain1/5e6f7a8b {"labels":{"owner":"default"}}`,
+		`put /skydns/local/domain2 {"host":"site.local"}`,
+	}, []string(nil)).Return(&etcdcv3.TxnResponse{}, nil)
+
+	c := etcdClient{
+		client: &etcdcv3.Client{
+			KV: mockKV,
+		},
+		ctx:   context.Background(),
+		lease: &etcdLease{ttl: 5 * time.Minute, id: 7},
+	}
+
+	err := c.ApplyServices([]string{"/skydns/local/domain1/1a2b3c4d"}, []*Service{
+		{Key: "/skydns/local/domain1/5e6f7a8b", Host: "5.5.5.5", TargetStrip: 1, Ownership: &Ownership{Labels: map[string]string{endpoint.OwnerLabelKey: "default"}}},
+		{Key: "/skydns/local/domain2", Host: "site.local"},
+	})
+	require.NoError(t, err)
+	mockKV.AssertExpectations(t)
+}
+
+func TestKeepAlive(t *testing.T) {
+	mockKV := new(MockEtcdKV)
+	mockLease := new(MockEtcdLease)
+	c := etcdClient{
+		client: &etcdcv3.Client{
+			KV:    mockKV,
+			Lease: mockLease,
+		},
+		ctx:   context.Background(),
+		lease: &etcdLease{ttl: 5 * time.Minute},
+	}
+
+	mockKV.On("Get", mock.Anything, "/external-dns/ownership/skydns/").Return(&etcdcv3.GetResponse{
+		Kvs: []*mvccpb.KeyValue{
+			{Key: []byte("/external-dns/ownership/skydns/local/domain1/1a2b3c4d")},
+		},
+	}, nil)
+	mockKV.On("Commit", mock.Anything, []string{
+		"txn [put /skydns/local/domain1/1a2b3c4d put /external-dns/ownership/skydns/local/domain1/1a2b3c4d] " +
+			"else [delete /external-dns/ownership/skydns/local/domain1/1a2b3c4d]",
+	}, []string(nil)).Return(&etcdcv3.TxnResponse{}, nil)
+
+	// The records are attached to a new lease.
+	mockLease.On("Grant", mock.Anything, int64(300)).Return(&etcdcv3.LeaseGrantResponse{ID: 1}, nil).Once()
+	require.NoError(t, c.KeepAlive(defaultCoreDNSPrefix))
+	assert.Equal(t, etcdcv3.LeaseID(1), c.lease.id)
+
+	// The lease is refreshed.
+	mockLease.On("KeepAliveOnce", mock.Anything, etcdcv3.LeaseID(1)).Return(&etcdcv3.LeaseKeepAliveResponse{}, nil).Once()
+	require.NoError(t, c.KeepAlive(defaultCoreDNSPrefix))
+	assert.Equal(t, etcdcv3.LeaseID(1), c.lease.id)
+
+	// The records are attached to a new lease when it has expired.
+	mockLease.On("KeepAliveOnce", mock.Anything, etcdcv3.LeaseID(1)).Return((*etcdcv3.LeaseKeepAliveResponse)(nil), rpctypes.ErrLeaseNotFound).Once()
+	mockLease.On("Grant", mock.Anything, int64(300)).Return(&etcdcv3.LeaseGrantResponse{ID: 2}, nil).Once()
+	require.NoError(t, c.KeepAlive(defaultCoreDNSPrefix))
+	assert.Equal(t, etcdcv3.LeaseID(2), c.lease.id)
+
+	// Other errors are returned.
+	mockLease.On("KeepAliveOnce", mock.Anything, etcdcv3.LeaseID(2)).Return((*etcdcv3.LeaseKeepAliveResponse)(nil), errors.New("etcd failure")).Once()
+	assert.EqualError(t, c.KeepAlive(defaultCoreDNSPrefix), "etcd failure")
+
+	mockKV.AssertNumberOfCalls(t, "Commit", 2)
+	mockLease.AssertExpectations(t)
+
+	// Nothing is done when the lease is disabled.
+	c.lease = nil
+	require.NoError(t, c.KeepAlive(defaultCoreDNSPrefix))
+}
+
+func TestKeepAliveBatches(t *testing.T) {
+	mockKV := new(MockEtcdKV)
+	mockLease := new(MockEtcdLease)
+	c := etcdClient{
+		client: &etcdcv3.Client{
+			KV:    mockKV,
+			Lease: mockLease,
+		},
+		ctx:   context.Background(),
+		lease: &etcdLease{ttl: 5 * time.Minute},
+	}
+
+	var kvs []*mvccpb.KeyValue
+	for i := range 2*etcdLeaseBatchSize + 1 {
+		kvs = append(kvs, &mvccpb.KeyValue{Key: fmt.Appendf(nil, "/external-dns/ownership/skydns/local/domain%d/1a2b3c4d", i)})
+	}
+	mockKV.On("Get", mock.Anything, "/external-dns/ownership/skydns/").Return(&etcdcv3.GetResponse{Kvs: kvs}, nil)
+	var batches []int
+	var deadlines []time.Time
+	mockKV.On("Commit", mock.Anything, mock.Anything, []string(nil)).Run(func(args mock.Arguments) {
+		batches = append(batches, len(args.Get(1).([]string)))
+		deadline, ok := args.Get(0).(context.Context).Deadline()
+		require.True(t, ok)
+		deadlines = append(deadlines, deadline)
+	}).Return(&etcdcv3.TxnResponse{}, nil)
+	mockLease.On("Grant", mock.Anything, int64(300)).Return(&etcdcv3.LeaseGrantResponse{ID: 1}, nil).Once()
+
+	require.NoError(t, c.KeepAlive(defaultCoreDNSPrefix))
+	assert.Equal(t, []int{etcdLeaseBatchSize, etcdLeaseBatchSize, 1}, batches)
+	// Each batch has its own deadline.
+	assert.True(t, deadlines[2].After(deadlines[0]))
+	assert.Equal(t, etcdcv3.LeaseID(1), c.lease.id)
+
+	// The lease is not replaced when a batch fails.
+	c.lease.id = etcdcv3.NoLease
+	mockKV.ExpectedCalls = nil
+	mockKV.On("Get", mock.Anything, "/external-dns/ownership/skydns/").Return(&etcdcv3.GetResponse{Kvs: kvs}, nil)
+	mockKV.On("Commit", mock.Anything, mock.Anything, []string(nil)).Return((*etcdcv3.TxnResponse)(nil), errors.New("etcd failure"))
+	mockLease.On("Grant", mock.Anything, int64(300)).Return(&etcdcv3.LeaseGrantResponse{ID: 2}, nil).Once()
+	assert.EqualError(t, c.KeepAlive(defaultCoreDNSPrefix), "etcd failure")
+	assert.Equal(t, etcdcv3.NoLease, c.lease.id)
+}
+
+func TestCoreDNSOwnership(t *testing.T) {
+	for name, client := range testClients(t) {
+		t.Run(name, func(t *testing.T) {
+			coredns := coreDNSProvider{
+				client:        client,
+				coreDNSPrefix: defaultCoreDNSPrefix,
+			}
+
+			ep := endpoint.NewEndpoint("domain1.local", endpoint.RecordTypeA, "5.5.5.5")
+			ep.Labels[endpoint.OwnerLabelKey] = "default"
+			ep.Labels[endpoint.ResourceLabelKey] = "service/default/domain1"
+			txt := endpoint.NewEndpoint("domain2.local", endpoint.RecordTypeTXT, "string2")
+			txt.Labels[endpoint.OwnerLabelKey] = "other"
+			require.NoError(t, coredns.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{ep, txt}}))
+
+			for _, svc := range servicesOf(t, client) {
+				assert.NotNil(t, svc.Ownership, svc.Key)
+			}
+
+			records, err := coredns.Records(context.Background())
+			require.NoError(t, err)
+			require.Len(t, records, 2)
+			for _, record := range records {
+				switch record.DNSName {
+				case "domain1.local":
+					assert.Equal(t, "default", record.Labels[endpoint.OwnerLabelKey])
+					assert.Equal(t, "service/default/domain1", record.Labels[endpoint.ResourceLabelKey])
+				case "domain2.local":
+					assert.Equal(t, "other", record.Labels[endpoint.OwnerLabelKey])
+				}
+			}
+		})
+	}
+}
+
 func TestNewCoreDNSProvider(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -753,7 +1015,7 @@ func TestNewCoreDNSProvider(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			testutils.TestHelperEnvSetter(t, tt.envs)
 
-			provider, err := NewCoreDNSProvider(&endpoint.DomainFilter{}, "/prefix/", false)
+			provider, err := NewCoreDNSProvider(&endpoint.DomainFilter{}, "/prefix/", 0, false)
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.EqualError(t, err, tt.errMsg)
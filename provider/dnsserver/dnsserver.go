@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultTTL is the TTL of the records of endpoints without TTL.
+	defaultTTL = 300
+	// soaTTL is the TTL of the synthesized SOA and NS records.
+	soaTTL = 300
+	// negativeTTL is the SOA minimum, the TTL of negative answers.
+	negativeTTL = 60
+	// maxCNAMEChain is the maximum number of CNAME records followed to answer a query.
+	maxCNAMEChain = 8
+)
+
+// Config is used for configuring a DNSServerProvider.
+type Config struct {
+	// The zones the server is authoritative for.
+	Zones []string
+	// The host names of the name servers of the zones, published as their NS records.
+	Nameservers []string
+	// The address the server listens on, over UDP and TCP.
+	ListenAddress string
+}
+
+// DNSServerProvider is an implementation of Provider that holds the records in memory and serves them
+// as an authoritative name server, synthesizing the SOA and NS records of the zones.
+type DNSServerProvider struct {
+	provider.BaseProvider
+	zones       []string
+	nameservers []string
+
+	mu        sync.RWMutex
+	endpoints map[endpoint.EndpointKey]*endpoint.Endpoint
+	records   *records
+
+	udpConn     net.PacketConn
+	tcpListener net.Listener
+}
+
+// records is an immutable snapshot of the records served, keyed by lower case fully qualified name
+// and type. Empty non-terminals are present without records.
+type records struct {
+	serial uint32
+	names  map[string]map[uint16][]dns.RR
+}
+
+// NewDNSServerProvider initializes a new DNS server based Provider, which serves the records
+// until the context is done.
+func NewDNSServerProvider(ctx context.Context, cfg Config) (*DNSServerProvider, error) {
+	if len(cfg.Zones) == 0 {
+		return nil, errors.New("no zones specified for the DNS server")
+	}
+	p := &DNSServerProvider{
+		endpoints: map[endpoint.EndpointKey]*endpoint.Endpoint{},
+		records:   &records{serial: uint32(time.Now().Unix()), names: map[string]map[uint16][]dns.RR{}},
+	}
+	for _, zone := range cfg.Zones {
+		p.zones = append(p.zones, strings.ToLower(dns.Fqdn(zone)))
+	}
+	for _, ns := range cfg.Nameservers {
+		p.nameservers = append(p.nameservers, strings.ToLower(dns.Fqdn(ns)))
+	}
+
+	var err error
+	p.udpConn, err = net.ListenPacket("udp", cfg.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s/udp: %w", cfg.ListenAddress, err)
+	}
+	p.tcpListener, err = net.Listen("tcp", cfg.ListenAddress)
+	if err != nil {
+		_ = p.udpConn.Close()
+		return nil, fmt.Errorf("failed to listen on %s/tcp: %w", cfg.ListenAddress, err)
+	}
+
+	servers := []*dns.Server{
+		{PacketConn: p.udpConn, Handler: p},
+		{Listener: p.tcpListener, Handler: p},
+	}
+	for _, server := range servers {
+		go func() {
+			if err := server.ActivateAndServe(); err != nil {
+				log.Errorf("DNS server stopped: %v", err)
+			}
+		}()
+	}
+	go func() {
+		<-ctx.Done()
+		for _, server := range servers {
+			_ = server.Shutdown()
+		}
+	}()
+	log.Infof("Serving zones %s on %s", strings.Join(p.zones, ", "), cfg.ListenAddress)
+
+	return p, nil
+}
+
+// Records returns the endpoints served.
+func (p *DNSServerProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	endpoints := make([]*endpoint.Endpoint, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		endpoints = append(endpoints, ep.DeepCopy())
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].DNSName != endpoints[j].DNSName {
+			return endpoints[i].DNSName < endpoints[j].DNSName
+		}
+		return endpoints[i].RecordType < endpoints[j].RecordType
+	})
+	return endpoints, nil
+}
+
+// ApplyChanges applies the changes to the endpoints served, and increments the serial of the zones.
+func (p *DNSServerProvider) ApplyChanges(_ context.Context, changes *plan.Changes) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, ep := range changes.Delete {
+		log.Infof("Removing %s %s", ep.RecordType, ep.DNSName)
+		delete(p.endpoints, ep.Key())
+	}
+	for _, ep := range append(changes.UpdateNew, changes.Create...) {
+		if p.findZone(ep.DNSName) == "" {
+			log.Warnf("No zone served for %s %s, skipping", ep.RecordType, ep.DNSName)
+			continue
+		}
+		log.Infof("Serving %s %s: %s", ep.RecordType, ep.DNSName, ep.Targets)
+		p.endpoints[ep.Key()] = ep.DeepCopy()
+	}
+
+	p.records = p.buildRecords(p.records.serial + 1)
+	return nil
+}
+
+// buildRecords builds the records served from the endpoints.
+func (p *DNSServerProvider) buildRecords(serial uint32) *records {
+	r := &records{serial: serial, names: map[string]map[uint16][]dns.RR{}}
+	for _, ep := range p.endpoints {
+		name := strings.ToLower(dns.Fqdn(ep.DNSName))
+		zone := p.findZone(name)
+		ttl := int64(defaultTTL)
+		if ep.RecordTTL.IsConfigured() {
+			ttl = int64(ep.RecordTTL)
+		}
+		for _, target := range ep.Targets {
+			rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", name, ttl, ep.RecordType, targetRdata(ep.RecordType, target)))
+			if err != nil || rr == nil {
+				log.Warnf("Skipping invalid %s target %q of %s: %v", ep.RecordType, target, ep.DNSName, err)
+				continue
+			}
+			if r.names[name] == nil {
+				r.names[name] = map[uint16][]dns.RR{}
+			}
+			r.names[name][rr.Header().Rrtype] = append(r.names[name][rr.Header().Rrtype], rr)
+		}
+		// Add the empty non-terminals between the name and the zone.
+		for parent := name; parent != zone; {
+			_, parent, _ = strings.Cut(parent, ".")
+			if r.names[parent] == nil {
+				r.names[parent] = map[uint16][]dns.RR{}
+			}
+		}
+	}
+	return r
+}
+
+// findZone returns the served zone of a name, the longest one that contains it.
+func (p *DNSServerProvider) findZone(name string) string {
+	name = strings.ToLower(dns.Fqdn(name))
+	found := ""
+	for _, zone := range p.zones {
+		if dns.IsSubDomain(zone, name) && len(zone) > len(found) {
+			found = zone
+		}
+	}
+	return found
+}
+
+// targetRdata returns the presentation format of an endpoint target, in which domain
+// names must be fully qualified and text must be quoted.
+func targetRdata(recordType, target string) string {
+	switch recordType {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypePTR:
+		return dns.Fqdn(target)
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		fields := strings.Fields(target)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = dns.Fqdn(fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	case endpoint.RecordTypeTXT:
+		if strings.HasPrefix(target, `"`) {
+			return target
+		}
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(target) + `"`
+	default:
+		return target
+	}
+}
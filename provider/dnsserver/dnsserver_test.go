@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsserver
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func newTestProvider(t *testing.T) *DNSServerProvider {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	p, err := NewDNSServerProvider(ctx, Config{
+		Zones:         []string{"example.com", "sub.example.com."},
+		Nameservers:   []string{"ns1.example.com"},
+		ListenAddress: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("ns1.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "192.0.2.10", "192.0.2.11"),
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpoint("ext.example.com", endpoint.RecordTypeCNAME, "example.org"),
+			endpoint.NewEndpoint("a.b.example.com", endpoint.RecordTypeTXT, "hello world"),
+			endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeA, "192.0.2.20"),
+			endpoint.NewEndpoint("team.example.com", endpoint.RecordTypeNS, "ns.team.example.com"),
+			endpoint.NewEndpoint("ns.team.example.com", endpoint.RecordTypeA, "192.0.2.30"),
+			endpoint.NewEndpoint("app.sub.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "192.0.2.99"),
+		},
+	}))
+	return p
+}
+
+func query(t *testing.T, p *DNSServerProvider, network, name string, qtype uint16) *dns.Msg {
+	t.Helper()
+	addr := p.udpConn.LocalAddr().String()
+	if network == "tcp" {
+		addr = p.tcpListener.Addr().String()
+	}
+	req := new(dns.Msg).SetQuestion(dns.Fqdn(name), qtype)
+	resp, _, err := (&dns.Client{Net: network}).Exchange(req, addr)
+	require.NoError(t, err)
+	return resp
+}
+
+func rrStrings(rrs []dns.RR) []string {
+	var s []string
+	for _, rr := range rrs {
+		s = append(s, rr.String())
+	}
+	return s
+}
+
+func TestNewDNSServerProvider(t *testing.T) {
+	_, err := NewDNSServerProvider(context.Background(), Config{ListenAddress: "127.0.0.1:0"})
+	assert.ErrorContains(t, err, "no zones specified")
+
+	p := newTestProvider(t)
+	_, err = NewDNSServerProvider(context.Background(), Config{Zones: []string{"example.com"}, ListenAddress: p.udpConn.LocalAddr().String()})
+	assert.ErrorContains(t, err, "failed to listen")
+}
+
+func TestDNSServerProviderRecords(t *testing.T) {
+	p := newTestProvider(t)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "ext.example.com")},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.b.example.com", endpoint.RecordTypeTXT, "hello world"),
+			endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeA, "192.0.2.20"),
+			endpoint.NewEndpoint("team.example.com", endpoint.RecordTypeNS, "ns.team.example.com"),
+			endpoint.NewEndpoint("ns.team.example.com", endpoint.RecordTypeA, "192.0.2.30"),
+		},
+	}))
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "ext.example.com"),
+		endpoint.NewEndpoint("app.sub.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		endpoint.NewEndpoint("ext.example.com", endpoint.RecordTypeCNAME, "example.org"),
+		endpoint.NewEndpoint("ns1.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "192.0.2.10", "192.0.2.11"),
+	}, records)
+
+	resp := query(t, p, "udp", "api.example.com", dns.TypeA)
+	assert.Equal(t, []string{"api.example.com.\t300\tIN\tCNAME\text.example.com.", "ext.example.com.\t300\tIN\tCNAME\texample.org."}, rrStrings(resp.Answer))
+}
+
+func TestDNSServerProviderServeDNS(t *testing.T) {
+	p := newTestProvider(t)
+	serial := p.records.serial
+
+	for _, tt := range []struct {
+		title         string
+		network       string
+		name          string
+		qtype         uint16
+		rcode         int
+		authoritative bool
+		answer        []string
+		ns            []string
+		extra         []string
+	}{
+		{
+			title:         "records",
+			name:          "WWW.example.com",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"www.example.com.\t60\tIN\tA\t192.0.2.10", "www.example.com.\t60\tIN\tA\t192.0.2.11"},
+		},
+		{
+			title:         "records over tcp",
+			network:       "tcp",
+			name:          "www.example.com",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"www.example.com.\t60\tIN\tA\t192.0.2.10", "www.example.com.\t60\tIN\tA\t192.0.2.11"},
+		},
+		{
+			title:         "apex SOA",
+			name:          "example.com",
+			qtype:         dns.TypeSOA,
+			authoritative: true,
+			answer:        []string{"example.com.\t300\tIN\tSOA\tns1.example.com. hostmaster.example.com. " + itoa(serial) + " 3600 600 86400 60"},
+		},
+		{
+			title:         "apex NS",
+			name:          "example.com",
+			qtype:         dns.TypeNS,
+			authoritative: true,
+			answer:        []string{"example.com.\t300\tIN\tNS\tns1.example.com."},
+		},
+		{
+			title:         "NODATA",
+			name:          "www.example.com",
+			qtype:         dns.TypeAAAA,
+			authoritative: true,
+			ns:            []string{"example.com.\t60\tIN\tSOA\tns1.example.com. hostmaster.example.com. " + itoa(serial) + " 3600 600 86400 60"},
+		},
+		{
+			title:         "empty non-terminal",
+			name:          "b.example.com",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			ns:            []string{"example.com.\t60\tIN\tSOA\tns1.example.com. hostmaster.example.com. " + itoa(serial) + " 3600 600 86400 60"},
+		},
+		{
+			title:         "NXDOMAIN",
+			name:          "missing.example.com",
+			qtype:         dns.TypeA,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+			ns:            []string{"example.com.\t60\tIN\tSOA\tns1.example.com. hostmaster.example.com. " + itoa(serial) + " 3600 600 86400 60"},
+		},
+		{
+			title:         "CNAME followed in zone",
+			name:          "api.example.com",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer: []string{
+				"api.example.com.\t300\tIN\tCNAME\twww.example.com.",
+				"www.example.com.\t60\tIN\tA\t192.0.2.10",
+				"www.example.com.\t60\tIN\tA\t192.0.2.11",
+			},
+		},
+		{
+			title:         "CNAME out of zone",
+			name:          "ext.example.com",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"ext.example.com.\t300\tIN\tCNAME\texample.org."},
+		},
+		{
+			title:         "TXT",
+			name:          "a.b.example.com",
+			qtype:         dns.TypeTXT,
+			authoritative: true,
+			answer:        []string{"a.b.example.com.\t300\tIN\tTXT\t\"hello world\""},
+		},
+		{
+			title:         "wildcard",
+			name:          "foo.bar.apps.example.com",
+			qtype:         dns.TypeA,
+			authoritative: true,
+			answer:        []string{"foo.bar.apps.example.com.\t300\tIN\tA\t192.0.2.20"},
+		},
+		{
+			title: "delegation",
+			name:  "www.team.example.com",
+			qtype: dns.TypeA,
+			ns:    []string{"team.example.com.\t300\tIN\tNS\tns.team.example.com."},
+			extra: []string{"ns.team.example.com.\t300\tIN\tA\t192.0.2.30"},
+		},
+		{
+			title:         "child zone",
+			name:          "app.sub.example.com",
+			qtype:         dns.TypeAAAA,
+			authoritative: true,
+			answer:        []string{"app.sub.example.com.\t300\tIN\tAAAA\t2001:db8::1"},
+		},
+		{
+			title:         "child zone NXDOMAIN",
+			name:          "missing.sub.example.com",
+			qtype:         dns.TypeA,
+			rcode:         dns.RcodeNameError,
+			authoritative: true,
+			ns:            []string{"sub.example.com.\t60\tIN\tSOA\tns1.example.com. hostmaster.sub.example.com. " + itoa(serial) + " 3600 600 86400 60"},
+		},
+		{
+			title: "not served",
+			name:  "www.example.net",
+			qtype: dns.TypeA,
+			rcode: dns.RcodeRefused,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			network := tt.network
+			if network == "" {
+				network = "udp"
+			}
+			resp := query(t, p, network, tt.name, tt.qtype)
+			assert.Equal(t, tt.rcode, resp.Rcode)
+			assert.Equal(t, tt.authoritative, resp.Authoritative)
+			assert.ElementsMatch(t, tt.answer, rrStrings(resp.Answer))
+			assert.ElementsMatch(t, tt.ns, rrStrings(resp.Ns))
+			assert.ElementsMatch(t, tt.extra, rrStrings(resp.Extra))
+		})
+	}
+}
+
+func TestDNSServerProviderTruncate(t *testing.T) {
+	p := newTestProvider(t)
+
+	var targets []string
+	for i := range 60 {
+		targets = append(targets, "2001:db8::"+itoa(uint32(i+1)))
+	}
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("big.example.com", endpoint.RecordTypeAAAA, targets...)},
+	}))
+
+	resp := query(t, p, "udp", "big.example.com", dns.TypeAAAA)
+	assert.True(t, resp.Truncated)
+
+	resp = query(t, p, "tcp", "big.example.com", dns.TypeAAAA)
+	assert.False(t, resp.Truncated)
+	assert.Len(t, resp.Answer, 60)
+}
+
+func itoa(i uint32) string {
+	return strconv.FormatUint(uint64(i), 10)
+}
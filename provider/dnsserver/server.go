@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsserver
+
+import (
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+)
+
+// ServeDNS implements dns.Handler, answering the queries for the served zones.
+func (p *DNSServerProvider) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	m := p.answer(req)
+
+	size := uint16(dns.MinMsgSize)
+	if opt := req.IsEdns0(); opt != nil {
+		size = max(opt.UDPSize(), size)
+		m.SetEdns0(size, false)
+	}
+	if _, ok := w.LocalAddr().(*net.UDPAddr); ok {
+		m.Truncate(int(size))
+	}
+	if err := w.WriteMsg(m); err != nil {
+		log.Debugf("Failed to write DNS response to %s: %v", w.RemoteAddr(), err)
+	}
+}
+
+// answer returns the response to a query.
+func (p *DNSServerProvider) answer(req *dns.Msg) *dns.Msg {
+	m := new(dns.Msg)
+	switch {
+	case req.Opcode != dns.OpcodeQuery:
+		return m.SetRcode(req, dns.RcodeNotImplemented)
+	case len(req.Question) != 1:
+		return m.SetRcode(req, dns.RcodeFormatError)
+	}
+	m.SetReply(req)
+
+	q := req.Question[0]
+	name := strings.ToLower(q.Name)
+	zone := p.findZone(name)
+	if zone == "" || (q.Qclass != dns.ClassINET && q.Qclass != dns.ClassANY) {
+		return m.SetRcode(req, dns.RcodeRefused)
+	}
+	m.Authoritative = true
+
+	p.mu.RLock()
+	r := p.records
+	p.mu.RUnlock()
+
+	for range maxCNAMEChain {
+		if referral := delegation(r, zone, name); referral != nil && q.Qtype != dns.TypeDS {
+			m.Authoritative = len(m.Answer) > 0
+			m.Ns = referral
+			m.Extra = glue(r, referral)
+			return m
+		}
+
+		rrsets, ok := p.lookup(r, zone, name)
+		if !ok {
+			m.Rcode = dns.RcodeNameError
+			m.Ns = []dns.RR{p.negativeSOA(r, zone)}
+			return m
+		}
+
+		if q.Qtype == dns.TypeANY {
+			for _, rrs := range rrsets {
+				m.Answer = append(m.Answer, rrs...)
+			}
+			return m
+		}
+		if rrs := rrsets[q.Qtype]; len(rrs) > 0 {
+			m.Answer = append(m.Answer, rrs...)
+			return m
+		}
+		cnames := rrsets[dns.TypeCNAME]
+		if len(cnames) == 0 {
+			m.Ns = []dns.RR{p.negativeSOA(r, zone)}
+			return m
+		}
+
+		// Follow the CNAME if its target is in a served zone, otherwise the resolver does.
+		m.Answer = append(m.Answer, cnames[0])
+		name = strings.ToLower(cnames[0].(*dns.CNAME).Target)
+		if zone = p.findZone(name); zone == "" {
+			return m
+		}
+	}
+	return m
+}
+
+// lookup returns the record sets of a name of a zone, including the synthesized SOA and NS records
+// of the apex and the records synthesized from a wildcard, and whether the name exists.
+func (p *DNSServerProvider) lookup(r *records, zone, name string) (map[uint16][]dns.RR, bool) {
+	if name == zone {
+		rrsets := map[uint16][]dns.RR{}
+		for rrtype, rrs := range r.names[name] {
+			rrsets[rrtype] = rrs
+		}
+		rrsets[dns.TypeSOA] = []dns.RR{p.soa(r, zone)}
+		for _, ns := range p.nameservers {
+			rrsets[dns.TypeNS] = append(rrsets[dns.TypeNS], &dns.NS{
+				Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: soaTTL},
+				Ns:  ns,
+			})
+		}
+		return rrsets, true
+	}
+	if rrsets, ok := r.names[name]; ok {
+		return rrsets, true
+	}
+
+	// The wildcard of the closest encloser answers for the names that do not exist.
+	encloser := name
+	for encloser != zone {
+		_, encloser, _ = strings.Cut(encloser, ".")
+		if _, ok := r.names[encloser]; ok {
+			break
+		}
+	}
+	wildcard, ok := r.names["*."+encloser]
+	if !ok {
+		return nil, false
+	}
+	rrsets := map[uint16][]dns.RR{}
+	for rrtype, rrs := range wildcard {
+		for _, rr := range rrs {
+			rr = dns.Copy(rr)
+			rr.Header().Name = name
+			rrsets[rrtype] = append(rrsets[rrtype], rr)
+		}
+	}
+	return rrsets, true
+}
+
+// delegation returns the NS records of the top-most delegation point of a name below the zone apex, if any.
+func delegation(r *records, zone, name string) []dns.RR {
+	var referral []dns.RR
+	for cut := name; cut != zone; _, cut, _ = strings.Cut(cut, ".") {
+		if ns := r.names[cut][dns.TypeNS]; len(ns) > 0 {
+			referral = ns
+		}
+	}
+	return referral
+}
+
+// glue returns the address records of the name servers of a delegation that are in the served records.
+func glue(r *records, referral []dns.RR) []dns.RR {
+	var extra []dns.RR
+	for _, rr := range referral {
+		target := strings.ToLower(rr.(*dns.NS).Ns)
+		extra = append(extra, r.names[target][dns.TypeA]...)
+		extra = append(extra, r.names[target][dns.TypeAAAA]...)
+	}
+	return extra
+}
+
+// soa returns the synthesized SOA record of a zone.
+func (p *DNSServerProvider) soa(r *records, zone string) *dns.SOA {
+	mname := zone
+	if len(p.nameservers) > 0 {
+		mname = p.nameservers[0]
+	}
+	return &dns.SOA{
+		Hdr:     dns.RR_Header{Name: zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: soaTTL},
+		Ns:      mname,
+		Mbox:    "hostmaster." + zone,
+		Serial:  r.serial,
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  negativeTTL,
+	}
+}
+
+// negativeSOA returns the SOA record of the authority section of negative answers, whose TTL
+// is the SOA minimum as per RFC 2308.
+func (p *DNSServerProvider) negativeSOA(r *records, zone string) dns.RR {
+	soa := p.soa(r, zone)
+	soa.Hdr.Ttl = min(soa.Hdr.Ttl, soa.Minttl)
+	return soa
+}
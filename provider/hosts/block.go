@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hosts
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	beginMarker = "# BEGIN EXTERNAL-DNS MANAGED BLOCK"
+	endMarker   = "# END EXTERNAL-DNS MANAGED BLOCK"
+)
+
+// record is an entry of the managed block.
+type record struct {
+	name       string
+	recordType string
+	target     string
+}
+
+// format parses and renders the entries of the managed block.
+type format interface {
+	// supports returns whether a record of the type can be written for the name.
+	supports(name, recordType string) bool
+	// parse returns the records of a line of the managed block.
+	parse(line string) ([]record, error)
+	// render returns the line of a record.
+	render(r record) string
+}
+
+// hostsFile is the content of a file, split around the managed block of an owner.
+type hostsFile struct {
+	content string
+	before  string
+	block   []string
+	after   string
+	begin   string
+	end     string
+}
+
+// splitFile splits the content of a file around the managed block of the owner. The content
+// outside of the block is kept as is, and the block is appended to the file if it has none.
+func splitFile(content, owner string) (*hostsFile, error) {
+	f := &hostsFile{
+		content: content,
+		begin:   fmt.Sprintf("%s (owner=%s)", beginMarker, owner),
+		end:     fmt.Sprintf("%s (owner=%s)", endMarker, owner),
+	}
+	lines := strings.SplitAfter(content, "\n")
+	begin, end := -1, -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case f.begin:
+			if begin >= 0 {
+				return nil, fmt.Errorf("duplicate %q line", f.begin)
+			}
+			begin = i
+		case f.end:
+			if begin < 0 || end >= 0 {
+				return nil, fmt.Errorf("unexpected %q line", f.end)
+			}
+			end = i
+		}
+	}
+	switch {
+	case begin < 0:
+		f.before = content
+	case end < 0:
+		return nil, fmt.Errorf("missing %q line", f.end)
+	default:
+		f.before = strings.Join(lines[:begin], "")
+		f.after = strings.Join(lines[end+1:], "")
+		for _, line := range lines[begin+1 : end] {
+			f.block = append(f.block, strings.TrimRight(line, "\r\n"))
+		}
+	}
+	return f, nil
+}
+
+// String returns the content of the file with the managed block.
+func (f *hostsFile) String() string {
+	var b strings.Builder
+	b.WriteString(f.before)
+	if f.before != "" && !strings.HasSuffix(f.before, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(f.begin + "\n")
+	for _, line := range f.block {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(f.end + "\n")
+	b.WriteString(f.after)
+	return b.String()
+}
+
+// sortRecords sorts the records by name, type and target, for a stable rendering of the block.
+func sortRecords(records []record) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].name != records[j].name {
+			return records[i].name < records[j].name
+		}
+		if records[i].recordType != records[j].recordType {
+			return records[i].recordType < records[j].recordType
+		}
+		return records[i].target < records[j].target
+	})
+}
+
+// addressRecordType returns the record type of an IP address, or an empty string if it is not one.
+func addressRecordType(target string) string {
+	ip := net.ParseIP(target)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return endpoint.RecordTypeA
+	default:
+		return endpoint.RecordTypeAAAA
+	}
+}
+
+// hostsFormat is the format of /etc/hosts: an IP address followed by the host names it resolves for.
+type hostsFormat struct{}
+
+func (hostsFormat) supports(name, recordType string) bool {
+	return (recordType == endpoint.RecordTypeA || recordType == endpoint.RecordTypeAAAA) && !strings.HasPrefix(name, "*.")
+}
+
+func (hostsFormat) parse(line string) ([]record, error) {
+	line, _, _ = strings.Cut(line, "#")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	recordType := addressRecordType(fields[0])
+	if recordType == "" {
+		return nil, fmt.Errorf("invalid IP address %q", fields[0])
+	}
+	var records []record
+	for _, name := range fields[1:] {
+		records = append(records, record{name: strings.ToLower(name), recordType: recordType, target: fields[0]})
+	}
+	return records, nil
+}
+
+func (hostsFormat) render(r record) string {
+	return r.target + "\t" + r.name
+}
+
+// dnsmasqFormat is the format of the dnsmasq configuration: host-record= options for the address
+// records, address= options for the wildcard address records, and cname= options.
+type dnsmasqFormat struct{}
+
+func (dnsmasqFormat) supports(name, recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		return true
+	case endpoint.RecordTypeCNAME:
+		return !strings.HasPrefix(name, "*.")
+	}
+	return false
+}
+
+func (dnsmasqFormat) parse(line string) ([]record, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+	option, value, _ := strings.Cut(line, "=")
+
+	var records []record
+	switch option {
+	case "host-record":
+		// host-record=<name>[,<name>...],[<IPv4 address>],[<IPv6 address>][,<TTL>]
+		var names, targets []string
+		for _, field := range strings.Split(value, ",") {
+			if addressRecordType(field) != "" {
+				targets = append(targets, field)
+			} else if _, err := strconv.Atoi(field); err != nil && field != "" {
+				names = append(names, strings.ToLower(field))
+			}
+		}
+		for _, name := range names {
+			for _, target := range targets {
+				records = append(records, record{name: name, recordType: addressRecordType(target), target: target})
+			}
+		}
+	case "address":
+		// address=/<domain>[/<domain>...]/<IP address>
+		fields := strings.Split(value, "/")
+		target := fields[len(fields)-1]
+		recordType := addressRecordType(target)
+		if len(fields) < 3 || fields[0] != "" || recordType == "" {
+			return nil, fmt.Errorf("unsupported address option %q", line)
+		}
+		for _, domain := range fields[1 : len(fields)-1] {
+			records = append(records, record{name: "*." + strings.ToLower(domain), recordType: recordType, target: target})
+		}
+	case "cname":
+		// cname=<cname>[,<cname>...],<target>[,<TTL>]
+		fields := strings.Split(value, ",")
+		if _, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+			fields = fields[:len(fields)-1]
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid cname option %q", line)
+		}
+		target := strings.ToLower(fields[len(fields)-1])
+		for _, name := range fields[:len(fields)-1] {
+			records = append(records, record{name: strings.ToLower(name), recordType: endpoint.RecordTypeCNAME, target: target})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported option %q", line)
+	}
+	return records, nil
+}
+
+func (dnsmasqFormat) render(r record) string {
+	switch {
+	case r.recordType == endpoint.RecordTypeCNAME:
+		return "cname=" + r.name + "," + r.target
+	case strings.HasPrefix(r.name, "*."):
+		return "address=/" + strings.TrimPrefix(r.name, "*.") + "/" + r.target
+	default:
+		return "host-record=" + r.name + "," + r.target
+	}
+}
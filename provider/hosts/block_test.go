@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hosts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestSplitFile(t *testing.T) {
+	content := `127.0.0.1	localhost
+# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=other)
+192.0.2.9	other.example.com
+# END EXTERNAL-DNS MANAGED BLOCK (owner=other)
+# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)
+192.0.2.1	www.example.com
+# END EXTERNAL-DNS MANAGED BLOCK (owner=default)
+10.0.0.1	router
+`
+	f, err := splitFile(content, "default")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1\twww.example.com"}, f.block)
+	assert.Equal(t, "10.0.0.1\trouter\n", f.after)
+	assert.Equal(t, content, f.String())
+
+	f, err = splitFile("127.0.0.1\tlocalhost", "default")
+	require.NoError(t, err)
+	assert.Empty(t, f.block)
+	assert.Equal(t, `127.0.0.1	localhost
+# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)
+# END EXTERNAL-DNS MANAGED BLOCK (owner=default)
+`, f.String())
+
+	for _, content := range []string{
+		"# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)\n192.0.2.1\twww.example.com\n",
+		"# END EXTERNAL-DNS MANAGED BLOCK (owner=default)\n",
+		"# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)\n# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)\n",
+	} {
+		_, err := splitFile(content, "default")
+		assert.Error(t, err, content)
+	}
+}
+
+func TestHostsFormat(t *testing.T) {
+	f := hostsFormat{}
+
+	records, err := f.parse("192.0.2.1  www.example.com WWW2.example.com # comment")
+	require.NoError(t, err)
+	assert.Equal(t, []record{
+		{name: "www.example.com", recordType: endpoint.RecordTypeA, target: "192.0.2.1"},
+		{name: "www2.example.com", recordType: endpoint.RecordTypeA, target: "192.0.2.1"},
+	}, records)
+
+	records, err = f.parse("  # comment")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	_, err = f.parse("www.example.com 192.0.2.1")
+	assert.Error(t, err)
+
+	assert.Equal(t, "2001:db8::1\twww.example.com", f.render(record{name: "www.example.com", recordType: endpoint.RecordTypeAAAA, target: "2001:db8::1"}))
+	assert.True(t, f.supports("www.example.com", endpoint.RecordTypeAAAA))
+	assert.False(t, f.supports("*.example.com", endpoint.RecordTypeA))
+	assert.False(t, f.supports("www.example.com", endpoint.RecordTypeCNAME))
+}
+
+func TestDnsmasqFormat(t *testing.T) {
+	f := dnsmasqFormat{}
+
+	for _, tt := range []struct {
+		line    string
+		records []record
+	}{
+		{
+			line: "host-record=www.example.com,www2.example.com,192.0.2.1,2001:db8::1,300",
+			records: []record{
+				{name: "www.example.com", recordType: endpoint.RecordTypeA, target: "192.0.2.1"},
+				{name: "www.example.com", recordType: endpoint.RecordTypeAAAA, target: "2001:db8::1"},
+				{name: "www2.example.com", recordType: endpoint.RecordTypeA, target: "192.0.2.1"},
+				{name: "www2.example.com", recordType: endpoint.RecordTypeAAAA, target: "2001:db8::1"},
+			},
+		},
+		{
+			line:    "address=/apps.example.com/192.0.2.2",
+			records: []record{{name: "*.apps.example.com", recordType: endpoint.RecordTypeA, target: "192.0.2.2"}},
+		},
+		{
+			line:    "cname=api.example.com,www.example.com,600",
+			records: []record{{name: "api.example.com", recordType: endpoint.RecordTypeCNAME, target: "www.example.com"}},
+		},
+		{
+			line: "# comment",
+		},
+	} {
+		t.Run(tt.line, func(t *testing.T) {
+			records, err := f.parse(tt.line)
+			require.NoError(t, err)
+			assert.Equal(t, tt.records, records)
+			for _, r := range records {
+				if r.name == tt.records[0].name {
+					parsed, err := f.parse(f.render(r))
+					require.NoError(t, err)
+					assert.Equal(t, []record{r}, parsed)
+				}
+			}
+		})
+	}
+
+	for _, line := range []string{"address=/example.com/", "address=/example.com/#", "cname=api.example.com", "server=/example.com/192.0.2.53"} {
+		_, err := f.parse(line)
+		assert.Error(t, err, line)
+	}
+
+	assert.True(t, f.supports("*.example.com", endpoint.RecordTypeA))
+	assert.False(t, f.supports("*.example.com", endpoint.RecordTypeCNAME))
+	assert.False(t, f.supports("www.example.com", endpoint.RecordTypeTXT))
+}
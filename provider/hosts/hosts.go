@@ -0,0 +1,289 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hosts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// FormatHosts is the format of /etc/hosts.
+	FormatHosts = "hosts"
+	// FormatDnsmasq is the format of the dnsmasq configuration.
+	FormatDnsmasq = "dnsmasq"
+)
+
+// Config is used for configuring a HostsProvider.
+type Config struct {
+	// The file containing the managed block.
+	File string
+	// The format of the file, FormatHosts or FormatDnsmasq.
+	Format string
+	// The owner of the managed block, several owners can manage their own block in the same file.
+	OwnerID string
+	// A shell command run after the file has changed, to reload the resolver.
+	ReloadCommand string
+	// A file containing the PID of the resolver, sent a SIGHUP after the file has changed.
+	ReloadPIDFile string
+	// A filter to apply to the records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed.
+	DryRun bool
+}
+
+// HostsProvider is an implementation of Provider for hosts files and dnsmasq configuration files.
+// The records are written in a managed block of the file, the entries outside of it are kept as they are.
+type HostsProvider struct {
+	provider.BaseProvider
+	file          string
+	format        format
+	ownerID       string
+	reloadCommand string
+	reloadPIDFile string
+	domainFilter  *endpoint.DomainFilter
+	dryRun        bool
+}
+
+// NewHostsProvider initializes a new hosts file based Provider.
+func NewHostsProvider(cfg Config) (*HostsProvider, error) {
+	if cfg.File == "" {
+		return nil, errors.New("no hosts file specified")
+	}
+	p := &HostsProvider{
+		file:          cfg.File,
+		ownerID:       cfg.OwnerID,
+		reloadCommand: cfg.ReloadCommand,
+		reloadPIDFile: cfg.ReloadPIDFile,
+		domainFilter:  cfg.DomainFilter,
+		dryRun:        cfg.DryRun,
+	}
+	switch cfg.Format {
+	case FormatHosts, "":
+		p.format = hostsFormat{}
+	case FormatDnsmasq:
+		p.format = dnsmasqFormat{}
+	default:
+		return nil, fmt.Errorf("unsupported hosts file format %q", cfg.Format)
+	}
+	return p, nil
+}
+
+// load reads the file and returns it with the records of its managed block. A missing file is empty.
+func (p *HostsProvider) load() (*hostsFile, []record, error) {
+	data, err := os.ReadFile(p.file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to read hosts file: %w", err)
+	}
+	f, err := splitFile(string(data), p.ownerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid managed block in hosts file %s: %w", p.file, err)
+	}
+	var records []record
+	for _, line := range f.block {
+		rs, err := p.format.parse(line)
+		if err != nil {
+			log.Warnf("Ignoring line of the managed block of hosts file %s: %v", p.file, err)
+			continue
+		}
+		records = append(records, rs...)
+	}
+	return f, records, nil
+}
+
+// Records returns the records of the managed block.
+func (p *HostsProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	_, records, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, r := range records {
+		if !p.domainFilter.Match(r.name) {
+			continue
+		}
+		key := endpoint.EndpointKey{DNSName: r.name, RecordType: r.recordType}
+		if ep, ok := byKey[key]; ok {
+			ep.Targets = append(ep.Targets, r.target)
+			continue
+		}
+		ep := endpoint.NewEndpoint(r.name, r.recordType, r.target)
+		byKey[key] = ep
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints drops the records that cannot be written in the file, and the TTL that it cannot hold.
+func (p *HostsProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	var adjusted []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if !p.format.supports(ep.DNSName, ep.RecordType) {
+			log.Debugf("Skipping record %s %s not supported by the hosts file format", ep.DNSName, ep.RecordType)
+			continue
+		}
+		ep.RecordTTL = 0
+		adjusted = append(adjusted, ep)
+	}
+	return adjusted, nil
+}
+
+// ApplyChanges rewrites the managed block with the changes, and reloads the resolver if the file has changed.
+// The file is locked while it is rewritten, so that the owners sharing it do not overwrite each other's blocks.
+func (p *HostsProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if !p.dryRun {
+		unlock, err := lockFile(p.file)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	f, records, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	set := map[record]bool{}
+	for _, r := range records {
+		set[r] = true
+	}
+	for _, eps := range [][]*endpoint.Endpoint{changes.Delete, changes.UpdateOld} {
+		for _, ep := range eps {
+			for _, target := range ep.Targets {
+				delete(set, record{name: ep.DNSName, recordType: ep.RecordType, target: target})
+			}
+		}
+	}
+	for _, eps := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
+		for _, ep := range eps {
+			if !p.format.supports(ep.DNSName, ep.RecordType) {
+				log.Warnf("Skipping record %s %s not supported by the hosts file format", ep.DNSName, ep.RecordType)
+				continue
+			}
+			for _, target := range ep.Targets {
+				set[record{name: ep.DNSName, recordType: ep.RecordType, target: target}] = true
+			}
+		}
+	}
+
+	records = records[:0]
+	for r := range set {
+		records = append(records, r)
+	}
+	sortRecords(records)
+	f.block = nil
+	for _, r := range records {
+		f.block = append(f.block, p.format.render(r))
+	}
+	content := f.String()
+	if content == f.content {
+		return nil
+	}
+
+	for _, ep := range changes.Delete {
+		log.Infof("Deleting record %s %s %v from hosts file %s", ep.DNSName, ep.RecordType, ep.Targets, p.file)
+	}
+	for _, ep := range changes.UpdateNew {
+		log.Infof("Updating record %s %s to %v in hosts file %s", ep.DNSName, ep.RecordType, ep.Targets, p.file)
+	}
+	for _, ep := range changes.Create {
+		log.Infof("Creating record %s %s %v in hosts file %s", ep.DNSName, ep.RecordType, ep.Targets, p.file)
+	}
+	if p.dryRun {
+		return nil
+	}
+
+	if err := writeFile(p.file, content); err != nil {
+		return err
+	}
+	return p.reload(ctx)
+}
+
+// writeFile replaces the file atomically, so that the resolver never reads a partially written file.
+// Renaming fails on a file bind-mounted on its own, such as the /etc/hosts of a container.
+func writeFile(path, content string) error {
+	mode := fs.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write hosts file: %w", err)
+	}
+	_, err = tmp.WriteString(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write hosts file %s: %w", path, err)
+	}
+	return nil
+}
+
+// reload runs the reload command and sends a SIGHUP to the resolver, when configured.
+func (p *HostsProvider) reload(ctx context.Context) error {
+	if p.reloadCommand != "" {
+		log.Debugf("Running reload command %q", p.reloadCommand)
+		output, err := exec.CommandContext(ctx, "/bin/sh", "-c", p.reloadCommand).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to run reload command: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+	if p.reloadPIDFile != "" {
+		data, err := os.ReadFile(p.reloadPIDFile)
+		if err != nil {
+			return fmt.Errorf("failed to read resolver PID file: %w", err)
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return fmt.Errorf("invalid resolver PID file %s: %w", p.reloadPIDFile, err)
+		}
+		process, err := os.FindProcess(pid)
+		if err == nil {
+			err = process.Signal(syscall.SIGHUP)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to send SIGHUP to resolver process %d: %w", pid, err)
+		}
+		log.Debugf("Sent SIGHUP to resolver process %d", pid)
+	}
+	return nil
+}
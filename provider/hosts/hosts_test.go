@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hosts
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const testHostsFile = `127.0.0.1	localhost
+192.0.2.100	printer.example.com
+
+# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)
+192.0.2.1	www.example.com
+192.0.2.2	www.example.com
+192.0.2.3	old.example.com
+192.0.2.4	other.example.org
+# END EXTERNAL-DNS MANAGED BLOCK (owner=default)
+10.0.0.1	router
+`
+
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hosts")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o640))
+	return path
+}
+
+func TestNewHostsProvider(t *testing.T) {
+	_, err := NewHostsProvider(Config{})
+	assert.ErrorContains(t, err, "no hosts file specified")
+
+	_, err = NewHostsProvider(Config{File: "/etc/hosts", Format: "unbound"})
+	assert.ErrorContains(t, err, "unsupported hosts file format")
+
+	p, err := NewHostsProvider(Config{File: "/etc/hosts"})
+	require.NoError(t, err)
+	assert.Equal(t, hostsFormat{}, p.format)
+}
+
+func TestHostsProviderRecords(t *testing.T) {
+	p, err := NewHostsProvider(Config{
+		File:         writeTestFile(t, testHostsFile),
+		OwnerID:      "default",
+		DomainFilter: endpoint.NewDomainFilter([]string{"example.com"}),
+	})
+	require.NoError(t, err)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.3"),
+	}, records)
+
+	p.file = filepath.Join(t.TempDir(), "missing")
+	records, err = p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestHostsProviderApplyChanges(t *testing.T) {
+	path := writeTestFile(t, testHostsFile)
+	reloaded := filepath.Join(t.TempDir(), "reloaded")
+	p, err := NewHostsProvider(Config{
+		File:          path,
+		OwnerID:       "default",
+		ReloadCommand: "echo reloaded >> " + reloaded,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1", "192.0.2.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.2", "192.0.2.5")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "192.0.2.3")},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `127.0.0.1	localhost
+192.0.2.100	printer.example.com
+
+# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)
+2001:db8::1	api.example.com
+192.0.2.4	other.example.org
+192.0.2.2	www.example.com
+192.0.2.5	www.example.com
+# END EXTERNAL-DNS MANAGED BLOCK (owner=default)
+10.0.0.1	router
+`, string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	// The file is not written and the resolver not reloaded when nothing changes.
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1")},
+	}))
+	data, err = os.ReadFile(reloaded)
+	require.NoError(t, err)
+	assert.Equal(t, "reloaded\n", string(data))
+
+	p.reloadCommand = "echo failure >&2; exit 1"
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1")},
+	})
+	assert.ErrorContains(t, err, "failed to run reload command: exit status 1: failure")
+}
+
+func TestHostsProviderDnsmasq(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "external-dns.conf")
+	p, err := NewHostsProvider(Config{File: path, Format: FormatDnsmasq, OwnerID: "default"})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+			endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeA, "192.0.2.2"),
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "unsupported"),
+		},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)
+address=/apps.example.com/192.0.2.2
+cname=api.example.com,www.example.com
+host-record=www.example.com,192.0.2.1
+# END EXTERNAL-DNS MANAGED BLOCK (owner=default)
+`, string(data))
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("*.apps.example.com", endpoint.RecordTypeA, "192.0.2.2"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+	}, records)
+}
+
+func TestHostsProviderAdjustEndpoints(t *testing.T) {
+	p, err := NewHostsProvider(Config{File: "/etc/hosts"})
+	require.NoError(t, err)
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1"),
+		endpoint.NewEndpoint("*.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1")}, adjusted)
+}
+
+func TestHostsProviderDryRun(t *testing.T) {
+	path := writeTestFile(t, testHostsFile)
+	p, err := NewHostsProvider(Config{File: path, OwnerID: "default", DryRun: true})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+	}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testHostsFile, string(data))
+}
+
+func TestHostsProviderInvalidBlock(t *testing.T) {
+	content := "# BEGIN EXTERNAL-DNS MANAGED BLOCK (owner=default)\n192.0.2.1\twww.example.com\n"
+	path := writeTestFile(t, content)
+	p, err := NewHostsProvider(Config{File: path, OwnerID: "default"})
+	require.NoError(t, err)
+
+	_, err = p.Records(context.Background())
+	assert.ErrorContains(t, err, "missing \"# END EXTERNAL-DNS MANAGED BLOCK (owner=default)\" line")
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+	})
+	assert.Error(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
+func TestHostsProviderReloadSignal(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	pidFile := filepath.Join(t.TempDir(), "dnsmasq.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644))
+	p, err := NewHostsProvider(Config{File: writeTestFile(t, testHostsFile), OwnerID: "default", ReloadPIDFile: pidFile})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+	}))
+	select {
+	case sig := <-signals:
+		assert.Equal(t, syscall.SIGHUP, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no SIGHUP received")
+	}
+
+	p.reloadPIDFile = filepath.Join(t.TempDir(), "missing.pid")
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+	})
+	assert.ErrorContains(t, err, "failed to read resolver PID file")
+}
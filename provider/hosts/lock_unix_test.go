@@ -0,0 +1,64 @@
+//go:build unix
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestHostsProviderConcurrentOwners(t *testing.T) {
+	path := writeTestFile(t, testHostsFile)
+
+	var wg sync.WaitGroup
+	for _, owner := range []string{"a", "b", "c", "d"} {
+		p, err := NewHostsProvider(Config{File: path, OwnerID: owner})
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 20 {
+				assert.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+					Create: []*endpoint.Endpoint{endpoint.NewEndpoint(fmt.Sprintf("%s%d.example.com", owner, i), endpoint.RecordTypeA, "192.0.2.1")},
+				}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// No owner lost the records of the others.
+	for _, owner := range []string{"a", "b", "c", "d"} {
+		p, err := NewHostsProvider(Config{File: path, OwnerID: owner})
+		require.NoError(t, err)
+		records, err := p.Records(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, records, 20, owner)
+	}
+	_, err := os.Stat(path + ".lock")
+	require.NoError(t, err)
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net"
 	"net/http"
@@ -58,6 +59,13 @@ const (
 	retryLimit = 3
 	// time in milliseconds
 	retryAfterTime = 250 * time.Millisecond
+
+	// ZoneKindNative and ZoneKindMaster are the kinds of the zones created by the provider
+	ZoneKindNative = "Native"
+	ZoneKindMaster = "Master"
+
+	// commentAccount is the account of the comments set on the RRsets
+	commentAccount = "external-dns"
 )
 
 // PDNSConfig is comprised of the fields necessary to create a new PDNSProvider
@@ -68,6 +76,18 @@ type PDNSConfig struct {
 	ServerID     string
 	APIKey       string
 	TLSConfig    TLSConfig
+	// ZoneParent is the domain directly below which the zones missing for new endpoints are created, if set
+	ZoneParent string
+	// ZoneNameservers are the name servers of the created zones
+	ZoneNameservers []string
+	// ZoneKind is the kind of the created zones, Native or Master
+	ZoneKind string
+	// DNSSEC secures the created zones with DNSSEC
+	DNSSEC bool
+	// Rectify rectifies the DNSSEC signed zones after their records have changed
+	Rectify bool
+	// RRSetComments sets a comment with the ownership of the endpoints on their RRsets
+	RRSetComments bool
 }
 
 // TLSConfig is comprised of the TLS-related fields necessary to create a new PDNSProvider
@@ -132,6 +152,9 @@ type PDNSAPIProvider interface {
 	PartitionZones(zones []pgo.Zone) ([]pgo.Zone, []pgo.Zone)
 	ListZone(zoneID string) (pgo.Zone, *http.Response, error)
 	PatchZone(zoneID string, zoneStruct pgo.Zone) (*http.Response, error)
+	CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error)
+	RectifyZone(zoneID string) (*http.Response, error)
+	ListCryptokeys(zoneID string) ([]pgo.Cryptokey, *http.Response, error)
 }
 
 // PDNSAPIClient : Struct that encapsulates all the PowerDNS specific implementation details
@@ -141,6 +164,9 @@ type PDNSAPIClient struct {
 	authCtx      context.Context
 	client       *pgo.APIClient
 	domainFilter *endpoint.DomainFilter
+	// httpClient and basePath are used for the requests the generated client does not send correctly
+	httpClient *http.Client
+	basePath   string
 }
 
 // ListZones : Method returns all enabled zones from PowerDNS
@@ -210,10 +236,114 @@ func (c *PDNSAPIClient) PatchZone(zoneID string, zoneStruct pgo.Zone) (resp *htt
 	return resp, provider.NewSoftError(fmt.Errorf("unable to patch zone: %w", err))
 }
 
+// CreateZone : Method used to create a zone in PowerDNS
+// ref: https://doc.powerdns.com/authoritative/http-api/zone.html#post--servers-server_id-zones
+func (c *PDNSAPIClient) CreateZone(zoneStruct pgo.Zone) (zone pgo.Zone, resp *http.Response, err error) {
+	// The generated client does not send the zone in the request body
+	for i := 0; i < retryLimit; i++ {
+		zone = pgo.Zone{}
+		resp, err = c.do(http.MethodPost, "/servers/"+c.serverID+"/zones", zoneStruct, &zone)
+		if err != nil {
+			log.Debugf("Unable to create zone %v", err)
+			log.Debugf("Retrying CreateZone() ... %d", i)
+			time.Sleep(retryAfterTime * (1 << uint(i)))
+			continue
+		}
+		return zone, resp, err
+	}
+
+	return zone, resp, provider.NewSoftError(fmt.Errorf("unable to create zone: %w", err))
+}
+
+// RectifyZone : Method used to rectify a DNSSEC signed zone in PowerDNS
+// ref: https://doc.powerdns.com/authoritative/http-api/zone.html#put--servers-server_id-zones-zone_id-rectify
+func (c *PDNSAPIClient) RectifyZone(zoneID string) (resp *http.Response, err error) {
+	// The generated client fails to decode the response, which is an object
+	for i := 0; i < retryLimit; i++ {
+		resp, err = c.do(http.MethodPut, "/servers/"+c.serverID+"/zones/"+zoneID+"/rectify", nil, nil)
+		if err != nil {
+			log.Debugf("Unable to rectify zone %v", err)
+			log.Debugf("Retrying RectifyZone() ... %d", i)
+			time.Sleep(retryAfterTime * (1 << uint(i)))
+			continue
+		}
+		return resp, err
+	}
+
+	return resp, provider.NewSoftError(fmt.Errorf("unable to rectify zone: %w", err))
+}
+
+// ListCryptokeys : Method returns the DNSSEC keys of a zone from PowerDNS
+// ref: https://doc.powerdns.com/authoritative/http-api/cryptokey.html#get--servers-server_id-zones-zone_id-cryptokeys
+func (c *PDNSAPIClient) ListCryptokeys(zoneID string) (keys []pgo.Cryptokey, resp *http.Response, err error) {
+	for i := 0; i < retryLimit; i++ {
+		keys, resp, err = c.client.ZonecryptokeyApi.ListCryptokeys(c.authCtx, c.serverID, zoneID)
+		if err != nil {
+			log.Debugf("Unable to list cryptokeys %v", err)
+			log.Debugf("Retrying ListCryptokeys() ... %d", i)
+			time.Sleep(retryAfterTime * (1 << uint(i)))
+			continue
+		}
+		return keys, resp, err
+	}
+
+	return keys, resp, provider.NewSoftError(fmt.Errorf("unable to list cryptokeys: %w", err))
+}
+
+// do sends a JSON request to the API and decodes the response into out, if set
+func (c *PDNSAPIClient) do(method, path string, body, out interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(c.authCtx, method, c.basePath+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if apiKey, ok := c.authCtx.Value(pgo.ContextAPIKey).(pgo.APIKey); ok {
+		req.Header.Set("X-API-Key", apiKey.Key)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	// Keep the body readable for stringifyHTTPResponseBody
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if resp.StatusCode >= 300 {
+		return resp, fmt.Errorf("Status: %v, Body: %s", resp.Status, data)
+	}
+	if out != nil {
+		return resp, json.Unmarshal(data, out)
+	}
+	return resp, nil
+}
+
 // PDNSProvider is an implementation of the Provider interface for PowerDNS
 type PDNSProvider struct {
 	provider.BaseProvider
-	client PDNSAPIProvider
+	client          PDNSAPIProvider
+	zoneParent      string
+	zoneNameservers []string
+	zoneKind        string
+	dnssec          bool
+	rectify         bool
+	rrsetComments   bool
 }
 
 // NewPDNSProvider initializes a new PowerDNS based Provider.
@@ -240,6 +370,17 @@ func NewPDNSProvider(ctx context.Context, config PDNSConfig) (*PDNSProvider, err
 		return nil, err
 	}
 
+	if config.ZoneParent != "" && len(config.ZoneNameservers) == 0 {
+		return nil, errors.New("missing name servers of the zones created by PDNS. Specify using --pdns-zone-nameserver=")
+	}
+	switch config.ZoneKind {
+	case "":
+		config.ZoneKind = ZoneKindNative
+	case ZoneKindNative, ZoneKindMaster:
+	default:
+		return nil, fmt.Errorf("unsupported PDNS zone kind %q", config.ZoneKind)
+	}
+
 	provider := &PDNSProvider{
 		client: &PDNSAPIClient{
 			dryRun:       config.DryRun,
@@ -247,7 +388,15 @@ func NewPDNSProvider(ctx context.Context, config PDNSConfig) (*PDNSProvider, err
 			authCtx:      context.WithValue(ctx, pgo.ContextAPIKey, pgo.APIKey{Key: config.APIKey}),
 			client:       pgo.NewAPIClient(pdnsClientConfig),
 			domainFilter: config.DomainFilter,
+			httpClient:   pdnsClientConfig.HTTPClient,
+			basePath:     pdnsClientConfig.BasePath,
 		},
+		zoneParent:      config.ZoneParent,
+		zoneNameservers: config.ZoneNameservers,
+		zoneKind:        config.ZoneKind,
+		dnssec:          config.DNSSEC,
+		rectify:         config.Rectify,
+		rrsetComments:   config.RRSetComments,
 	}
 	return provider, nil
 }
@@ -333,6 +482,9 @@ func (p *PDNSProvider) ConvertEndpointsToZones(eps []*endpoint.Endpoint, changet
 
 				// DELETEs explicitly forbid a TTL, therefore only PATCHes need the TTL
 				if changetype == PdnsReplace {
+					if p.rrsetComments {
+						rrset.Comments = []pgo.Comment{{Content: ownershipComment(ep), Account: commentAccount}}
+					}
 					if int64(ep.RecordTTL) > int64(math.MaxInt32) {
 						return nil, provider.NewSoftError(fmt.Errorf("value of record TTL overflows, limited to int32"))
 					}
@@ -402,6 +554,145 @@ func (p *PDNSProvider) mutateRecords(endpoints []*endpoint.Endpoint, changetype
 			log.Debugf("PDNS API response: %s", stringifyHTTPResponseBody(resp))
 			return err
 		}
+		if p.rectify && zone.Dnssec {
+			log.Debugf("Rectifying zone %s", zone.Name)
+			resp, err := p.client.RectifyZone(zone.Id)
+			if err != nil {
+				log.Debugf("PDNS API response: %s", stringifyHTTPResponseBody(resp))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ownershipComment returns the content of the comment of the RRset of an endpoint, made of
+// its owner and resource labels
+func ownershipComment(ep *endpoint.Endpoint) string {
+	labels := endpoint.Labels{}
+	for _, key := range []string{endpoint.OwnerLabelKey, endpoint.ResourceLabelKey} {
+		if value, ok := ep.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	return labels.SerializePlain(false)
+}
+
+// zoneNameFor returns the name of the zone directly below the parent containing a DNS name,
+// or an empty string if the name is not below the parent
+func zoneNameFor(dnsname, parent string) string {
+	prefix, ok := strings.CutSuffix(dnsname, "."+parent)
+	if !ok || prefix == "" {
+		return ""
+	}
+	labels := strings.Split(prefix, ".")
+	return labels[len(labels)-1] + "." + parent
+}
+
+// createMissingZones creates the zones directly below the zone parent for the endpoints
+// which have no zone of their own, and delegates them from the parent zone if it exists
+func (p *PDNSProvider) createMissingZones(endpoints []*endpoint.Endpoint) error {
+	zones, _, err := p.client.ListZones()
+	if err != nil {
+		return err
+	}
+	parent := provider.EnsureTrailingDot(p.zoneParent)
+
+	for _, ep := range endpoints {
+		dnsname := provider.EnsureTrailingDot(ep.DNSName)
+		zoneName := zoneNameFor(dnsname, parent)
+		if zoneName == "" {
+			continue
+		}
+		exists := false
+		for _, zone := range zones {
+			if len(zone.Name) >= len(zoneName) && (dnsname == zone.Name || strings.HasSuffix(dnsname, "."+zone.Name)) {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		if filtered, _ := p.client.PartitionZones([]pgo.Zone{{Name: zoneName}}); len(filtered) == 0 {
+			log.Debugf("Not creating zone %s because it does not match the domain filter", zoneName)
+			continue
+		}
+
+		zone, err := p.createZone(zoneName)
+		if err != nil {
+			return err
+		}
+		zones = append(zones, zone)
+
+		for _, parentZone := range zones {
+			if parentZone.Name == parent {
+				if err := p.delegateZone(parentZone, zone); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// createZone creates a zone with the configured kind and name servers
+func (p *PDNSProvider) createZone(name string) (pgo.Zone, error) {
+	nameservers := make([]string, 0, len(p.zoneNameservers))
+	for _, ns := range p.zoneNameservers {
+		nameservers = append(nameservers, provider.EnsureTrailingDot(ns))
+	}
+	log.Infof("CREATE ZONE: %s", name)
+	zone, resp, err := p.client.CreateZone(pgo.Zone{
+		Name:        name,
+		Kind:        p.zoneKind,
+		Nameservers: nameservers,
+		Dnssec:      p.dnssec,
+		ApiRectify:  p.dnssec,
+	})
+	if err != nil {
+		log.Debugf("PDNS API response: %s", stringifyHTTPResponseBody(resp))
+		return pgo.Zone{}, err
+	}
+	return zone, nil
+}
+
+// delegateZone adds the NS records of a created zone to its parent zone, and its DS records
+// if it is DNSSEC signed
+func (p *PDNSProvider) delegateZone(parent, zone pgo.Zone) error {
+	ns := pgo.RrSet{Name: zone.Name, Type_: "NS", Ttl: defaultTTL, Changetype: string(PdnsReplace)}
+	for _, nameserver := range p.zoneNameservers {
+		ns.Records = append(ns.Records, pgo.Record{Content: provider.EnsureTrailingDot(nameserver)})
+	}
+	rrsets := []pgo.RrSet{ns}
+
+	if p.dnssec {
+		keys, resp, err := p.client.ListCryptokeys(zone.Id)
+		if err != nil {
+			log.Debugf("PDNS API response: %s", stringifyHTTPResponseBody(resp))
+			return err
+		}
+		ds := pgo.RrSet{Name: zone.Name, Type_: "DS", Ttl: defaultTTL, Changetype: string(PdnsReplace)}
+		for _, key := range keys {
+			if !key.Active {
+				continue
+			}
+			for _, content := range key.Ds {
+				ds.Records = append(ds.Records, pgo.Record{Content: content})
+			}
+		}
+		if len(ds.Records) > 0 {
+			rrsets = append(rrsets, ds)
+		}
+	}
+
+	log.Infof("DELEGATE ZONE: %s from %s", zone.Name, parent.Name)
+	parent.Rrsets = rrsets
+	resp, err := p.client.PatchZone(parent.Id, parent)
+	if err != nil {
+		log.Debugf("PDNS API response: %s", stringifyHTTPResponseBody(resp))
+		return err
 	}
 	return nil
 }
@@ -459,6 +750,11 @@ func (p *PDNSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	// call to mutate records with an empty list of endpoints is still a
 	// valid call and a no-op, but we might as well not make the call to
 	// prevent unnecessary logging
+	if len(changes.Create) > 0 && p.zoneParent != "" {
+		if err := p.createMissingZones(changes.Create); err != nil {
+			return err
+		}
+	}
 	if len(changes.Create) > 0 {
 		// "Replacing" non-existent records creates them
 		err := p.mutateRecords(changes.Create, PdnsReplace)
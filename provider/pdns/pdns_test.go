@@ -19,7 +19,9 @@ package pdns
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
@@ -28,6 +30,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -672,6 +675,18 @@ func (c *PDNSAPIClientStub) PatchZone(zoneID string, zoneStruct pgo.Zone) (*http
 	return &http.Response{}, nil
 }
 
+func (c *PDNSAPIClientStub) CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error) {
+	return zoneStruct, &http.Response{}, nil
+}
+
+func (c *PDNSAPIClientStub) RectifyZone(zoneID string) (*http.Response, error) {
+	return &http.Response{}, nil
+}
+
+func (c *PDNSAPIClientStub) ListCryptokeys(zoneID string) ([]pgo.Cryptokey, *http.Response, error) {
+	return nil, &http.Response{}, nil
+}
+
 /******************************************************************************/
 // API that returns a zones with no records
 type PDNSAPIClientStubEmptyZones struct {
@@ -703,6 +718,18 @@ func (c *PDNSAPIClientStubEmptyZones) PatchZone(zoneID string, zoneStruct pgo.Zo
 	return &http.Response{}, nil
 }
 
+func (c *PDNSAPIClientStubEmptyZones) CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error) {
+	return zoneStruct, &http.Response{}, nil
+}
+
+func (c *PDNSAPIClientStubEmptyZones) RectifyZone(zoneID string) (*http.Response, error) {
+	return &http.Response{}, nil
+}
+
+func (c *PDNSAPIClientStubEmptyZones) ListCryptokeys(zoneID string) ([]pgo.Cryptokey, *http.Response, error) {
+	return nil, &http.Response{}, nil
+}
+
 /******************************************************************************/
 // API that returns error on PatchZone()
 type PDNSAPIClientStubPatchZoneFailure struct {
@@ -768,6 +795,43 @@ func (c *PDNSAPIClientStubPartitionZones) PartitionZones(zones []pgo.Zone) ([]pg
 	return []pgo.Zone{ZoneEmpty}, []pgo.Zone{ZoneEmptyLong, ZoneEmpty2}
 }
 
+/******************************************************************************/
+// API that keeps track of the zones it creates and rectifies
+type PDNSAPIClientStubZoneCreation struct {
+	// Anonymous struct for composition
+	PDNSAPIClientStubEmptyZones
+	createdZones    []pgo.Zone
+	rectifiedZones  []string
+	cryptokeysZones []string
+}
+
+func (c *PDNSAPIClientStubZoneCreation) ListZones() ([]pgo.Zone, *http.Response, error) {
+	zones := []pgo.Zone{ZoneEmpty, ZoneEmptyLong, ZoneEmpty2}
+	for _, zone := range c.createdZones {
+		zones = append(zones, pgo.Zone{Id: zone.Name, Name: zone.Name, Kind: zone.Kind, Dnssec: zone.Dnssec})
+	}
+	return zones, nil, nil
+}
+
+func (c *PDNSAPIClientStubZoneCreation) CreateZone(zoneStruct pgo.Zone) (pgo.Zone, *http.Response, error) {
+	c.createdZones = append(c.createdZones, zoneStruct)
+	zoneStruct.Id = zoneStruct.Name
+	return zoneStruct, &http.Response{}, nil
+}
+
+func (c *PDNSAPIClientStubZoneCreation) RectifyZone(zoneID string) (*http.Response, error) {
+	c.rectifiedZones = append(c.rectifiedZones, zoneID)
+	return &http.Response{}, nil
+}
+
+func (c *PDNSAPIClientStubZoneCreation) ListCryptokeys(zoneID string) ([]pgo.Cryptokey, *http.Response, error) {
+	c.cryptokeysZones = append(c.cryptokeysZones, zoneID)
+	return []pgo.Cryptokey{
+		{Keytype: "csk", Active: true, Ds: []string{"12345 13 2 abcdef"}},
+		{Keytype: "zsk", Active: false, Ds: []string{"54321 13 2 fedcba"}},
+	}, &http.Response{}, nil
+}
+
 /******************************************************************************/
 
 type NewPDNSProviderTestSuite struct {
@@ -811,6 +875,26 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSProviderCreate() {
 			DomainFilter: endpoint.NewDomainFilter([]string{""}),
 		})
 	suite.NoError(err, "Regular case should raise no error")
+
+	_, err = NewPDNSProvider(
+		context.Background(),
+		PDNSConfig{
+			Server:     "http://localhost:8081",
+			APIKey:     "foo",
+			ZoneParent: "tenants.example.com",
+		})
+	suite.Error(err, "--pdns-zone-parent without --pdns-zone-nameserver should raise an error")
+
+	_, err = NewPDNSProvider(
+		context.Background(),
+		PDNSConfig{
+			Server:          "http://localhost:8081",
+			APIKey:          "foo",
+			ZoneParent:      "tenants.example.com",
+			ZoneNameservers: []string{"ns1.example.com"},
+			ZoneKind:        "Slave",
+		})
+	suite.Error(err, "an unsupported --pdns-zone-kind should raise an error")
 }
 
 func (suite *NewPDNSProviderTestSuite) TestPDNSProviderCreateTLS() {
@@ -1161,3 +1245,170 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 func TestNewPDNSProviderTestSuite(t *testing.T) {
 	suite.Run(t, new(NewPDNSProviderTestSuite))
 }
+
+func (suite *NewPDNSProviderTestSuite) TestPDNSZoneNameFor() {
+	suite.Equal("acme.example.com.", zoneNameFor("www.acme.example.com.", "example.com."))
+	suite.Equal("acme.example.com.", zoneNameFor("acme.example.com.", "example.com."))
+	suite.Equal("acme.example.com.", zoneNameFor("a.b.acme.example.com.", "example.com."))
+	suite.Equal("", zoneNameFor("example.com.", "example.com."))
+	suite.Equal("", zoneNameFor("www.simexample.com.", "example.com."))
+}
+
+func (suite *NewPDNSProviderTestSuite) TestPDNSCreateMissingZones() {
+	c := &PDNSAPIClientStubZoneCreation{}
+	p := &PDNSProvider{
+		client:          c,
+		zoneParent:      "example.com",
+		zoneNameservers: []string{"ns1.example.com", "ns2.example.com."},
+		zoneKind:        ZoneKindNative,
+		dnssec:          true,
+	}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.acme.example.com", endpoint.RecordTypeA, "8.8.8.8"),
+			endpoint.NewEndpoint("api.acme.example.com", endpoint.RecordTypeA, "8.8.4.4"),
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "8.8.8.8"),
+			endpoint.NewEndpoint("www.long.domainname.example.com", endpoint.RecordTypeA, "8.8.8.8"),
+			endpoint.NewEndpoint("www.mock.test", endpoint.RecordTypeA, "8.8.8.8"),
+		},
+	})
+	suite.NoError(err)
+	suite.Equal([]pgo.Zone{{
+		Name:        "acme.example.com.",
+		Kind:        ZoneKindNative,
+		Nameservers: []string{"ns1.example.com.", "ns2.example.com."},
+		Dnssec:      true,
+		ApiRectify:  true,
+	}}, c.createdZones)
+	suite.Equal([]string{"acme.example.com."}, c.cryptokeysZones)
+
+	// The created zone is delegated from its parent before its records are added
+	suite.Require().NotEmpty(c.patchedZones)
+	suite.Equal("example.com.", c.patchedZones[0].Name)
+	suite.Equal([]pgo.RrSet{
+		{
+			Name:       "acme.example.com.",
+			Type_:      "NS",
+			Ttl:        defaultTTL,
+			Changetype: "REPLACE",
+			Records:    []pgo.Record{{Content: "ns1.example.com."}, {Content: "ns2.example.com."}},
+		},
+		{
+			Name:       "acme.example.com.",
+			Type_:      "DS",
+			Ttl:        defaultTTL,
+			Changetype: "REPLACE",
+			Records:    []pgo.Record{{Content: "12345 13 2 abcdef"}},
+		},
+	}, c.patchedZones[0].Rrsets)
+
+	var acme *pgo.Zone
+	for i := range c.patchedZones[1:] {
+		if c.patchedZones[1+i].Name == "acme.example.com." {
+			acme = &c.patchedZones[1+i]
+		}
+	}
+	suite.Require().NotNil(acme, "the records should be added to the created zone")
+	suite.Len(acme.Rrsets, 2)
+
+	// Zones are not created for the records outside of the parent or the domain filter
+	c = &PDNSAPIClientStubZoneCreation{}
+	p.client = c
+	p.dnssec = false
+	err = p.createMissingZones([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.acme.mock.test", endpoint.RecordTypeA, "8.8.8.8"),
+	})
+	suite.NoError(err)
+	suite.Empty(c.createdZones)
+}
+
+func (suite *NewPDNSProviderTestSuite) TestPDNSRectify() {
+	c := &PDNSAPIClientStubZoneCreation{createdZones: []pgo.Zone{{Name: "signed.example.com.", Dnssec: true}}}
+	p := &PDNSProvider{client: c, rectify: true}
+
+	err := p.mutateRecords([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.signed.example.com", endpoint.RecordTypeA, "8.8.8.8"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "8.8.8.8"),
+	}, PdnsReplace)
+	suite.NoError(err)
+	suite.Equal([]string{"signed.example.com."}, c.rectifiedZones)
+}
+
+func (suite *NewPDNSProviderTestSuite) TestPDNSRRSetComments() {
+	c := &PDNSAPIClientStubEmptyZones{}
+	p := &PDNSProvider{client: c, rrsetComments: true}
+
+	ep := endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "8.8.8.8")
+	ep.Labels[endpoint.OwnerLabelKey] = "my-cluster"
+	ep.Labels[endpoint.ResourceLabelKey] = "ingress/default/www"
+	ep.Labels["other"] = "ignored"
+
+	suite.NoError(p.mutateRecords([]*endpoint.Endpoint{ep}, PdnsReplace))
+	suite.Require().Len(c.patchedZones, 1)
+	suite.Equal([]pgo.Comment{{
+		Content: "heritage=external-dns,external-dns/owner=my-cluster,external-dns/resource=ingress/default/www",
+		Account: "external-dns",
+	}}, c.patchedZones[0].Rrsets[0].Comments)
+
+	// Deletions carry no comment
+	c.patchedZones = nil
+	suite.NoError(p.mutateRecords([]*endpoint.Endpoint{ep}, PdnsDelete))
+	suite.Require().Len(c.patchedZones, 1)
+	suite.Empty(c.patchedZones[0].Rrsets[0].Comments)
+}
+
+func TestPDNSAPIClientCreateRectifyZone(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "TEST-API-KEY" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/servers/localhost/zones":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"acme.example.com.","name":"acme.example.com.","kind":"Native","dnssec":true}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/servers/localhost/zones/acme.example.com./rectify":
+			w.Write([]byte(`{"result":"Rectified"}`))
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error":"Domain 'missing.example.com.' does not exist"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := &PDNSAPIClient{
+		serverID: "localhost",
+		authCtx:  context.WithValue(context.Background(), pgo.ContextAPIKey, pgo.APIKey{Key: "TEST-API-KEY"}),
+		basePath: srv.URL + apiBase,
+	}
+
+	zone, _, err := c.CreateZone(pgo.Zone{Name: "acme.example.com.", Kind: ZoneKindNative, Nameservers: []string{"ns1.example.com."}, Dnssec: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone.Id != "acme.example.com." || !zone.Dnssec {
+		t.Errorf("unexpected created zone %+v", zone)
+	}
+	if _, err := c.RectifyZone("acme.example.com."); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`POST /api/v1/servers/localhost/zones {"name":"acme.example.com.","kind":"Native","dnssec":true,"nameservers":["ns1.example.com."]}`,
+		`PUT /api/v1/servers/localhost/zones/acme.example.com./rectify `,
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(requests, "\n"))
+	}
+
+	resp, err := c.do(http.MethodPut, "/servers/localhost/zones/missing.example.com./rectify", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected an error, got %v", err)
+	}
+	if body := stringifyHTTPResponseBody(resp); !strings.Contains(body, "does not exist") {
+		t.Errorf("expected the response body to be readable, got %q", body)
+	}
+}
@@ -21,8 +21,10 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"net"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -44,8 +46,33 @@ import (
 const (
 	// maximum time DNS client can be off from server for an update to succeed
 	clockSkew = 300
+	// default port of the secondary name servers to notify
+	defaultNotifyPort = "53"
 )
 
+// errConflict is returned when the name server rejects an update because its prerequisites failed,
+// as the records were changed since they were read
+var errConflict = errors.New("records changed concurrently")
+
+// Response codes of the failed prerequisites of an update (RFC 2136 3.2)
+var prerequisiteRcodes = []int{
+	dns.RcodeYXDomain,
+	dns.RcodeYXRrset,
+	dns.RcodeNXRrset,
+	dns.RcodeNameError,
+}
+
+// DNSSEC records generated by the name servers signing zones, never managed by the provider
+var dnssecTypes = []uint16{
+	dns.TypeRRSIG,
+	dns.TypeNSEC,
+	dns.TypeNSEC3,
+	dns.TypeNSEC3PARAM,
+	dns.TypeDNSKEY,
+	dns.TypeCDS,
+	dns.TypeCDNSKEY,
+}
+
 // rfc2136 provider type
 type rfc2136Provider struct {
 	provider.BaseProvider
@@ -61,6 +88,16 @@ type rfc2136Provider struct {
 	tlsConfig       TLSConfig
 	createPTR       bool
 
+	// secondary name servers notified of the changes of the zones
+	notifyHosts []string
+
+	// fetch the changes of the zones with IXFR, from the last transferred snapshot of each zone
+	ixfr      bool
+	snapshots map[string]*zoneSnapshot
+
+	// add prerequisites to the updates, so that they fail if the records changed since they were read
+	prerequisites bool
+
 	// options specific to rfc3645 gss-tsig support
 	gssTsig      bool
 	krb5Username string
@@ -107,10 +144,11 @@ var tsigAlgs = map[string]string{
 type rfc2136Actions interface {
 	SendMessage(msg *dns.Msg) error
 	IncomeTransfer(m *dns.Msg, nameserver string) (env chan *dns.Envelope, err error)
+	SendNotify(msg *dns.Msg, secondary string) error
 }
 
 // NewRfc2136Provider is a factory function for OpenStack rfc2136 providers
-func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure bool, keyName string, secret string, secretAlg string, axfr bool, domainFilter *endpoint.DomainFilter, dryRun bool, minTTL time.Duration, createPTR bool, gssTsig bool, krb5Username string, krb5Password string, krb5Realm string, batchChangeSize int, tlsConfig TLSConfig, loadBalancingStrategy string, actions rfc2136Actions) (provider.Provider, error) {
+func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure bool, keyName string, secret string, secretAlg string, axfr bool, domainFilter *endpoint.DomainFilter, dryRun bool, minTTL time.Duration, createPTR bool, gssTsig bool, krb5Username string, krb5Password string, krb5Realm string, batchChangeSize int, tlsConfig TLSConfig, loadBalancingStrategy string, notifyHosts []string, ixfr bool, prerequisites bool, actions rfc2136Actions) (provider.Provider, error) {
 	secretAlgChecked, ok := tsigAlgs[secretAlg]
 	if !ok && !insecure && !gssTsig {
 		return nil, fmt.Errorf("%s is not supported TSIG algorithm", secretAlg)
@@ -132,6 +170,14 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 		nameservers = append(nameservers, host)
 	}
 
+	var secondaries []string
+	for _, host := range notifyHosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(host, defaultNotifyPort)
+		}
+		secondaries = append(secondaries, host)
+	}
+
 	r := &rfc2136Provider{
 		nameservers:           nameservers,
 		zoneNames:             zoneNames,
@@ -148,6 +194,10 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 		batchChangeSize:       batchChangeSize,
 		tlsConfig:             tlsConfig,
 		loadBalancingStrategy: loadBalancingStrategy,
+		notifyHosts:           secondaries,
+		ixfr:                  ixfr,
+		snapshots:             make(map[string]*zoneSnapshot),
+		prerequisites:         prerequisites,
 		randGen:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		counter:               0,
 		lastErr:               nil,
@@ -275,58 +325,197 @@ func (r *rfc2136Provider) List() ([]dns.RR, error) {
 	for _, zone := range r.zoneNames {
 		log.Debugf("Fetching records for '%q'", zone)
 
-		m := new(dns.Msg)
-		m.SetAxfr(dns.Fqdn(zone))
-		if !r.insecure && !r.gssTsig {
-			m.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
+		rrs, err := r.listZone(dns.Fqdn(zone))
+		if err != nil {
+			return nil, err
 		}
+		records = append(records, rrs...)
+	}
 
-		var lastErr error
-		for i := 0; i < len(r.nameservers); i++ {
-			nameserver := r.getNextNameserver()
-			log.Debugf("Fetching records from nameserver: %s", nameserver)
+	return records, nil
+}
 
-			env, err := r.actions.IncomeTransfer(m, nameserver)
-			if err != nil {
-				lastErr = fmt.Errorf("failed to fetch records via AXFR: %w", err)
-				r.lastErr = lastErr
-				continue
+// listZone fetches the records of a zone. With IXFR enabled, only the changes since the last
+// snapshot of the zone are transferred, falling back to AXFR when there is no usable snapshot.
+func (r *rfc2136Provider) listZone(zone string) ([]dns.RR, error) {
+	if r.ixfr {
+		if snapshot, ok := r.snapshots[zone]; ok {
+			m := new(dns.Msg)
+			m.SetIxfr(zone, snapshot.soa.Serial, snapshot.soa.Ns, snapshot.soa.Mbox)
+			rrs, err := r.transfer(m)
+			if err == nil {
+				snapshot, err = snapshot.update(rrs)
 			}
+			if err == nil {
+				r.snapshots[zone] = snapshot
+				return snapshot.records(), nil
+			}
+			log.Warnf("Failed to fetch the changes of zone %s via IXFR, falling back to AXFR: %v", zone, err)
+			delete(r.snapshots, zone)
+		}
+	}
 
-			for e := range env {
-				if e.Error != nil {
-					if errors.Is(e.Error, dns.ErrSoa) {
-						log.Error("AXFR error: unexpected response received from the server")
-					} else {
-						log.Errorf("AXFR error: %v", e.Error)
-					}
-					continue
+	m := new(dns.Msg)
+	m.SetAxfr(zone)
+	rrs, err := r.transfer(m)
+	if err != nil {
+		return nil, err
+	}
+	if !r.ixfr {
+		return rrs, nil
+	}
+
+	snapshot, err := newZoneSnapshot(rrs)
+	if err != nil {
+		// The records are still usable, only the next transfer will not be incremental
+		log.Warnf("Failed to keep a snapshot of zone %s for IXFR: %v", zone, err)
+		return rrs, nil
+	}
+	r.snapshots[zone] = snapshot
+	return snapshot.records(), nil
+}
+
+// transfer sends a zone transfer request to the name servers until one of them returns records,
+// and returns the transferred records without the DNSSEC records of signed zones.
+func (r *rfc2136Provider) transfer(m *dns.Msg) ([]dns.RR, error) {
+	if !r.insecure && !r.gssTsig {
+		m.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
+	}
+	xfrType := dns.TypeToString[m.Question[0].Qtype]
+
+	records := make([]dns.RR, 0)
+	var lastErr error
+	for i := 0; i < len(r.nameservers); i++ {
+		nameserver := r.getNextNameserver()
+		log.Debugf("Fetching records from nameserver: %s", nameserver)
+
+		env, err := r.actions.IncomeTransfer(m, nameserver)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to fetch records via %s: %w", xfrType, err)
+			r.lastErr = lastErr
+			continue
+		}
+
+		for e := range env {
+			if e.Error != nil {
+				if errors.Is(e.Error, dns.ErrSoa) {
+					log.Errorf("%s error: unexpected response received from the server", xfrType)
+				} else {
+					log.Errorf("%s error: %v", xfrType, e.Error)
 				}
-				records = append(records, e.RR...)
+				continue
 			}
-			// If records were fetched successfully, break out of the loop
-			if len(records) > 0 {
-				break
+			for _, rr := range e.RR {
+				if slices.Contains(dnssecTypes, rr.Header().Rrtype) {
+					continue
+				}
+				records = append(records, rr)
 			}
 		}
-
-		if lastErr != nil {
-			r.lastErr = lastErr
-			return nil, lastErr
+		// If records were fetched successfully, break out of the loop
+		if len(records) > 0 {
+			return records, nil
 		}
 	}
 
+	if lastErr != nil {
+		r.lastErr = lastErr
+		return nil, lastErr
+	}
+
 	return records, nil
 }
 
+// zoneSnapshot holds the records of a zone at the serial of its SOA record, to which the
+// changes transferred with IXFR are applied.
+type zoneSnapshot struct {
+	soa *dns.SOA
+	rrs []dns.RR
+}
+
+// newZoneSnapshot returns the snapshot of a zone transferred entirely, which starts with its
+// SOA record, repeated at the end.
+func newZoneSnapshot(rrs []dns.RR) (*zoneSnapshot, error) {
+	if len(rrs) == 0 {
+		return nil, dns.ErrSoa
+	}
+	soa, ok := rrs[0].(*dns.SOA)
+	if !ok {
+		return nil, dns.ErrSoa
+	}
+
+	records := make([]dns.RR, 0, len(rrs))
+	for _, rr := range rrs[1:] {
+		if rr.Header().Rrtype != dns.TypeSOA {
+			records = append(records, rr)
+		}
+	}
+	return &zoneSnapshot{soa: soa, rrs: records}, nil
+}
+
+// records returns the records of the zone, starting with its SOA record.
+func (s *zoneSnapshot) records() []dns.RR {
+	return append([]dns.RR{s.soa}, s.rrs...)
+}
+
+// update returns the snapshot of the zone after the records of an IXFR response (RFC 1995).
+// They are either the current SOA record alone when the zone has not changed, the entire zone
+// when the server cannot send incremental changes, or sequences of deleted records, starting
+// with the SOA record of their serial, and of added records, starting with the SOA record of
+// their serial, enclosed in the current SOA record.
+func (s *zoneSnapshot) update(rrs []dns.RR) (*zoneSnapshot, error) {
+	if len(rrs) == 0 {
+		return nil, dns.ErrSoa
+	}
+	current, ok := rrs[0].(*dns.SOA)
+	if !ok {
+		return nil, dns.ErrSoa
+	}
+
+	if len(rrs) == 1 {
+		if current.Serial != s.soa.Serial {
+			return nil, fmt.Errorf("unexpected serial %d of unchanged zone at serial %d", current.Serial, s.soa.Serial)
+		}
+		return s, nil
+	}
+	if _, ok := rrs[1].(*dns.SOA); !ok {
+		return newZoneSnapshot(rrs)
+	}
+
+	if first := rrs[1].(*dns.SOA); first.Serial != s.soa.Serial {
+		return nil, fmt.Errorf("changes starting at serial %d instead of serial %d", first.Serial, s.soa.Serial)
+	}
+	if last, ok := rrs[len(rrs)-1].(*dns.SOA); !ok || last.Serial != current.Serial {
+		return nil, errors.New("incomplete incremental zone transfer")
+	}
+
+	records := slices.Clone(s.rrs)
+	// The first SOA record starts the deleted records
+	adding := true
+	for _, rr := range rrs[1 : len(rrs)-1] {
+		if rr.Header().Rrtype == dns.TypeSOA {
+			adding = !adding
+			continue
+		}
+		if adding {
+			records = append(records, rr)
+		} else {
+			records = slices.DeleteFunc(records, func(existing dns.RR) bool {
+				return dns.IsDuplicate(existing, rr)
+			})
+		}
+	}
+	return &zoneSnapshot{soa: current, rrs: records}, nil
+}
+
 func (r *rfc2136Provider) AddReverseRecord(ip string, hostname string) error {
 	changes := r.GenerateReverseRecord(ip, hostname)
-	return r.ApplyChanges(context.Background(), &plan.Changes{Create: changes})
+	return r.applyChanges(&plan.Changes{Create: changes}, false)
 }
 
 func (r *rfc2136Provider) RemoveReverseRecord(ip string, hostname string) error {
 	changes := r.GenerateReverseRecord(ip, hostname)
-	return r.ApplyChanges(context.Background(), &plan.Changes{Delete: changes})
+	return r.applyChanges(&plan.Changes{Delete: changes}, false)
 }
 
 func (r *rfc2136Provider) GenerateReverseRecord(ip string, hostname string) []*endpoint.Endpoint {
@@ -348,9 +537,18 @@ func (r *rfc2136Provider) GenerateReverseRecord(ip string, hostname string) []*e
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return r.applyChanges(changes, r.prerequisites)
+}
+
+// applyChanges applies the changes, with prerequisites rejecting the changes of the records
+// changed since they were read if enabled. The reverse records are generated rather than read,
+// so they are changed without prerequisites.
+func (r *rfc2136Provider) applyChanges(changes *plan.Changes, prerequisites bool) error {
 	log.Debugf("ApplyChanges (Create: %d, UpdateOld: %d, UpdateNew: %d, Delete: %d)", len(changes.Create), len(changes.UpdateOld), len(changes.UpdateNew), len(changes.Delete))
 
 	var errs []error
+	// zones successfully updated, to notify the secondary name servers of
+	updated := make(map[string]bool)
 
 	for c, chunk := range chunkBy(changes.Create, r.batchChangeSize) {
 		log.Debugf("Processing batch %d of create changes", c)
@@ -370,6 +568,9 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 			zone := findMsgZone(ep, r.zoneNames)
 			m[zone].SetUpdate(zone)
 
+			if prerequisites {
+				requireRRsetAbsent(m[zone], ep)
+			}
 			r.AddRecord(m[zone], ep)
 
 			if r.createPTR && (ep.RecordType == "A" || ep.RecordType == "AAAA") {
@@ -378,13 +579,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 		}
 
 		// only send if there are records available
-		for _, z := range m {
+		for zone, z := range m {
 			if len(z.Ns) > 0 {
 				if err := r.actions.SendMessage(z); err != nil {
 					log.Errorf("RFC2136 create record failed: %v", err)
 					errs = append(errs, err)
 					continue
 				}
+				updated[zone] = true
 			}
 		}
 	}
@@ -408,6 +610,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 			zone := findMsgZone(ep, r.zoneNames)
 			m[zone].SetUpdate(zone)
 
+			if prerequisites {
+				if err := requireRRset(m[zone], changes.UpdateOld[i]); err != nil {
+					// without its prerequisite, the update could overwrite a record changed concurrently
+					log.Errorf("RFC2136 update of %s skipped: %v", ep.DNSName, err)
+					errs = append(errs, err)
+					continue
+				}
+			}
 			r.UpdateRecord(m[zone], changes.UpdateOld[i], ep)
 			if r.createPTR && (ep.RecordType == "A" || ep.RecordType == "AAAA") {
 				r.RemoveReverseRecord(changes.UpdateOld[i].Targets[0], ep.DNSName)
@@ -416,13 +626,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 		}
 
 		// only send if there are records available
-		for _, z := range m {
+		for zone, z := range m {
 			if len(z.Ns) > 0 {
 				if err := r.actions.SendMessage(z); err != nil {
 					log.Errorf("RFC2136 update record failed: %v", err)
 					errs = append(errs, err)
 					continue
 				}
+				updated[zone] = true
 			}
 		}
 	}
@@ -445,6 +656,14 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 			zone := findMsgZone(ep, r.zoneNames)
 			m[zone].SetUpdate(zone)
 
+			if prerequisites {
+				if err := requireRRset(m[zone], ep); err != nil {
+					// without its prerequisite, the deletion could remove a record changed concurrently
+					log.Errorf("RFC2136 deletion of %s skipped: %v", ep.DNSName, err)
+					errs = append(errs, err)
+					continue
+				}
+			}
 			r.RemoveRecord(m[zone], ep)
 			if r.createPTR && (ep.RecordType == "A" || ep.RecordType == "AAAA") {
 				r.RemoveReverseRecord(ep.Targets[0], ep.DNSName)
@@ -452,24 +671,71 @@ func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Change
 		}
 
 		// only send if there are records available
-		for _, z := range m {
+		for zone, z := range m {
 			if len(z.Ns) > 0 {
 				if err := r.actions.SendMessage(z); err != nil {
 					log.Errorf("RFC2136 delete record failed: %v", err)
 					errs = append(errs, err)
 					continue
 				}
+				updated[zone] = true
 			}
 		}
 	}
 
+	r.notify(updated)
+
 	if len(errs) > 0 {
-		return fmt.Errorf("RFC2136 had errors in one or more of its batches: %v", errs)
+		err := fmt.Errorf("RFC2136 had errors in one or more of its batches: %v", errs)
+		if !slices.ContainsFunc(errs, func(err error) bool { return !errors.Is(err, errConflict) }) {
+			// The records changed concurrently are read again on the next synchronization
+			return provider.NewSoftError(err)
+		}
+		return err
 	}
 
 	return nil
 }
 
+// requireRRsetAbsent adds the prerequisite that the RRset of the endpoint does not exist
+// to an update (RFC 2136 2.4.3).
+func requireRRsetAbsent(m *dns.Msg, ep *endpoint.Endpoint) {
+	m.RRsetNotUsed([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: dns.Fqdn(ep.DNSName), Rrtype: dns.StringToType[ep.RecordType]}}})
+}
+
+// requireRRset adds the prerequisite that the RRset of the endpoint exists with exactly the
+// targets of the endpoint to an update (RFC 2136 2.4.2).
+func requireRRset(m *dns.Msg, ep *endpoint.Endpoint) error {
+	var rrs []dns.RR
+	for _, target := range ep.Targets {
+		rr, err := dns.NewRR(fmt.Sprintf("%s 0 %s %s", ep.DNSName, ep.RecordType, target))
+		if err != nil {
+			return fmt.Errorf("failed to build RR: %w", err)
+		}
+		rrs = append(rrs, rr)
+	}
+	m.Used(rrs)
+	return nil
+}
+
+// notify sends a NOTIFY message (RFC 1996) for each updated zone to the secondary name servers,
+// so that they transfer the changes without waiting for the refresh interval of the zone.
+// Failures are only logged, as the secondaries still catch up on their next refresh.
+func (r *rfc2136Provider) notify(zones map[string]bool) {
+	if len(r.notifyHosts) == 0 {
+		return
+	}
+	for _, zone := range slices.Sorted(maps.Keys(zones)) {
+		for _, secondary := range r.notifyHosts {
+			m := new(dns.Msg)
+			m.SetNotify(zone)
+			if err := r.actions.SendNotify(m, secondary); err != nil {
+				log.Warnf("Failed to notify %s of the changes of zone %s: %v", secondary, zone, err)
+			}
+		}
+	}
+}
+
 func (r *rfc2136Provider) UpdateRecord(m *dns.Msg, oldEp *endpoint.Endpoint, newEp *endpoint.Endpoint) error {
 	err := r.RemoveRecord(m, oldEp)
 	if err != nil {
@@ -611,6 +877,11 @@ func (r *rfc2136Provider) SendMessage(msg *dns.Msg) error {
 			r.lastErr = lastErr
 			continue
 		}
+		if resp != nil && len(msg.Answer) > 0 && slices.Contains(prerequisiteRcodes, resp.Rcode) {
+			// The other name servers hold the same records, and would reject the update as well
+			log.Infof("RFC2136 update prerequisites failed: %s", dns.RcodeToString[resp.Rcode])
+			return fmt.Errorf("%w: %s", errConflict, dns.RcodeToString[resp.Rcode])
+		}
 		if resp != nil && resp.Rcode != dns.RcodeSuccess {
 			log.Infof("Bad dns.Client.Exchange response: %s", resp)
 			lastErr = fmt.Errorf("bad return code: %s", dns.RcodeToString[resp.Rcode])
@@ -626,6 +897,30 @@ func (r *rfc2136Provider) SendMessage(msg *dns.Msg) error {
 	return lastErr
 }
 
+func (r *rfc2136Provider) SendNotify(msg *dns.Msg, secondary string) error {
+	if r.dryRun {
+		log.Debugf("SendNotify.skipped")
+		return nil
+	}
+	log.Debugf("Sending NOTIFY for zone %s to %s", msg.Question[0].Name, secondary)
+
+	// NOTIFY messages are sent over UDP, signed with the TSIG key if any
+	c := new(dns.Client)
+	if !r.insecure && !r.gssTsig {
+		c.TsigProvider = tsig.HMAC{r.tsigKeyName: r.tsigSecret}
+		msg.SetTsig(r.tsigKeyName, r.tsigSecretAlg, clockSkew, time.Now().Unix())
+	}
+
+	resp, _, err := c.Exchange(msg, secondary)
+	if err != nil {
+		return err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("bad return code: %s", dns.RcodeToString[resp.Rcode])
+	}
+	return nil
+}
+
 func chunkBy(slice []*endpoint.Endpoint, chunkSize int) [][]*endpoint.Endpoint {
 	var chunks [][]*endpoint.Endpoint
 
@@ -19,13 +19,16 @@ package rfc2136
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net"
 	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -41,8 +44,12 @@ import (
 
 type rfc2136Stub struct {
 	output                []*dns.Envelope
+	ixfrOutput            []*dns.Envelope
 	updateMsgs            []*dns.Msg
 	createMsgs            []*dns.Msg
+	sentMsgs              []*dns.Msg
+	notifyMsgs            []string
+	xfrQueries            []string
 	nameservers           []string
 	counter               int
 	randGen               *rand.Rand
@@ -101,6 +108,7 @@ func getSortedChanges(msgs []*dns.Msg) []string {
 }
 
 func (r *rfc2136Stub) SendMessage(msg *dns.Msg) error {
+	r.sentMsgs = append(r.sentMsgs, msg)
 	r.lastNameserver = r.getNextNameserver()
 	log.Info("Sending message to nameserver: ", r.lastNameserver)
 	zone := extractZoneFromMessage(msg.String())
@@ -136,23 +144,52 @@ func (r *rfc2136Stub) SendMessage(msg *dns.Msg) error {
 }
 
 func (r *rfc2136Stub) setOutput(output []string) error {
-	r.output = make([]*dns.Envelope, len(output))
+	var err error
+	r.output, err = toEnvelopes(output)
+	return err
+}
+
+// setIxfrOutput sets the output of the IXFR requests, which otherwise get the output of AXFR requests
+func (r *rfc2136Stub) setIxfrOutput(output []string) error {
+	var err error
+	r.ixfrOutput, err = toEnvelopes(output)
+	return err
+}
+
+func toEnvelopes(output []string) ([]*dns.Envelope, error) {
+	envelopes := make([]*dns.Envelope, len(output))
 	for i, e := range output {
 		rr, err := dns.NewRR(e)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		r.output[i] = &dns.Envelope{
+		envelopes[i] = &dns.Envelope{
 			RR: []dns.RR{rr},
 		}
 	}
+	return envelopes, nil
+}
+
+func (r *rfc2136Stub) SendNotify(msg *dns.Msg, secondary string) error {
+	r.notifyMsgs = append(r.notifyMsgs, fmt.Sprintf("%s %s %s", dns.OpcodeToString[msg.Opcode], msg.Question[0].Name, secondary))
 	return nil
 }
 
 func (r *rfc2136Stub) IncomeTransfer(m *dns.Msg, a string) (env chan *dns.Envelope, err error) {
+	query := dns.TypeToString[m.Question[0].Qtype] + " " + m.Question[0].Name
+	if len(m.Ns) > 0 {
+		query += fmt.Sprintf(" %d", m.Ns[0].(*dns.SOA).Serial)
+	}
+	r.xfrQueries = append(r.xfrQueries, query)
+
+	output := r.output
+	if m.Question[0].Qtype == dns.TypeIXFR && r.ixfrOutput != nil {
+		output = r.ixfrOutput
+	}
+
 	outChan := make(chan *dns.Envelope)
 	go func() {
-		for _, e := range r.output {
+		for _, e := range output {
 
 			var responseEnvelope *dns.Envelope
 			for _, record := range e.RR {
@@ -186,7 +223,7 @@ func createRfc2136StubProvider(stub *rfc2136Stub, zoneNames ...string) (provider
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{""}, 0, zoneNames, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zoneNames, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
 }
 
 func createRfc2136StubProviderWithHosts(stub *rfc2136Stub) (provider.Provider, error) {
@@ -197,15 +234,15 @@ func createRfc2136StubProviderWithHosts(stub *rfc2136Stub) (provider.Provider, e
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
 }
 
 func createRfc2136TLSStubProvider(stub *rfc2136Stub, tlsConfig TLSConfig) (provider.Provider, error) {
-	return NewRfc2136Provider([]string{"rfc2136-host"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
 }
 
 func createRfc2136TLSStubProviderWithHosts(stub *rfc2136Stub, tlsConfig TLSConfig) (provider.Provider, error) {
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
 }
 
 func createRfc2136StubProviderWithReverse(stub *rfc2136Stub) (provider.Provider, error) {
@@ -218,7 +255,7 @@ func createRfc2136StubProviderWithReverse(stub *rfc2136Stub) (provider.Provider,
 	}
 
 	zones := []string{"foo.com", "3.2.1.in-addr.arpa"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, true, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, true, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
 }
 
 func createRfc2136StubProviderWithZones(stub *rfc2136Stub) (provider.Provider, error) {
@@ -230,7 +267,7 @@ func createRfc2136StubProviderWithZones(stub *rfc2136Stub) (provider.Provider, e
 		ClientCertKeyFilePath: "",
 	}
 	zones := []string{"foo.com", "foobar.com"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
 }
 
 func createRfc2136StubProviderWithZonesFilters(stub *rfc2136Stub) (provider.Provider, error) {
@@ -242,7 +279,17 @@ func createRfc2136StubProviderWithZonesFilters(stub *rfc2136Stub) (provider.Prov
 		ClientCertKeyFilePath: "",
 	}
 	zones := []string{"foo.com", "foobar.com"}
-	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", stub)
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, endpoint.NewDomainFilter(zones), false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, "", nil, false, false, stub)
+}
+
+func createRfc2136StubProviderWithTransfers(stub *rfc2136Stub, notifyHosts []string, ixfr bool) (provider.Provider, error) {
+	zones := []string{"foo.com"}
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, TLSConfig{}, "", notifyHosts, ixfr, false, stub)
+}
+
+func createRfc2136StubProviderWithPrerequisites(stub *rfc2136Stub) (provider.Provider, error) {
+	zones := []string{"foo.com", "3.2.1.in-addr.arpa"}
+	return NewRfc2136Provider([]string{""}, 0, zones, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, true, false, "", "", "", 50, TLSConfig{}, "", nil, false, true, stub)
 }
 
 func createRfc2136StubProviderWithStrategy(stub *rfc2136Stub, strategy string) (provider.Provider, error) {
@@ -253,7 +300,7 @@ func createRfc2136StubProviderWithStrategy(stub *rfc2136Stub, strategy string) (
 		ClientCertFilePath:    "",
 		ClientCertKeyFilePath: "",
 	}
-	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, strategy, stub)
+	return NewRfc2136Provider([]string{"rfc2136-host1", "rfc2136-host2", "rfc2136-host3"}, 0, nil, false, "key", "secret", "hmac-sha512", true, &endpoint.DomainFilter{}, false, 300*time.Second, false, false, "", "", "", 50, tlsConfig, strategy, nil, false, false, stub)
 }
 
 func extractUpdateSectionFromMessage(msg fmt.Stringer) []string {
@@ -535,6 +582,242 @@ func TestRfc2136GetRecords(t *testing.T) {
 	assert.True(t, contains(recs, "v2.foo.com"))
 }
 
+func TestRfc2136GetRecordsSkipsDNSSEC(t *testing.T) {
+	stub := newStub()
+	err := stub.setOutput([]string{
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 1 3600 600 86400 300",
+		"foo.com 3600 DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==",
+		"foo.com 3600 NSEC3PARAM 1 0 0 -",
+		"v1.foo.com 3600 A 1.2.3.4",
+		"v1.foo.com 3600 RRSIG A 13 3 3600 20300101000000 20250101000000 12345 foo.com. dGVzdA==",
+		"v1.foo.com 3600 NSEC v2.foo.com. A RRSIG NSEC",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 1 3600 600 86400 300",
+	})
+	assert.NoError(t, err)
+
+	provider, err := createRfc2136StubProviderWithTransfers(stub, nil, false)
+	assert.NoError(t, err)
+
+	rrs, err := provider.(*rfc2136Provider).List()
+	assert.NoError(t, err)
+	var types []string
+	for _, rr := range rrs {
+		types = append(types, dns.TypeToString[rr.Header().Rrtype])
+	}
+	assert.Equal(t, []string{"SOA", "A", "SOA"}, types)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, recs, 1)
+	assert.True(t, contains(recs, "v1.foo.com"))
+}
+
+func TestRfc2136GetRecordsIXFR(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProviderWithTransfers(stub, nil, true)
+	assert.NoError(t, err)
+
+	records := func() []string {
+		recs, err := provider.Records(context.Background())
+		assert.NoError(t, err)
+		var names []string
+		for _, rec := range recs {
+			names = append(names, rec.DNSName+" "+rec.RecordType+" "+rec.Targets.String())
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	// The zone is first transferred entirely
+	assert.NoError(t, stub.setOutput([]string{
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 1 3600 600 86400 300",
+		"v1.foo.com 3600 A 1.2.3.4",
+		"v2.foo.com 3600 A 1.2.3.5",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 1 3600 600 86400 300",
+	}))
+	assert.Equal(t, []string{"v1.foo.com A 1.2.3.4", "v2.foo.com A 1.2.3.5"}, records())
+
+	// Then only its changes, with the serial of the previous transfer
+	assert.NoError(t, stub.setOutput([]string{
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 3 3600 600 86400 300",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 1 3600 600 86400 300",
+		"v2.foo.com 3600 A 1.2.3.5",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 2 3600 600 86400 300",
+		"v3.foo.com 3600 A 1.2.3.6",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 2 3600 600 86400 300",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 3 3600 600 86400 300",
+		"v3.foo.com 3600 RRSIG A 13 3 3600 20300101000000 20250101000000 12345 foo.com. dGVzdA==",
+		"v4.foo.com 3600 TXT \"test\"",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 3 3600 600 86400 300",
+	}))
+	assert.Equal(t, []string{"v1.foo.com A 1.2.3.4", "v3.foo.com A 1.2.3.6", "v4.foo.com TXT test"}, records())
+
+	// Up to date
+	assert.NoError(t, stub.setOutput([]string{
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 3 3600 600 86400 300",
+	}))
+	assert.Equal(t, []string{"v1.foo.com A 1.2.3.4", "v3.foo.com A 1.2.3.6", "v4.foo.com TXT test"}, records())
+
+	// Incomplete changes are discarded for an entire transfer
+	assert.NoError(t, stub.setIxfrOutput([]string{
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 4 3600 600 86400 300",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 3 3600 600 86400 300",
+		"v1.foo.com 3600 A 1.2.3.4",
+	}))
+	assert.NoError(t, stub.setOutput([]string{
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 4 3600 600 86400 300",
+		"v3.foo.com 3600 A 1.2.3.6",
+		"v4.foo.com 3600 TXT \"test\"",
+		"foo.com 3600 SOA ns1.foo.com. hostmaster.foo.com. 4 3600 600 86400 300",
+	}))
+	assert.Equal(t, []string{"v3.foo.com A 1.2.3.6", "v4.foo.com TXT test"}, records())
+
+	assert.Equal(t, []string{
+		"AXFR foo.com.",
+		"IXFR foo.com. 1",
+		"IXFR foo.com. 3",
+		"IXFR foo.com. 3",
+		"AXFR foo.com.",
+	}, stub.xfrQueries)
+	assert.Equal(t, uint32(4), provider.(*rfc2136Provider).snapshots["foo.com."].soa.Serial)
+}
+
+func TestRfc2136ApplyChangesNotify(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProviderWithTransfers(stub, []string{"secondary1", "192.0.2.1:5353", "2001:db8::1"}, false)
+	assert.NoError(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("v1.foo.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("v2.foo.com", endpoint.RecordTypeA, "1.2.3.5"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("v3.foo.com", endpoint.RecordTypeA, "1.2.3.6"),
+		},
+	})
+	assert.NoError(t, err)
+
+	// The secondaries are notified once of all the changes of a zone
+	assert.Equal(t, []string{
+		"NOTIFY foo.com. secondary1:53",
+		"NOTIFY foo.com. 192.0.2.1:5353",
+		"NOTIFY foo.com. [2001:db8::1]:53",
+	}, stub.notifyMsgs)
+
+	// Without changes, there is nothing to notify
+	stub.notifyMsgs = nil
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{})
+	assert.NoError(t, err)
+	assert.Empty(t, stub.notifyMsgs)
+}
+
+func TestRfc2136ApplyChangesWithPrerequisites(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProviderWithPrerequisites(stub)
+	assert.NoError(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("v1.foo.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("v2.foo.com", endpoint.RecordTypeTXT, 300, "old")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("v2.foo.com", endpoint.RecordTypeTXT, 300, "new")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("v3.foo.com", endpoint.RecordTypeCNAME, "v1.foo.com")},
+	})
+	assert.NoError(t, err)
+
+	prerequisites := map[string][]string{}
+	for _, msg := range stub.sentMsgs {
+		zone := msg.Question[0].Name
+		for _, rr := range msg.Answer {
+			prerequisites[zone] = append(prerequisites[zone], strings.Join(strings.Fields(rr.String()), " "))
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"foo.com.": {
+			"v1.foo.com. 0 NONE A",
+			"v2.foo.com. 0 IN TXT \"old\"",
+			"v3.foo.com. 0 IN CNAME v1.foo.com.",
+		},
+	}, prerequisites, "the reverse records are generated without prerequisites")
+	assert.Len(t, stub.sentMsgs, 4)
+}
+
+func TestRfc2136ApplyChangesWithInvalidPrerequisites(t *testing.T) {
+	stub := newStub()
+	provider, err := createRfc2136StubProviderWithPrerequisites(stub)
+	assert.NoError(t, err)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("v1.foo.com", endpoint.RecordTypeA, "invalid")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("v1.foo.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("v2.foo.com", endpoint.RecordTypeA, "invalid"),
+			endpoint.NewEndpoint("v3.foo.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to build RR")
+
+	// the changes whose prerequisites cannot be built are skipped, the others are applied
+	var updated []string
+	for _, msg := range stub.sentMsgs {
+		if msg.Question[0].Name != "foo.com." {
+			continue
+		}
+		for _, rr := range msg.Ns {
+			updated = append(updated, rr.Header().Name)
+		}
+	}
+	assert.Equal(t, []string{"v3.foo.com."}, updated)
+}
+
+func TestRfc2136SendMessageWithPrerequisites(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rcode    int
+		requests int32
+		soft     bool
+	}{
+		{name: "success", rcode: dns.RcodeSuccess, requests: 1},
+		{name: "prerequisite failure", rcode: dns.RcodeNXRrset, requests: 1, soft: true},
+		{name: "server failure", rcode: dns.RcodeServerFailure, requests: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			server := &dns.Server{
+				Listener: listener,
+				// The default accept function rejects updates
+				MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+				Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+					requests.Add(1)
+					resp := new(dns.Msg)
+					resp.SetRcode(req, tc.rcode)
+					w.WriteMsg(resp)
+				}),
+			}
+			go server.ActivateAndServe()
+			t.Cleanup(func() { server.Shutdown() })
+
+			port := listener.Addr().(*net.TCPAddr).Port
+			p, err := NewRfc2136Provider([]string{"127.0.0.1", "127.0.0.1"}, port, []string{"foo.com"}, true, "", "", "", true, &endpoint.DomainFilter{}, false, 0, false, false, "", "", "", 50, TLSConfig{}, "disabled", nil, false, true, nil)
+			require.NoError(t, err)
+
+			err = p.ApplyChanges(context.Background(), &plan.Changes{
+				Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("v1.foo.com", endpoint.RecordTypeA, "1.2.3.4")},
+			})
+			if tc.rcode == dns.RcodeSuccess {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tc.soft, errors.Is(err, provider.SoftError))
+			assert.Equal(t, tc.requests, requests.Load(), "the other name servers are not tried on prerequisite failures")
+		})
+	}
+}
+
 // Make sure the test version of SendMessage raises an error
 // if a zone update ever contains records outside of it's zone
 // as the TestRfc2136ApplyChanges tests all assume this
@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/linki/instrumented_http"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
+)
+
+const (
+	apiListZones    = "/api/zones/list"
+	apiGetRecords   = "/api/zones/records/get"
+	apiAddRecord    = "/api/zones/records/add"
+	apiDeleteRecord = "/api/zones/records/delete"
+
+	zoneTypePrimary = "Primary"
+)
+
+// technitiumZone is a zone of the zones/list API.
+type technitiumZone struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Disabled bool   `json:"disabled"`
+}
+
+// technitiumRecord is a record of the zones/records/get API.
+type technitiumRecord struct {
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	TTL      int64           `json:"ttl"`
+	Disabled bool            `json:"disabled"`
+	RData    technitiumRData `json:"rData"`
+}
+
+// technitiumRData is the data of a record, whose fields depend on its type.
+type technitiumRData struct {
+	IPAddress  string `json:"ipAddress,omitempty"`
+	CNAME      string `json:"cname,omitempty"`
+	Text       string `json:"text,omitempty"`
+	Priority   uint16 `json:"priority,omitempty"`
+	Weight     uint16 `json:"weight,omitempty"`
+	Port       uint16 `json:"port,omitempty"`
+	Target     string `json:"target,omitempty"`
+	Preference uint16 `json:"preference,omitempty"`
+	Exchange   string `json:"exchange,omitempty"`
+}
+
+// target returns the endpoint target of the record data.
+func (d technitiumRData) target(recordType string) string {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		return d.IPAddress
+	case endpoint.RecordTypeCNAME:
+		return d.CNAME
+	case endpoint.RecordTypeTXT:
+		return d.Text
+	case endpoint.RecordTypeSRV:
+		return fmt.Sprintf("%d %d %d %s", d.Priority, d.Weight, d.Port, d.Target)
+	case endpoint.RecordTypeMX:
+		return fmt.Sprintf("%d %s", d.Preference, d.Exchange)
+	}
+	return ""
+}
+
+// rDataParams returns the parameters of the records API identifying the data of an endpoint target.
+func rDataParams(recordType, target string) (url.Values, error) {
+	params := url.Values{}
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		params.Set("ipAddress", target)
+	case endpoint.RecordTypeCNAME:
+		params.Set("cname", target)
+	case endpoint.RecordTypeTXT:
+		params.Set("text", target)
+	case endpoint.RecordTypeSRV:
+		fields := strings.Fields(target)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid SRV target %q", target)
+		}
+		params.Set("priority", fields[0])
+		params.Set("weight", fields[1])
+		params.Set("port", fields[2])
+		params.Set("target", strings.TrimSuffix(fields[3], "."))
+	case endpoint.RecordTypeMX:
+		fields := strings.Fields(target)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid MX target %q", target)
+		}
+		params.Set("preference", fields[0])
+		params.Set("exchange", strings.TrimSuffix(fields[1], "."))
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+	return params, nil
+}
+
+// technitiumClient is a client of the HTTP API of Technitium DNS Server.
+type technitiumClient struct {
+	server     string
+	token      string
+	httpClient *http.Client
+}
+
+// newTechnitiumClient creates a new Technitium API client.
+func newTechnitiumClient(cfg TechnitiumConfig) (*technitiumClient, error) {
+	if cfg.Server == "" {
+		return nil, errors.New("no Technitium server specified")
+	}
+	if cfg.Token == "" {
+		return nil, errors.New("no Technitium API token specified")
+	}
+	tlsConfig, err := tlsutils.NewTLSConfig(
+		cfg.TLSConfig.ClientCertFilePath,
+		cfg.TLSConfig.ClientCertKeyFilePath,
+		cfg.TLSConfig.CAFilePath,
+		"",
+		cfg.TLSConfig.SkipTLSVerify,
+		tls.VersionTLS12,
+	)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &technitiumClient{
+		server:     strings.TrimSuffix(cfg.Server, "/"),
+		token:      cfg.Token,
+		httpClient: instrumented_http.NewClient(&http.Client{Transport: transport}, &instrumented_http.Callbacks{}),
+	}, nil
+}
+
+// do calls an API with the form parameters and decodes its response into out.
+func (c *technitiumClient) do(ctx context.Context, path string, params url.Values, out any) error {
+	params.Set("token", c.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.server+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("technitium API %s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Status       string          `json:"status"`
+		ErrorMessage string          `json:"errorMessage"`
+		Response     json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode technitium API %s response: %w", path, err)
+	}
+	switch result.Status {
+	case "ok":
+	case "invalid-token":
+		return fmt.Errorf("technitium API %s: invalid token", path)
+	default:
+		return fmt.Errorf("technitium API %s: %s", path, result.ErrorMessage)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(result.Response, out)
+}
+
+// listZones returns the zones of the server.
+func (c *technitiumClient) listZones(ctx context.Context) ([]technitiumZone, error) {
+	var response struct {
+		Zones []technitiumZone `json:"zones"`
+	}
+	if err := c.do(ctx, apiListZones, url.Values{}, &response); err != nil {
+		return nil, err
+	}
+	return response.Zones, nil
+}
+
+// listRecords returns all the records of a zone.
+func (c *technitiumClient) listRecords(ctx context.Context, zone string) ([]technitiumRecord, error) {
+	var response struct {
+		Records []technitiumRecord `json:"records"`
+	}
+	params := url.Values{"domain": {zone}, "zone": {zone}, "listZone": {"true"}}
+	if err := c.do(ctx, apiGetRecords, params, &response); err != nil {
+		return nil, err
+	}
+	return response.Records, nil
+}
+
+// addRecord adds a record to a zone, replacing the records of the same name and type if overwrite is set.
+func (c *technitiumClient) addRecord(ctx context.Context, zone, name, recordType string, ttl int64, target string, overwrite bool) error {
+	params, err := rDataParams(recordType, target)
+	if err != nil {
+		return err
+	}
+	params.Set("domain", name)
+	params.Set("zone", zone)
+	params.Set("type", recordType)
+	params.Set("ttl", strconv.FormatInt(ttl, 10))
+	params.Set("overwrite", strconv.FormatBool(overwrite))
+	log.Debugf("Adding record %s %s %s to technitium zone %s", name, recordType, target, zone)
+	return c.do(ctx, apiAddRecord, params, nil)
+}
+
+// deleteRecord deletes a record from a zone.
+func (c *technitiumClient) deleteRecord(ctx context.Context, zone, name, recordType, target string) error {
+	params, err := rDataParams(recordType, target)
+	if err != nil {
+		return err
+	}
+	params.Set("domain", name)
+	params.Set("zone", zone)
+	params.Set("type", recordType)
+	log.Debugf("Deleting record %s %s %s from technitium zone %s", name, recordType, target, zone)
+	return c.do(ctx, apiDeleteRecord, params, nil)
+}
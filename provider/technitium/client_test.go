@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret-token"
+
+// fakeServer is an in-memory stand-in for the zones and records API of Technitium DNS Server.
+type fakeServer struct {
+	sync.Mutex
+	zones    []technitiumZone
+	records  map[string][]technitiumRecord
+	requests []string
+}
+
+func newFakeServer(zones []technitiumZone, records map[string][]technitiumRecord) *fakeServer {
+	if records == nil {
+		records = map[string][]technitiumRecord{}
+	}
+	return &fakeServer{zones: zones, records: records}
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("token") != testToken {
+		writeResponse(w, map[string]any{"status": "invalid-token", "errorMessage": "Invalid token or session expired."})
+		return
+	}
+	s.requests = append(s.requests, r.URL.Path+" "+r.Form.Get("domain")+" "+r.Form.Get("type"))
+
+	zone := r.Form.Get("zone")
+	switch r.URL.Path {
+	case apiListZones:
+		writeOK(w, map[string]any{"zones": s.zones})
+	case apiGetRecords:
+		if _, ok := s.records[zone]; !ok {
+			writeResponse(w, map[string]any{"status": "error", "errorMessage": "No such zone was found: " + zone})
+			return
+		}
+		writeOK(w, map[string]any{"records": s.records[zone]})
+	case apiAddRecord:
+		record := formRecord(r)
+		ttl, _ := strconv.ParseInt(r.Form.Get("ttl"), 10, 64)
+		record.TTL = ttl
+		var records []technitiumRecord
+		for _, existing := range s.records[zone] {
+			if existing.Name == record.Name && existing.Type == record.Type {
+				if r.Form.Get("overwrite") == "true" {
+					continue
+				}
+				if existing.RData == record.RData {
+					writeResponse(w, map[string]any{"status": "error", "errorMessage": "Cannot add record: record already exists."})
+					return
+				}
+				existing.TTL = ttl
+			}
+			records = append(records, existing)
+		}
+		s.records[zone] = append(records, record)
+		writeOK(w, map[string]any{})
+	case apiDeleteRecord:
+		record := formRecord(r)
+		var records []technitiumRecord
+		for _, existing := range s.records[zone] {
+			if existing.Name != record.Name || existing.Type != record.Type || existing.RData != record.RData {
+				records = append(records, existing)
+			}
+		}
+		s.records[zone] = records
+		writeOK(w, nil)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func formRecord(r *http.Request) technitiumRecord {
+	uint16Value := func(name string) uint16 {
+		v, _ := strconv.ParseUint(r.Form.Get(name), 10, 16)
+		return uint16(v)
+	}
+	return technitiumRecord{
+		Name: r.Form.Get("domain"),
+		Type: r.Form.Get("type"),
+		RData: technitiumRData{
+			IPAddress:  r.Form.Get("ipAddress"),
+			CNAME:      r.Form.Get("cname"),
+			Text:       r.Form.Get("text"),
+			Priority:   uint16Value("priority"),
+			Weight:     uint16Value("weight"),
+			Port:       uint16Value("port"),
+			Target:     r.Form.Get("target"),
+			Preference: uint16Value("preference"),
+			Exchange:   r.Form.Get("exchange"),
+		},
+	}
+}
+
+func writeOK(w http.ResponseWriter, response any) {
+	writeResponse(w, map[string]any{"status": "ok", "response": response})
+}
+
+func writeResponse(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestNewTechnitiumClient(t *testing.T) {
+	_, err := newTechnitiumClient(TechnitiumConfig{Token: testToken})
+	assert.ErrorContains(t, err, "no Technitium server specified")
+
+	_, err = newTechnitiumClient(TechnitiumConfig{Server: "https://dns.example.com"})
+	assert.ErrorContains(t, err, "no Technitium API token specified")
+
+	_, err = newTechnitiumClient(TechnitiumConfig{
+		Server:    "https://dns.example.com",
+		Token:     testToken,
+		TLSConfig: TLSConfig{CAFilePath: filepath.Join(t.TempDir(), "missing.crt")},
+	})
+	assert.Error(t, err)
+
+	client, err := newTechnitiumClient(TechnitiumConfig{Server: "https://dns.example.com/", Token: testToken})
+	require.NoError(t, err)
+	assert.Equal(t, "https://dns.example.com", client.server)
+}
+
+func TestTechnitiumClientToken(t *testing.T) {
+	srv := httptest.NewServer(newFakeServer(nil, nil))
+	defer srv.Close()
+
+	client, err := newTechnitiumClient(TechnitiumConfig{Server: srv.URL, Token: "wrong"})
+	require.NoError(t, err)
+	_, err = client.listZones(context.Background())
+	assert.EqualError(t, err, "technitium API /api/zones/list: invalid token")
+}
+
+func TestTechnitiumClientErrors(t *testing.T) {
+	srv := httptest.NewServer(newFakeServer(nil, nil))
+	defer srv.Close()
+
+	client, err := newTechnitiumClient(TechnitiumConfig{Server: srv.URL, Token: testToken})
+	require.NoError(t, err)
+
+	_, err = client.listRecords(context.Background(), "example.com")
+	assert.EqualError(t, err, "technitium API /api/zones/records/get: No such zone was found: example.com")
+
+	err = client.addRecord(context.Background(), "example.com", "www.example.com", "SRV", 300, "10 5", false)
+	assert.EqualError(t, err, `invalid SRV target "10 5"`)
+
+	err = client.deleteRecord(context.Background(), "example.com", "www.example.com", "NS", "ns1.example.com")
+	assert.EqualError(t, err, "unsupported record type NS")
+
+	client.server = srv.URL + "/missing"
+	_, err = client.listZones(context.Background())
+	assert.ErrorContains(t, err, "returned 404 Not Found")
+}
+
+func TestTechnitiumClientTLS(t *testing.T) {
+	srv := httptest.NewTLSServer(newFakeServer([]technitiumZone{{Name: "example.com", Type: zoneTypePrimary}}, nil))
+	defer srv.Close()
+
+	client, err := newTechnitiumClient(TechnitiumConfig{Server: srv.URL, Token: testToken})
+	require.NoError(t, err)
+	_, err = client.listZones(context.Background())
+	assert.ErrorContains(t, err, "certificate")
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+	for _, tlsConfig := range []TLSConfig{{SkipTLSVerify: true}, {CAFilePath: caFile}} {
+		client, err := newTechnitiumClient(TechnitiumConfig{Server: srv.URL, Token: testToken, TLSConfig: tlsConfig})
+		require.NoError(t, err)
+		zones, err := client.listZones(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []technitiumZone{{Name: "example.com", Type: zoneTypePrimary}}, zones)
+	}
+}
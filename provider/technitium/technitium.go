@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL Technitium DNS Server gives to the records added without one.
+const defaultTTL = 3600
+
+// supportedRecordTypes are the record types managed by the provider.
+var supportedRecordTypes = map[string]bool{
+	endpoint.RecordTypeA:     true,
+	endpoint.RecordTypeAAAA:  true,
+	endpoint.RecordTypeCNAME: true,
+	endpoint.RecordTypeTXT:   true,
+	endpoint.RecordTypeSRV:   true,
+	endpoint.RecordTypeMX:    true,
+}
+
+// TLSConfig is comprised of the TLS-related fields necessary to create a new TechnitiumProvider.
+type TLSConfig struct {
+	SkipTLSVerify         bool
+	CAFilePath            string
+	ClientCertFilePath    string
+	ClientCertKeyFilePath string
+}
+
+// TechnitiumConfig is used for configuring a TechnitiumProvider.
+type TechnitiumConfig struct {
+	// The root URL of the Technitium DNS Server web service.
+	Server string
+	// The API token used to authenticate the requests.
+	Token string
+	// The TLS configuration of the connection to the server.
+	TLSConfig TLSConfig
+	// A filter to apply when looking up and applying records.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed to stdout.
+	DryRun bool
+}
+
+// TechnitiumProvider is an implementation of Provider for Technitium DNS Server.
+type TechnitiumProvider struct {
+	provider.BaseProvider
+	client       *technitiumClient
+	domainFilter *endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewTechnitiumProvider initializes a new Technitium DNS Server based Provider.
+func NewTechnitiumProvider(cfg TechnitiumConfig) (*TechnitiumProvider, error) {
+	client, err := newTechnitiumClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	domainFilter := cfg.DomainFilter
+	if domainFilter == nil {
+		domainFilter = &endpoint.DomainFilter{}
+	}
+	return &TechnitiumProvider{
+		client:       client,
+		domainFilter: domainFilter,
+		dryRun:       cfg.DryRun,
+	}, nil
+}
+
+// zones returns the enabled primary zones of the server matching the domain filter.
+func (p *TechnitiumProvider) zones(ctx context.Context) (provider.ZoneIDName, error) {
+	zones, err := p.client.listZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zoneIDName := provider.ZoneIDName{}
+	for _, zone := range zones {
+		if zone.Type != zoneTypePrimary || zone.Disabled {
+			log.Debugf("Skipping technitium zone %s of type %s", zone.Name, zone.Type)
+			continue
+		}
+		if !p.domainFilter.Match(zone.Name) {
+			continue
+		}
+		zoneIDName.Add(zone.Name, zone.Name)
+	}
+	return zoneIDName, nil
+}
+
+// Records implements Provider, returning the records of the primary zones of the server.
+func (p *TechnitiumProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for zone := range zones {
+		records, err := p.client.listRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone, err)
+		}
+		byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+		for _, r := range records {
+			if !supportedRecordTypes[r.Type] || r.Disabled || !p.domainFilter.Match(r.Name) {
+				continue
+			}
+			key := endpoint.EndpointKey{DNSName: r.Name, RecordType: r.Type}
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, r.RData.target(r.Type))
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.TTL), r.RData.target(r.Type))
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints drops the records of unsupported types and gives the default TTL of
+// the server to the records without one, so that they compare equal to the current ones.
+func (p *TechnitiumProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !supportedRecordTypes[ep.RecordType] {
+			log.Debugf("Skipping record %s of unsupported type %s", ep.DNSName, ep.RecordType)
+			continue
+		}
+		if !ep.RecordTTL.IsConfigured() {
+			ep.RecordTTL = defaultTTL
+		}
+		adjusted = append(adjusted, ep)
+	}
+	return adjusted, nil
+}
+
+// ApplyChanges implements Provider, syncing desired state with the zones of the server.
+func (p *TechnitiumProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if !changes.HasChanges() {
+		return nil
+	}
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ep := range changes.Delete {
+		zone, _ := zones.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no zone was found", ep.DNSName)
+			continue
+		}
+		for _, target := range ep.Targets {
+			log.Infof("Deleting record %s %s %s from zone %s", ep.DNSName, ep.RecordType, target, zone)
+			if p.dryRun {
+				continue
+			}
+			if err := p.client.deleteRecord(ctx, zone, ep.DNSName, ep.RecordType, target); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The record sets of the updates are replaced as a whole by adding their first record
+	// with overwrite, so the old records need not be deleted.
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
+		for _, ep := range endpoints {
+			if err := p.setRecords(ctx, zones, ep); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setRecords replaces the record set of an endpoint with its targets.
+func (p *TechnitiumProvider) setRecords(ctx context.Context, zones provider.ZoneIDName, ep *endpoint.Endpoint) error {
+	zone, _ := zones.FindZone(ep.DNSName)
+	if zone == "" {
+		log.Debugf("Skipping record %s because no zone was found", ep.DNSName)
+		return nil
+	}
+	ttl := int64(defaultTTL)
+	if ep.RecordTTL.IsConfigured() {
+		ttl = int64(ep.RecordTTL)
+	}
+	log.Infof("Setting record %s %s %s in zone %s", ep.DNSName, ep.RecordType, strings.Join(ep.Targets, ","), zone)
+	if p.dryRun {
+		return nil
+	}
+	for i, target := range ep.Targets {
+		if err := p.client.addRecord(ctx, zone, ep.DNSName, ep.RecordType, ttl, target, i == 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
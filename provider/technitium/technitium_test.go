@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package technitium
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func newTestProvider(t *testing.T, fake *fakeServer, cfg TechnitiumConfig) *TechnitiumProvider {
+	t.Helper()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	cfg.Server = srv.URL
+	cfg.Token = testToken
+	p, err := NewTechnitiumProvider(cfg)
+	require.NoError(t, err)
+	return p
+}
+
+func testZones() []technitiumZone {
+	return []technitiumZone{
+		{Name: "example.com", Type: zoneTypePrimary},
+		{Name: "sub.example.com", Type: zoneTypePrimary},
+		{Name: "example.org", Type: zoneTypePrimary},
+		{Name: "secondary.com", Type: "Secondary"},
+		{Name: "disabled.com", Type: zoneTypePrimary, Disabled: true},
+	}
+}
+
+func TestTechnitiumProviderRecords(t *testing.T) {
+	fake := newFakeServer(testZones(), map[string][]technitiumRecord{
+		"example.com": {
+			{Name: "example.com", Type: "SOA", TTL: 900},
+			{Name: "example.com", Type: "NS", TTL: 3600, RData: technitiumRData{}},
+			{Name: "example.com", Type: endpoint.RecordTypeMX, TTL: 3600, RData: technitiumRData{Preference: 10, Exchange: "mail.example.com"}},
+			{Name: "www.example.com", Type: endpoint.RecordTypeA, TTL: 300, RData: technitiumRData{IPAddress: "192.0.2.1"}},
+			{Name: "www.example.com", Type: endpoint.RecordTypeA, TTL: 300, RData: technitiumRData{IPAddress: "192.0.2.2"}},
+			{Name: "www.example.com", Type: endpoint.RecordTypeAAAA, TTL: 300, RData: technitiumRData{IPAddress: "2001:db8::1"}},
+			{Name: "off.example.com", Type: endpoint.RecordTypeA, TTL: 300, RData: technitiumRData{IPAddress: "192.0.2.3"}, Disabled: true},
+			{Name: "alias.example.com", Type: endpoint.RecordTypeCNAME, TTL: 600, RData: technitiumRData{CNAME: "www.example.com"}},
+			{Name: "www.example.com", Type: endpoint.RecordTypeTXT, TTL: 300, RData: technitiumRData{Text: "heritage=external-dns"}},
+			{Name: "_sip._udp.example.com", Type: endpoint.RecordTypeSRV, TTL: 300, RData: technitiumRData{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}},
+		},
+		"sub.example.com": {
+			{Name: "api.sub.example.com", Type: endpoint.RecordTypeA, TTL: 60, RData: technitiumRData{IPAddress: "192.0.2.10"}},
+		},
+		"example.org": {
+			{Name: "www.example.org", Type: endpoint.RecordTypeA, TTL: 60, RData: technitiumRData{IPAddress: "192.0.2.20"}},
+		},
+	})
+	p := newTestProvider(t, fake, TechnitiumConfig{DomainFilter: endpoint.NewDomainFilter([]string{"example.com"})})
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 600, "www.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("_sip._udp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("api.sub.example.com", endpoint.RecordTypeA, 60, "192.0.2.10"),
+	}, records)
+}
+
+func TestTechnitiumProviderApplyChanges(t *testing.T) {
+	fake := newFakeServer(testZones(), map[string][]technitiumRecord{
+		"example.com": {
+			{Name: "www.example.com", Type: endpoint.RecordTypeA, TTL: 300, RData: technitiumRData{IPAddress: "192.0.2.1"}},
+			{Name: "www.example.com", Type: endpoint.RecordTypeA, TTL: 300, RData: technitiumRData{IPAddress: "192.0.2.2"}},
+			{Name: "old.example.com", Type: endpoint.RecordTypeCNAME, TTL: 300, RData: technitiumRData{CNAME: "www.example.com"}},
+		},
+		"sub.example.com": {},
+		"example.org":     {},
+	})
+	p := newTestProvider(t, fake, TechnitiumConfig{})
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.sub.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 600, "10 mail.example.com.", "20 backup.example.com."),
+			endpoint.NewEndpointWithTTL("_sip._udp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+			endpoint.NewEndpoint("www.unknown.net", endpoint.RecordTypeA, "192.0.2.99"),
+		},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "192.0.2.2", "192.0.2.3")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("old.example.com", endpoint.RecordTypeCNAME, 300, "www.example.com")},
+	}))
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "192.0.2.2", "192.0.2.3"),
+		endpoint.NewEndpointWithTTL("api.sub.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 600, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._udp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+	}, records)
+}
+
+func TestTechnitiumProviderDryRun(t *testing.T) {
+	records := map[string][]technitiumRecord{
+		"example.com": {
+			{Name: "www.example.com", Type: endpoint.RecordTypeA, TTL: 300, RData: technitiumRData{IPAddress: "192.0.2.1"}},
+		},
+	}
+	fake := newFakeServer(testZones(), records)
+	p := newTestProvider(t, fake, TechnitiumConfig{DryRun: true})
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.10")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1")},
+	}))
+	assert.Equal(t, []string{apiListZones + "  "}, fake.requests)
+	assert.Len(t, fake.records["example.com"], 1)
+}
+
+func TestTechnitiumProviderAdjustEndpoints(t *testing.T) {
+	p := newTestProvider(t, newFakeServer(nil, nil), TechnitiumConfig{})
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.1"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "text"),
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, defaultTTL, "192.0.2.1"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "text"),
+	}, adjusted)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	throttledCallsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "calls_total",
+			Help:      "Number of provider calls partitioned by provider, method and status (vector).",
+		},
+		[]string{"provider", "method", "status"},
+	)
+	throttledRetriesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "retries_total",
+			Help:      "Number of retried provider calls partitioned by provider and method (vector).",
+		},
+		[]string{"provider", "method"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(throttledCallsTotal)
+	metrics.RegisterMetric.MustRegister(throttledRetriesTotal)
+}
+
+// ThrottleConfig configures the rate limit and retries of a ThrottledProvider.
+type ThrottleConfig struct {
+	// RequestsPerSecond is the rate of provider calls; 0 disables rate limiting, e.g. when the
+	// API requests of the provider are already rate limited by a transport from NewRateLimitedTransport.
+	RequestsPerSecond float64
+	// Burst is the number of calls allowed to exceed the rate at once.
+	Burst int
+	// MaxRetries is the number of times a call failing with a SoftError is retried; 0 disables retries.
+	MaxRetries uint
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// ThrottledProvider rate limits the calls to a provider and retries calls failing with a SoftError
+// using an exponential backoff with jitter. Any other error is returned without retrying.
+//
+// The rate limit applies to the Provider methods called by every reconciliation, not to the API
+// requests a provider makes to implement them: a single Records call may page through many of them.
+// Providers limit their API requests by using a transport from NewRateLimitedTransport instead.
+//
+// Only the idempotent calls listing the records or the zones are retried: a failed ApplyChanges may
+// have been partially applied, so it is left to the next reconciliation, which plans the remaining
+// changes against the current records.
+type ThrottledProvider struct {
+	Provider
+	name    string
+	config  ThrottleConfig
+	limiter *rate.Limiter
+}
+
+// throttledZoneProvider is a ThrottledProvider which also rate limits
+// the calls to the ZoneChangeApplier implemented by the wrapped provider.
+type throttledZoneProvider struct {
+	*ThrottledProvider
+	applier ZoneChangeApplier
+}
+
+// NewThrottledProvider wraps provider, recording metrics under the given provider name.
+// If provider implements ZoneChangeApplier, so does the returned provider, so that it can
+// be wrapped by a ZonePartitionedProvider with every per-zone call rate limited.
+func NewThrottledProvider(provider Provider, name string, config ThrottleConfig) Provider {
+	throttled := &ThrottledProvider{
+		Provider: provider,
+		name:     name,
+		config:   config,
+		limiter:  NewRateLimiter(config.RequestsPerSecond, config.Burst),
+	}
+	if applier, ok := provider.(ZoneChangeApplier); ok {
+		return &throttledZoneProvider{ThrottledProvider: throttled, applier: applier}
+	}
+	return throttled
+}
+
+// NewRateLimiter returns a token bucket allowing requestsPerSecond with bursts of burst; 0 disables rate limiting.
+func NewRateLimiter(requestsPerSecond float64, burst int) *rate.Limiter {
+	if requestsPerSecond > 0 {
+		return rate.NewLimiter(rate.Limit(requestsPerSecond), max(burst, 1))
+	}
+	return rate.NewLimiter(rate.Inf, 0)
+}
+
+// rateLimitedTransport waits for its limiter before sending every request.
+type rateLimitedTransport struct {
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+// NewRateLimitedTransport wraps the transport of a provider HTTP client, or http.DefaultTransport if next is nil,
+// so that every API request waits for the limiter. It returns next unchanged if limiter is nil.
+func NewRateLimitedTransport(limiter *rate.Limiter, next http.RoundTripper) http.RoundTripper {
+	if limiter == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitedTransport{limiter: limiter, next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// Records returns the records of the wrapped provider, retrying on soft errors.
+func (t *ThrottledProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return retryCall(ctx, t, "records", func() ([]*endpoint.Endpoint, error) {
+		return t.Provider.Records(ctx)
+	})
+}
+
+// ApplyChanges applies the changes using the wrapped provider once the rate limit allows it.
+func (t *ThrottledProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if err := t.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	err := t.Provider.ApplyChanges(ctx, changes)
+	t.observe("apply_changes", err)
+	return err
+}
+
+// ZoneIDNames implements ZoneChangeApplier, returning the zones of the wrapped provider and retrying on soft errors.
+func (t *throttledZoneProvider) ZoneIDNames(ctx context.Context) (ZoneIDName, error) {
+	return retryCall(ctx, t.ThrottledProvider, "zone_id_names", func() (ZoneIDName, error) {
+		return t.applier.ZoneIDNames(ctx)
+	})
+}
+
+// SplitZoneChanges implements ZoneChangeApplier, splitting the changes as the wrapped provider does.
+func (t *throttledZoneProvider) SplitZoneChanges(zones ZoneIDName, changes *plan.Changes) map[string]*plan.Changes {
+	return t.applier.SplitZoneChanges(zones, changes)
+}
+
+// ApplyZoneChanges implements ZoneChangeApplier, applying the changes of a zone once the rate limit allows it.
+// Like ApplyChanges, it is never retried.
+func (t *throttledZoneProvider) ApplyZoneChanges(ctx context.Context, zoneID string, changes *plan.Changes) error {
+	if err := t.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	err := t.applier.ApplyZoneChanges(ctx, zoneID, changes)
+	t.observe("apply_zone_changes", err)
+	return err
+}
+
+// retryCall calls an idempotent method of the wrapped provider once the rate limit allows it,
+// retrying it on soft errors. Any other error is returned as is.
+func retryCall[T any](ctx context.Context, t *ThrottledProvider, method string, call func() (T, error)) (T, error) {
+	result, err := backoff.Retry(ctx, func() (T, error) {
+		var zero T
+		if err := t.limiter.Wait(ctx); err != nil {
+			return zero, backoff.Permanent(err)
+		}
+		result, err := call()
+		t.observe(method, err)
+		if err != nil && !errors.Is(err, SoftError) {
+			return zero, backoff.Permanent(err)
+		}
+		return result, err
+	}, t.retryOptions(method)...)
+	var permanent *backoff.PermanentError
+	if errors.As(err, &permanent) {
+		return result, permanent.Err
+	}
+	return result, err
+}
+
+func (t *ThrottledProvider) retryOptions(method string) []backoff.RetryOption {
+	return []backoff.RetryOption{
+		backoff.WithBackOff(&backoff.ExponentialBackOff{
+			InitialInterval:     t.config.InitialBackoff,
+			RandomizationFactor: backoff.DefaultRandomizationFactor,
+			Multiplier:          2,
+			MaxInterval:         t.config.MaxBackoff,
+		}),
+		backoff.WithMaxTries(t.config.MaxRetries + 1),
+		backoff.WithMaxElapsedTime(0),
+		backoff.WithNotify(func(err error, next time.Duration) {
+			log.Warnf("Provider %s %s failed, retrying in %s: %v", t.name, method, next.Round(time.Millisecond), err)
+			throttledRetriesTotal.CounterVec.WithLabelValues(t.name, method).Inc()
+		}),
+	}
+}
+
+func (t *ThrottledProvider) observe(method string, err error) {
+	status := "success"
+	switch {
+	case errors.Is(err, SoftError):
+		status = "soft_error"
+	case err != nil:
+		status = "error"
+	}
+	throttledCallsTotal.CounterVec.WithLabelValues(t.name, method, status).Inc()
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func testThrottleConfig(maxRetries uint) ThrottleConfig {
+	return ThrottleConfig{
+		MaxRetries:     maxRetries,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+func TestThrottledProviderRecords(t *testing.T) {
+	records := []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")}
+	permanent := errors.New("invalid credentials")
+
+	for _, tc := range []struct {
+		name      string
+		errs      []error
+		retries   uint
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			wantCalls: 1,
+		},
+		{
+			name:      "soft errors are retried",
+			errs:      []error{NewSoftErrorf("throttled"), NewSoftErrorf("throttled")},
+			retries:   3,
+			wantCalls: 3,
+		},
+		{
+			name:      "retries are exhausted",
+			errs:      []error{NewSoftErrorf("throttled"), NewSoftErrorf("throttled"), NewSoftErrorf("throttled")},
+			retries:   2,
+			wantCalls: 3,
+			wantErr:   SoftError,
+		},
+		{
+			name:      "other errors are not retried",
+			errs:      []error{permanent},
+			retries:   3,
+			wantCalls: 1,
+			wantErr:   permanent,
+		},
+		{
+			name:      "retries disabled",
+			errs:      []error{NewSoftErrorf("throttled")},
+			wantCalls: 1,
+			wantErr:   SoftError,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			p := NewThrottledProvider(&testProviderFunc{
+				records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+					calls++
+					if calls <= len(tc.errs) {
+						return nil, tc.errs[calls-1]
+					}
+					return records, nil
+				},
+			}, "test", testThrottleConfig(tc.retries))
+
+			got, err := p.Records(context.Background())
+			assert.Equal(t, tc.wantCalls, calls)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, records, got)
+		})
+	}
+}
+
+func TestThrottledProviderRecordsPermanentErrorIsUnwrapped(t *testing.T) {
+	permanent := errors.New("invalid credentials")
+	p := NewThrottledProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			return nil, permanent
+		},
+	}, "test", testThrottleConfig(1))
+
+	_, err := p.Records(context.Background())
+	assert.Equal(t, permanent, err)
+}
+
+func TestThrottledProviderApplyChangesIsNotRetried(t *testing.T) {
+	calls := 0
+	p := NewThrottledProvider(&testProviderFunc{
+		applyChanges: func(_ context.Context, _ *plan.Changes) error {
+			calls++
+			return NewSoftErrorf("partially applied")
+		},
+	}, "test", testThrottleConfig(3))
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{})
+	require.ErrorIs(t, err, SoftError)
+	assert.Equal(t, 1, calls)
+}
+
+func TestThrottledProviderRateLimit(t *testing.T) {
+	p := NewThrottledProvider(&testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) {
+			return nil, nil
+		},
+	}, "test", ThrottleConfig{RequestsPerSecond: 20, Burst: 1})
+
+	start := time.Now()
+	for range 3 {
+		_, err := p.Records(context.Background())
+		require.NoError(t, err)
+	}
+	// the first call uses the burst, the two others wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestThrottledProviderRateLimitHonorsContext(t *testing.T) {
+	p := NewThrottledProvider(&testProviderFunc{
+		applyChanges: func(_ context.Context, _ *plan.Changes) error {
+			return nil
+		},
+	}, "test", ThrottleConfig{RequestsPerSecond: 0.001, Burst: 1})
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, p.ApplyChanges(ctx, &plan.Changes{}))
+}
+
+func TestThrottledProviderZoneChangeApplier(t *testing.T) {
+	_, ok := NewThrottledProvider(&testProviderFunc{}, "test", ThrottleConfig{}).(ZoneChangeApplier)
+	assert.False(t, ok)
+
+	applier := newZoneApplierProvider(nil)
+	throttled := NewThrottledProvider(applier, "test", ThrottleConfig{RequestsPerSecond: 20, Burst: 1})
+	require.Implements(t, (*ZoneChangeApplier)(nil), throttled)
+
+	p, err := NewZonePartitionedProvider(throttled, 3)
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("b.sub.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}))
+	assert.Len(t, applier.applied, 3)
+	// listing the zones uses the burst, and every zone then waits 50ms
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+}
+
+// flakyZonesProvider fails to list its zones with the given errors before succeeding.
+type flakyZonesProvider struct {
+	*zoneApplierProvider
+	errs  []error
+	calls int
+}
+
+func (p *flakyZonesProvider) ZoneIDNames(ctx context.Context) (ZoneIDName, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return nil, p.errs[p.calls-1]
+	}
+	return p.zoneApplierProvider.ZoneIDNames(ctx)
+}
+
+func TestThrottledProviderZoneIDNamesIsRetried(t *testing.T) {
+	applier := &flakyZonesProvider{
+		zoneApplierProvider: newZoneApplierProvider(nil),
+		errs:                []error{NewSoftErrorf("throttled")},
+	}
+	throttled := NewThrottledProvider(applier, "test", testThrottleConfig(1)).(ZoneChangeApplier)
+
+	zones, err := throttled.ZoneIDNames(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, applier.zones, zones)
+	assert.Equal(t, 2, applier.calls)
+
+	permanent := errors.New("invalid credentials")
+	applier.calls, applier.errs = 0, []error{permanent}
+	_, err = throttled.ZoneIDNames(context.Background())
+	assert.Equal(t, permanent, err)
+	assert.Equal(t, 1, applier.calls)
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitedTransport(NewRateLimiter(20, 1), nil)}
+	start := time.Now()
+	for range 3 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	assert.Equal(t, 3, requests)
+	// the first request uses the burst, the two others wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.Error(t, err)
+	assert.Equal(t, 3, requests)
+
+	assert.Nil(t, NewRateLimitedTransport(nil, nil))
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var (
+	zoneApplyChangesTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "zone_apply_changes_total",
+			Help:      "Number of per-zone ApplyChanges calls partitioned by zone and status (vector).",
+		},
+		[]string{"zone", "status"},
+	)
+	zoneApplyChangesFailing = metrics.NewGaugedVectorOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "zone_apply_changes_failing",
+			Help:      "Whether the last per-zone ApplyChanges call failed, partitioned by zone (vector).",
+		},
+		[]string{"zone"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(zoneApplyChangesTotal)
+	metrics.RegisterMetric.MustRegister(zoneApplyChangesFailing)
+}
+
+// ZoneChangeApplier is implemented by providers which can apply changes to each of their zones independently.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type ZoneChangeApplier interface {
+	// ZoneIDNames returns the zones managed by the provider.
+	ZoneIDNames(ctx context.Context) (ZoneIDName, error)
+	// SplitZoneChanges separates the changes per zone ID, among the zones last returned by ZoneIDNames.
+	// Most providers use SplitChangesByZone; a change may belong to several zones, e.g. private ones.
+	SplitZoneChanges(zones ZoneIDName, changes *plan.Changes) map[string]*plan.Changes
+	// ApplyZoneChanges applies changes which all belong to the zone with the given ID.
+	ApplyZoneChanges(ctx context.Context, zoneID string, changes *plan.Changes) error
+}
+
+// partialZoneError is the error of the changes to some of the zones, when the changes to the others were applied.
+type partialZoneError struct {
+	error
+}
+
+func (e partialZoneError) Unwrap() error {
+	return e.error
+}
+
+// ZonePartitionedProvider splits changes per zone and applies them concurrently,
+// so that a failing zone does not prevent changes to the other zones.
+// Providers which do not implement ZoneChangeApplier apply their changes serially, as if unwrapped.
+type ZonePartitionedProvider struct {
+	Provider
+	applier     ZoneChangeApplier
+	concurrency int
+}
+
+// NewZonePartitionedProvider wraps a provider, applying changes to at most concurrency zones at the same time
+// if it implements ZoneChangeApplier. Otherwise, a warning is logged and the changes are applied serially.
+func NewZonePartitionedProvider(provider Provider, concurrency int) (*ZonePartitionedProvider, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("zone concurrency must be positive, got %d", concurrency)
+	}
+	applier, ok := provider.(ZoneChangeApplier)
+	if !ok {
+		log.Warnf("Provider %T does not support applying changes per zone, applying the changes of all zones serially", provider)
+	}
+	return &ZonePartitionedProvider{
+		Provider:    provider,
+		applier:     applier,
+		concurrency: concurrency,
+	}, nil
+}
+
+// ApplyChanges applies the changes of every zone in a bounded worker pool.
+// If only some zones fail, their errors are reported as a SoftError, as the
+// changes to the remaining zones have been applied.
+func (z *ZonePartitionedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if z.applier == nil {
+		return z.Provider.ApplyChanges(ctx, changes)
+	}
+	zones, err := z.applier.ZoneIDNames(ctx)
+	if err != nil {
+		return err
+	}
+	changesByZone := z.applier.SplitZoneChanges(zones, changes)
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		failures  = map[string]error{}
+		semaphore = make(chan struct{}, z.concurrency)
+	)
+	for zoneID, zoneChanges := range changesByZone {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			zoneName := zones[zoneID]
+			err := z.applier.ApplyZoneChanges(ctx, zoneID, zoneChanges)
+			if err != nil {
+				log.WithField("zone", zoneName).Errorf("Failed to apply changes: %v", err)
+				zoneApplyChangesTotal.CounterVec.WithLabelValues(zoneName, "failure").Inc()
+				zoneApplyChangesFailing.SetWithLabels(1, zoneName)
+
+				mutex.Lock()
+				failures[zoneID] = err
+				mutex.Unlock()
+				return
+			}
+			zoneApplyChangesTotal.CounterVec.WithLabelValues(zoneName, "success").Inc()
+			zoneApplyChangesFailing.SetWithLabels(0, zoneName)
+		}()
+	}
+	wg.Wait()
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(failures))
+	for _, zoneID := range slices.Sorted(maps.Keys(failures)) {
+		errs = append(errs, fmt.Errorf("zone %q: %w", zones[zoneID], failures[zoneID]))
+	}
+	err = errors.Join(errs...)
+	if len(failures) < len(changesByZone) {
+		return NewSoftError(partialZoneError{err})
+	}
+	return err
+}
+
+// SplitChangesByZone separates changes into one set of changes per zone, using the most specific zone for
+// every DNS name. Changes for DNS names outside of all zones are dropped; zones without changes are omitted.
+func SplitChangesByZone(zones ZoneIDName, changes *plan.Changes) map[string]*plan.Changes {
+	changesByZone := map[string]*plan.Changes{}
+	zoneChanges := func(ep *endpoint.Endpoint) *plan.Changes {
+		zoneID, _ := zones.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %q because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		if _, ok := changesByZone[zoneID]; !ok {
+			changesByZone[zoneID] = &plan.Changes{}
+		}
+		return changesByZone[zoneID]
+	}
+
+	for _, ep := range changes.Create {
+		if c := zoneChanges(ep); c != nil {
+			c.Create = append(c.Create, ep)
+		}
+	}
+	// UpdateOld and UpdateNew are matched by index and refer to the same DNS name
+	for i, ep := range changes.UpdateNew {
+		if c := zoneChanges(ep); c != nil {
+			c.UpdateNew = append(c.UpdateNew, ep)
+			c.UpdateOld = append(c.UpdateOld, changes.UpdateOld[i])
+		}
+	}
+	for _, ep := range changes.Delete {
+		if c := zoneChanges(ep); c != nil {
+			c.Delete = append(c.Delete, ep)
+		}
+	}
+	return changesByZone
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type zoneApplierProvider struct {
+	BaseProvider
+	zones        ZoneIDName
+	failingZones map[string]error
+
+	mutex   sync.Mutex
+	applied map[string]*plan.Changes
+
+	running    atomic.Int32
+	maxRunning atomic.Int32
+}
+
+func (p *zoneApplierProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, nil
+}
+
+func (p *zoneApplierProvider) ApplyChanges(_ context.Context, _ *plan.Changes) error {
+	return errors.New("unexpected call to ApplyChanges")
+}
+
+func (p *zoneApplierProvider) ZoneIDNames(_ context.Context) (ZoneIDName, error) {
+	return p.zones, nil
+}
+
+func (p *zoneApplierProvider) SplitZoneChanges(zones ZoneIDName, changes *plan.Changes) map[string]*plan.Changes {
+	return SplitChangesByZone(zones, changes)
+}
+
+func (p *zoneApplierProvider) ApplyZoneChanges(_ context.Context, zoneID string, changes *plan.Changes) error {
+	running := p.running.Add(1)
+	defer p.running.Add(-1)
+	for {
+		maxRunning := p.maxRunning.Load()
+		if running <= maxRunning || p.maxRunning.CompareAndSwap(maxRunning, running) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if err, ok := p.failingZones[zoneID]; ok {
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.applied[zoneID] = changes
+	return nil
+}
+
+func newZoneApplierProvider(failingZones map[string]error) *zoneApplierProvider {
+	return &zoneApplierProvider{
+		zones: ZoneIDName{
+			"zone-1": "example.org",
+			"zone-2": "sub.example.org",
+			"zone-3": "example.com",
+		},
+		failingZones: failingZones,
+		applied:      map[string]*plan.Changes{},
+	}
+}
+
+func TestSplitChangesByZone(t *testing.T) {
+	zones := ZoneIDName{
+		"zone-1": "example.org",
+		"zone-2": "sub.example.org",
+	}
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("b.sub.example.org", endpoint.RecordTypeA, "1.1.1.1")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("b.sub.example.org", endpoint.RecordTypeA, "2.2.2.2")},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("c.sub.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("d.example.net", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}
+
+	got := SplitChangesByZone(zones, changes)
+	assert.Equal(t, map[string]*plan.Changes{
+		"zone-1": {Create: changes.Create},
+		"zone-2": {UpdateOld: changes.UpdateOld, UpdateNew: changes.UpdateNew, Delete: changes.Delete[:1]},
+	}, got)
+}
+
+func TestNewZonePartitionedProvider(t *testing.T) {
+	_, err := NewZonePartitionedProvider(newZoneApplierProvider(nil), 0)
+	require.Error(t, err)
+}
+
+func TestZonePartitionedProviderFallsBackToApplyChanges(t *testing.T) {
+	var applied *plan.Changes
+	p, err := NewZonePartitionedProvider(&testProviderFunc{
+		applyChanges: func(_ context.Context, changes *plan.Changes) error {
+			applied = changes
+			return nil
+		},
+	}, 2)
+	require.NoError(t, err)
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1")}}
+	require.NoError(t, p.ApplyChanges(t.Context(), changes))
+	assert.Same(t, changes, applied)
+}
+
+func TestZonePartitionedProviderApplyChanges(t *testing.T) {
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("b.sub.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+			endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+	}
+
+	t.Run("all zones succeed", func(t *testing.T) {
+		applier := newZoneApplierProvider(nil)
+		p, err := NewZonePartitionedProvider(applier, 2)
+		require.NoError(t, err)
+
+		require.NoError(t, p.ApplyChanges(t.Context(), changes))
+		assert.Len(t, applier.applied, 3)
+		assert.LessOrEqual(t, applier.maxRunning.Load(), int32(2))
+	})
+
+	t.Run("failing zone does not block the others", func(t *testing.T) {
+		applier := newZoneApplierProvider(map[string]error{"zone-2": errors.New("api unavailable")})
+		p, err := NewZonePartitionedProvider(applier, 3)
+		require.NoError(t, err)
+
+		err = p.ApplyChanges(t.Context(), changes)
+		require.ErrorIs(t, err, SoftError)
+		assert.ErrorContains(t, err, `zone "sub.example.org": api unavailable`)
+		assert.Len(t, applier.applied, 2)
+		assert.Contains(t, applier.applied, "zone-1")
+		assert.Contains(t, applier.applied, "zone-3")
+	})
+
+	t.Run("all zones fail", func(t *testing.T) {
+		failure := errors.New("api unavailable")
+		applier := newZoneApplierProvider(map[string]error{"zone-1": failure, "zone-2": failure, "zone-3": failure})
+		p, err := NewZonePartitionedProvider(applier, 3)
+		require.NoError(t, err)
+
+		err = p.ApplyChanges(t.Context(), changes)
+		require.ErrorIs(t, err, failure)
+		assert.NotErrorIs(t, err, SoftError)
+	})
+}
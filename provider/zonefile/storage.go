@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonefile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// storage reads and writes the zone files, keyed by file name.
+type storage interface {
+	load(ctx context.Context) (map[string]string, error)
+	save(ctx context.Context, files map[string]string) error
+}
+
+// dirStorage stores the zone files in a directory.
+type dirStorage struct {
+	dir string
+}
+
+func (s *dirStorage) load(_ context.Context) (map[string]string, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone files: %w", err)
+	}
+	files := map[string]string{}
+	for _, de := range dirEntries {
+		if !de.Type().IsRegular() || strings.HasPrefix(de.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, de.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zone file: %w", err)
+		}
+		files[de.Name()] = string(data)
+	}
+	return files, nil
+}
+
+// save replaces the zone files atomically, so that name servers never load a partially written file.
+func (s *dirStorage) save(_ context.Context, files map[string]string) error {
+	for name, data := range files {
+		path := filepath.Join(s.dir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to write zone file: %w", err)
+		}
+		tmp, err := os.CreateTemp(s.dir, "."+name+".*")
+		if err != nil {
+			return fmt.Errorf("failed to write zone file: %w", err)
+		}
+		_, err = tmp.WriteString(data)
+		if err == nil {
+			err = tmp.Chmod(info.Mode().Perm())
+		}
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), path)
+		}
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+			return fmt.Errorf("failed to write zone file %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// configMapStorage stores the zone files as the keys of a ConfigMap.
+type configMapStorage struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func (s *configMapStorage) load(ctx context.Context) (map[string]string, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zone files ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return cm.Data, nil
+}
+
+func (s *configMapStorage) save(ctx context.Context, files map[string]string) error {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get zone files ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	for name, data := range files {
+		cm.Data[name] = data
+	}
+	if _, err := s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update zone files ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,415 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonefile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// now returns the current time, it is replaced in tests.
+var now = time.Now
+
+// entry is a logical entry of a zone file: a resource record, possibly spanning several lines
+// in parentheses, or a comment, a blank line or a directive, which are kept verbatim.
+type entry struct {
+	// text is the original text of the entry, including the trailing newline.
+	text string
+	// rr is the parsed resource record, nil for entries that are not resource records.
+	rr dns.RR
+	// origin is the $ORIGIN in effect for the entry.
+	origin string
+	// defaultTTL is the $TTL in effect for the entry, empty if there is none.
+	defaultTTL string
+	// inherited is set if the entry omits its owner and inherits it from the previous record.
+	inherited bool
+	// deleted is set if the record has been removed from the zone.
+	deleted bool
+}
+
+// zone is a parsed zone file which can be rendered back with the changes applied,
+// keeping the entries that were not changed as they were written.
+type zone struct {
+	// name is the fully qualified name of the zone, the owner of its SOA record.
+	name    string
+	entries []*entry
+	changed bool
+}
+
+// parseZone parses the master file text of a zone whose initial origin is the given one.
+func parseZone(text, origin string) (*zone, error) {
+	z := &zone{}
+	origin = dns.Fqdn(origin)
+	var defaultTTL, lastTTL, lastOwner string
+
+	chunks, err := splitEntries(text)
+	if err != nil {
+		return nil, err
+	}
+	for i, chunk := range chunks {
+		e := &entry{text: chunk, origin: origin, defaultTTL: defaultTTL}
+		z.entries = append(z.entries, e)
+
+		trimmed := strings.TrimSpace(chunk)
+		if trimmed == "" || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "$") {
+			fields := strings.Fields(stripComment(trimmed))
+			switch strings.ToUpper(fields[0]) {
+			case "$ORIGIN":
+				if len(fields) < 2 {
+					return nil, fmt.Errorf("entry %d: $ORIGIN without a domain name", i+1)
+				}
+				origin = absoluteName(fields[1], origin)
+			case "$TTL":
+				if len(fields) < 2 {
+					return nil, fmt.Errorf("entry %d: $TTL without a value", i+1)
+				}
+				defaultTTL = fields[1]
+			case "$INCLUDE":
+				return nil, fmt.Errorf("entry %d: $INCLUDE is not supported", i+1)
+			}
+			// $GENERATE and unknown directives are kept as they are and are not managed.
+			continue
+		}
+
+		e.inherited = chunk[0] == ' ' || chunk[0] == '\t'
+		owner := ""
+		if e.inherited {
+			if lastOwner == "" {
+				return nil, fmt.Errorf("entry %d: record without an owner name", i+1)
+			}
+			owner = lastOwner
+		}
+		ttl := defaultTTL
+		if ttl == "" {
+			ttl = lastTTL
+		}
+		rr, err := parseRR(owner+chunk, origin, ttl)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		e.rr = rr
+		lastOwner = rr.Header().Name
+		lastTTL = strconv.FormatUint(uint64(rr.Header().Ttl), 10)
+		if rr.Header().Rrtype == dns.TypeSOA && z.name == "" {
+			z.name = rr.Header().Name
+		}
+	}
+	return z, nil
+}
+
+// parseRR parses the text of a single resource record in the given origin and default TTL.
+func parseRR(text, origin, ttl string) (dns.RR, error) {
+	var preamble strings.Builder
+	fmt.Fprintf(&preamble, "$ORIGIN %s\n", origin)
+	if ttl != "" {
+		fmt.Fprintf(&preamble, "$TTL %s\n", ttl)
+	}
+	zp := dns.NewZoneParser(strings.NewReader(preamble.String()+text), origin, "")
+	rr, ok := zp.Next()
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no record in %q", strings.TrimSpace(text))
+	}
+	return rr, nil
+}
+
+// splitEntries splits the text of a zone file into entries, joining the lines of
+// records that span several lines in parentheses.
+func splitEntries(text string) ([]string, error) {
+	var entries []string
+	var current strings.Builder
+	depth := 0
+	for len(text) > 0 {
+		line := text
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			line = text[:i+1]
+		}
+		text = text[len(line):]
+
+		current.WriteString(line)
+		scanTokens(line, func(token string, _ int) {
+			switch token {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+		})
+		if depth < 0 {
+			return nil, fmt.Errorf("unbalanced parentheses in %q", strings.TrimSpace(current.String()))
+		}
+		if depth == 0 {
+			entries = append(entries, current.String())
+			current.Reset()
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %q", strings.TrimSpace(current.String()))
+	}
+	return entries, nil
+}
+
+// scanTokens calls fn with each token of the text and its offset, skipping comments.
+// Parentheses are tokens of their own and quoted strings are single tokens.
+func scanTokens(text string, fn func(token string, offset int)) {
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == ';':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			fn(text[i:i+1], i)
+			i++
+		default:
+			start := i
+			quoted := c == '"'
+			if quoted {
+				i++
+			}
+			for i < len(text) {
+				c = text[i]
+				if c == '\\' {
+					i += 2
+					continue
+				}
+				if quoted {
+					i++
+					if c == '"' {
+						break
+					}
+					continue
+				}
+				if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ';' || c == '(' || c == ')' {
+					break
+				}
+				i++
+			}
+			i = min(i, len(text))
+			fn(text[start:i], start)
+		}
+	}
+}
+
+// stripComment removes the comment at the end of a line.
+func stripComment(line string) string {
+	end := len(line)
+	scanTokens(line, func(token string, offset int) {
+		end = offset + len(token)
+	})
+	return line[:end]
+}
+
+// absoluteName returns the fully qualified form of a name of the zone file in the given origin.
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case dns.IsFqdn(name):
+		return name
+	case origin == ".":
+		return name + "."
+	default:
+		return name + "." + origin
+	}
+}
+
+// relativeName returns the name as it is written in a zone file with the given origin.
+func relativeName(name, origin string) string {
+	switch {
+	case strings.EqualFold(name, origin):
+		return "@"
+	case origin != "." && len(name) > len(origin) && strings.EqualFold(name[len(name)-len(origin)-1:], "."+origin):
+		return name[:len(name)-len(origin)-1]
+	default:
+		return name
+	}
+}
+
+// records returns the resource records of the zone which have not been deleted.
+func (z *zone) records() []dns.RR {
+	var rrs []dns.RR
+	for _, e := range z.entries {
+		if e.rr != nil && !e.deleted {
+			rrs = append(rrs, e.rr)
+		}
+	}
+	return rrs
+}
+
+// remove deletes the records of the zone that are duplicates of rr, ignoring their TTL.
+func (z *zone) remove(rr dns.RR) {
+	for _, e := range z.entries {
+		if e.rr != nil && !e.deleted && dns.IsDuplicate(e.rr, rr) {
+			e.deleted = true
+			z.changed = true
+		}
+	}
+}
+
+// setTTL rewrites the records of the zone that are duplicates of rr and have another TTL.
+func (z *zone) setTTL(rr dns.RR) {
+	for _, e := range z.entries {
+		if e.rr == nil || e.deleted || !dns.IsDuplicate(e.rr, rr) || e.rr.Header().Ttl == rr.Header().Ttl {
+			continue
+		}
+		e.rr = dns.Copy(e.rr)
+		e.rr.Header().Ttl = rr.Header().Ttl
+		e.text = formatRR(e.rr, e.origin, true)
+		e.inherited = false
+		z.changed = true
+	}
+}
+
+// contains returns whether the zone has a duplicate of rr, ignoring its TTL.
+func (z *zone) contains(rr dns.RR) bool {
+	for _, e := range z.entries {
+		if e.rr != nil && !e.deleted && dns.IsDuplicate(e.rr, rr) {
+			return true
+		}
+	}
+	return false
+}
+
+// add inserts rr after the last record of the zone with the same owner name, or at the end of the zone.
+// The TTL of rr is omitted if explicitTTL is false and the zone defines a default TTL with $TTL.
+func (z *zone) add(rr dns.RR, explicitTTL bool) {
+	pos := len(z.entries)
+	for i, e := range z.entries {
+		if e.rr != nil && !e.deleted && strings.EqualFold(e.rr.Header().Name, rr.Header().Name) {
+			pos = i + 1
+		}
+	}
+
+	origin, defaultTTL := z.name, ""
+	if pos > 0 {
+		last := z.entries[pos-1]
+		origin, defaultTTL = last.origin, last.defaultTTL
+		if last.rr == nil {
+			// A directive changes the origin or the TTL for the entries after it.
+			fields := strings.Fields(stripComment(last.text))
+			if len(fields) > 1 && strings.EqualFold(fields[0], "$ORIGIN") {
+				origin = absoluteName(fields[1], origin)
+			}
+			if len(fields) > 1 && strings.EqualFold(fields[0], "$TTL") {
+				defaultTTL = fields[1]
+			}
+		}
+		if !strings.HasSuffix(last.text, "\n") {
+			last.text += "\n"
+		}
+	}
+	if defaultTTL == "" {
+		explicitTTL = true
+	}
+
+	e := &entry{rr: rr, origin: origin, defaultTTL: defaultTTL, text: formatRR(rr, origin, explicitTTL)}
+	z.entries = append(z.entries[:pos], append([]*entry{e}, z.entries[pos:]...)...)
+	z.changed = true
+}
+
+// bumpSerial increments the serial of the SOA record of the zone. Serials in the
+// YYYYMMDDnn date format are set to the first serial of the current day if that is greater.
+func (z *zone) bumpSerial() error {
+	for _, e := range z.entries {
+		soa, ok := e.rr.(*dns.SOA)
+		if !ok || e.deleted {
+			continue
+		}
+		serial := soa.Serial + 1
+		if isDateSerial(soa.Serial) {
+			today, _ := strconv.ParseUint(now().UTC().Format("20060102"), 10, 32)
+			serial = max(serial, uint32(today)*100)
+		}
+
+		// Rewrite the serial token in place to keep the layout and the comments of the record.
+		tokens, offsets := []string{}, []int{}
+		scanTokens(e.text, func(token string, offset int) {
+			if token != "(" && token != ")" {
+				tokens = append(tokens, token)
+				offsets = append(offsets, offset)
+			}
+		})
+		for i, token := range tokens {
+			if strings.EqualFold(token, "SOA") && i+3 < len(tokens) {
+				start := offsets[i+3]
+				end := start + len(tokens[i+3])
+				e.text = e.text[:start] + strconv.FormatUint(uint64(serial), 10) + e.text[end:]
+				soa.Serial = serial
+				return nil
+			}
+		}
+		return fmt.Errorf("failed to find the serial of the SOA record of zone %s", z.name)
+	}
+	return fmt.Errorf("zone %s has no SOA record", z.name)
+}
+
+// isDateSerial returns whether the serial looks like a serial in the YYYYMMDDnn date format.
+func isDateSerial(serial uint32) bool {
+	_, err := time.Parse("20060102", strconv.FormatUint(uint64(serial/100), 10))
+	return serial >= 1970010100 && err == nil
+}
+
+// render returns the text of the zone file with the changes applied.
+func (z *zone) render() string {
+	var b strings.Builder
+	lastOwner := ""
+	for _, e := range z.entries {
+		if e.deleted {
+			continue
+		}
+		if e.rr == nil {
+			b.WriteString(e.text)
+			continue
+		}
+		owner := e.rr.Header().Name
+		if e.inherited && !strings.EqualFold(owner, lastOwner) {
+			// The record that the entry inherited its owner from was removed or another record was inserted before it.
+			b.WriteString(relativeName(owner, e.origin))
+		}
+		b.WriteString(e.text)
+		lastOwner = owner
+	}
+	return b.String()
+}
+
+// formatRR returns the zone file line of a record in the given origin.
+func formatRR(rr dns.RR, origin string, explicitTTL bool) string {
+	h := rr.Header()
+	fields := []string{relativeName(h.Name, origin)}
+	if explicitTTL {
+		fields = append(fields, strconv.FormatUint(uint64(h.Ttl), 10))
+	}
+	fields = append(fields, dns.ClassToString[h.Class], dns.TypeToString[h.Rrtype], rdata(rr))
+	return strings.Join(fields, "\t") + "\n"
+}
+
+// rdata returns the presentation format of the data of a record.
+func rdata(rr dns.RR) string {
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonefile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZone = `; example.com zone
+$TTL 300
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		2024010101 ; serial
+		3600       ; refresh
+		600        ; retry
+		86400      ; expire
+		300 )      ; minimum
+	IN	NS	ns1.example.com.
+ns1	IN	A	192.0.2.1
+
+www	IN	A	192.0.2.10
+	IN	AAAA	2001:db8::10 ; dual stack
+$ORIGIN sub.example.com.
+api	60	IN	CNAME	www.example.com.
+$GENERATE 1-4 host$ A 192.0.2.$
+`
+
+func TestParseZone(t *testing.T) {
+	z, err := parseZone(testZone, "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com.", z.name)
+	assert.Equal(t, testZone, z.render())
+
+	var records []string
+	for _, rr := range z.records() {
+		records = append(records, rr.String())
+	}
+	assert.Equal(t, []string{
+		"example.com.\t300\tIN\tSOA\tns1.example.com. hostmaster.example.com. 2024010101 3600 600 86400 300",
+		"example.com.\t300\tIN\tNS\tns1.example.com.",
+		"ns1.example.com.\t300\tIN\tA\t192.0.2.1",
+		"www.example.com.\t300\tIN\tA\t192.0.2.10",
+		"www.example.com.\t300\tIN\tAAAA\t2001:db8::10",
+		"api.sub.example.com.\t60\tIN\tCNAME\twww.example.com.",
+	}, records)
+}
+
+func TestParseZoneErrors(t *testing.T) {
+	for _, tt := range []struct {
+		title string
+		text  string
+		err   string
+	}{
+		{
+			title: "include",
+			text:  "$INCLUDE other.zone\n",
+			err:   "$INCLUDE is not supported",
+		},
+		{
+			title: "unbalanced parentheses",
+			text:  "@ IN SOA ns1 hostmaster ( 1 2 3 4 5\n",
+			err:   "unbalanced parentheses",
+		},
+		{
+			title: "no owner",
+			text:  "  IN A 192.0.2.1\n",
+			err:   "record without an owner name",
+		},
+		{
+			title: "invalid record",
+			text:  "www IN A not-an-ip\n",
+			err:   "entry 1",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			_, err := parseZone(tt.text, "example.com.")
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestZoneChanges(t *testing.T) {
+	z, err := parseZone(testZone, "example.com.")
+	require.NoError(t, err)
+
+	z.remove(mustRR(t, "www.example.com. 300 IN A 192.0.2.10"))
+	z.add(mustRR(t, "www.example.com. 300 IN A 192.0.2.11"), false)
+	z.add(mustRR(t, "new.sub.example.com. 120 IN TXT \"hello world\""), true)
+	z.setTTL(mustRR(t, "api.sub.example.com. 30 IN CNAME www.example.com."))
+	assert.True(t, z.contains(mustRR(t, "www.example.com. 60 IN A 192.0.2.11")))
+	assert.False(t, z.contains(mustRR(t, "www.example.com. 300 IN A 192.0.2.10")))
+
+	assert.Equal(t, `; example.com zone
+$TTL 300
+@	IN	SOA	ns1.example.com. hostmaster.example.com. (
+		2024010101 ; serial
+		3600       ; refresh
+		600        ; retry
+		86400      ; expire
+		300 )      ; minimum
+	IN	NS	ns1.example.com.
+ns1	IN	A	192.0.2.1
+
+www	IN	AAAA	2001:db8::10 ; dual stack
+www	IN	A	192.0.2.11
+$ORIGIN sub.example.com.
+api	30	IN	CNAME	www.example.com.
+$GENERATE 1-4 host$ A 192.0.2.$
+new	120	IN	TXT	"hello world"
+`, z.render())
+}
+
+func TestZoneBumpSerial(t *testing.T) {
+	now = func() time.Time { return time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	for _, tt := range []struct {
+		title    string
+		serial   string
+		expected string
+	}{
+		{
+			title:    "counter",
+			serial:   "41",
+			expected: "42",
+		},
+		{
+			title:    "date of a previous day",
+			serial:   "2024010105",
+			expected: "2025031400",
+		},
+		{
+			title:    "date of the current day",
+			serial:   "2025031407",
+			expected: "2025031408",
+		},
+		{
+			title:    "date in the future",
+			serial:   "2030010100",
+			expected: "2030010101",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			z, err := parseZone("@ 3600 IN SOA ns1 hostmaster "+tt.serial+" 3600 600 86400 300 ; soa\n", "example.com.")
+			require.NoError(t, err)
+			require.NoError(t, z.bumpSerial())
+			assert.Equal(t, "@ 3600 IN SOA ns1 hostmaster "+tt.expected+" 3600 600 86400 300 ; soa\n", z.render())
+		})
+	}
+}
+
+func TestZoneRenderInheritedOwner(t *testing.T) {
+	z, err := parseZone("@ 3600 IN SOA ns1 hostmaster 1 3600 600 86400 300\nwww 300 IN A 192.0.2.1\n 300 IN AAAA 2001:db8::1\n", "example.com.")
+	require.NoError(t, err)
+
+	z.remove(mustRR(t, "www.example.com. 300 IN A 192.0.2.1"))
+
+	assert.Equal(t, "@ 3600 IN SOA ns1 hostmaster 1 3600 600 86400 300\nwww 300 IN AAAA 2001:db8::1\n", z.render())
+}
+
+func TestRelativeName(t *testing.T) {
+	assert.Equal(t, "@", relativeName("example.com.", "example.com."))
+	assert.Equal(t, "www", relativeName("www.example.com.", "example.com."))
+	assert.Equal(t, "www.other.com.", relativeName("www.other.com.", "example.com."))
+	assert.Equal(t, "wwwexample.com.", relativeName("wwwexample.com.", "example.com."))
+	assert.Equal(t, "www.", absoluteName("www", "."))
+	assert.Equal(t, "www.example.com.", absoluteName("www", "example.com."))
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+	return rr
+}
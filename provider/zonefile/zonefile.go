@@ -0,0 +1,332 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonefile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const defaultTTL = 3600
+
+// supportedRecordTypes are the record types managed in the zone files.
+var supportedRecordTypes = map[uint16]string{
+	dns.TypeA:     endpoint.RecordTypeA,
+	dns.TypeAAAA:  endpoint.RecordTypeAAAA,
+	dns.TypeCNAME: endpoint.RecordTypeCNAME,
+	dns.TypeTXT:   endpoint.RecordTypeTXT,
+	dns.TypeSRV:   endpoint.RecordTypeSRV,
+	dns.TypeNS:    endpoint.RecordTypeNS,
+	dns.TypePTR:   endpoint.RecordTypePTR,
+	dns.TypeMX:    endpoint.RecordTypeMX,
+}
+
+// Config is used for configuring a ZoneFileProvider.
+type Config struct {
+	// The directory containing the zone files.
+	Directory string
+	// The ConfigMap containing the zone files, in the namespace/name format. Used if Directory is empty.
+	ConfigMap string
+	// The Kubernetes client used to access the ConfigMap.
+	KubeClient kubernetes.Interface
+	// A filter to apply to the zones.
+	DomainFilter *endpoint.DomainFilter
+	// Do nothing and log what would have changed.
+	DryRun bool
+}
+
+// ZoneFileProvider is an implementation of Provider for RFC 1035 master files, as served by
+// BIND, NSD or Knot. Each file holds a zone, whose name is the owner of its SOA record.
+// Records of unsupported types, comments and directives are kept as they are.
+type ZoneFileProvider struct {
+	provider.BaseProvider
+	storage      storage
+	domainFilter *endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewZoneFileProvider initializes a new zone file based Provider.
+func NewZoneFileProvider(cfg Config) (*ZoneFileProvider, error) {
+	p := &ZoneFileProvider{domainFilter: cfg.DomainFilter, dryRun: cfg.DryRun}
+	switch {
+	case cfg.Directory != "":
+		p.storage = &dirStorage{dir: cfg.Directory}
+	case cfg.ConfigMap != "":
+		namespace, name, ok := strings.Cut(cfg.ConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid zone files ConfigMap %q, expected namespace/name", cfg.ConfigMap)
+		}
+		if cfg.KubeClient == nil {
+			return nil, errors.New("a Kubernetes client is required to use a zone files ConfigMap")
+		}
+		p.storage = &configMapStorage{client: cfg.KubeClient, namespace: namespace, name: name}
+	default:
+		return nil, errors.New("no zone file directory or ConfigMap specified")
+	}
+	return p, nil
+}
+
+// zones loads and parses the zone files matching the domain filter, keyed by file name.
+func (p *ZoneFileProvider) zones(ctx context.Context) (map[string]*zone, error) {
+	files, err := p.storage.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones := map[string]*zone{}
+	for file, text := range files {
+		z, err := parseZone(text, originFromFileName(file))
+		if err != nil {
+			log.Warnf("Skipping zone file %s: %v", file, err)
+			continue
+		}
+		if z.name == "" {
+			log.Debugf("Skipping zone file %s without SOA record", file)
+			continue
+		}
+		if !p.domainFilter.Match(z.name) {
+			log.Debugf("Skipping zone %s of file %s that does not match domain filter", z.name, file)
+			continue
+		}
+		zones[file] = z
+	}
+	return zones, nil
+}
+
+// originFromFileName returns the initial origin of a zone file, derived from names
+// such as example.com, example.com.zone, example.com.db or db.example.com.
+func originFromFileName(file string) string {
+	name := strings.TrimPrefix(file, "db.")
+	name = strings.TrimSuffix(name, ".zone")
+	name = strings.TrimSuffix(name, ".db")
+	return dns.Fqdn(name)
+}
+
+// Records returns the records of the supported types of all the zones, except their SOA and apex NS records.
+func (p *ZoneFileProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, file := range sortedKeys(zones) {
+		z := zones[file]
+		byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+		for _, rr := range z.records() {
+			h := rr.Header()
+			recordType, ok := supportedRecordTypes[h.Rrtype]
+			if !ok || h.Class != dns.ClassINET {
+				continue
+			}
+			if h.Rrtype == dns.TypeNS && strings.EqualFold(h.Name, z.name) {
+				continue
+			}
+			key := endpoint.EndpointKey{DNSName: strings.ToLower(strings.TrimSuffix(h.Name, ".")), RecordType: recordType}
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, strings.TrimSuffix(rdata(rr), "."))
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(key.DNSName, recordType, endpoint.TTL(h.Ttl), rdata(rr))
+			if ep == nil {
+				continue
+			}
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+// ApplyChanges applies the changes to the zone files, bumping the serial of the zones that changed.
+func (p *ZoneFileProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneIDName := provider.ZoneIDName{}
+	for file, z := range zones {
+		zoneIDName.Add(file, strings.TrimSuffix(z.name, "."))
+	}
+	findZone := func(ep *endpoint.Endpoint) *zone {
+		file, _ := zoneIDName.FindZone(ep.DNSName)
+		if file == "" {
+			log.Warnf("No zone file found for %s %s, skipping", ep.RecordType, ep.DNSName)
+			return nil
+		}
+		return zones[file]
+	}
+
+	for _, ep := range changes.Delete {
+		z := findZone(ep)
+		if z == nil {
+			continue
+		}
+		rrs, err := recordsFor(ep)
+		if err != nil {
+			return err
+		}
+		for _, rr := range rrs {
+			log.Infof("Removing %s from zone %s", strings.TrimSpace(rr.String()), z.name)
+			z.remove(rr)
+		}
+	}
+
+	updateOld := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range changes.UpdateOld {
+		updateOld[ep.Key()] = ep
+	}
+	for _, desired := range changes.UpdateNew {
+		z := findZone(desired)
+		if z == nil {
+			continue
+		}
+		rrs, err := recordsFor(desired)
+		if err != nil {
+			return err
+		}
+		current, ok := updateOld[desired.Key()]
+		if !ok {
+			current = endpoint.NewEndpoint(desired.DNSName, desired.RecordType)
+		}
+		currentRRs, err := recordsFor(current)
+		if err != nil {
+			return err
+		}
+		for _, rr := range currentRRs {
+			if !containsRR(rrs, rr) {
+				log.Infof("Removing %s from zone %s", strings.TrimSpace(rr.String()), z.name)
+				z.remove(rr)
+			}
+		}
+		p.addRecords(z, desired, rrs)
+	}
+
+	for _, ep := range changes.Create {
+		z := findZone(ep)
+		if z == nil {
+			continue
+		}
+		rrs, err := recordsFor(ep)
+		if err != nil {
+			return err
+		}
+		p.addRecords(z, ep, rrs)
+	}
+
+	files := map[string]string{}
+	for file, z := range zones {
+		if !z.changed {
+			continue
+		}
+		if err := z.bumpSerial(); err != nil {
+			return err
+		}
+		files[file] = z.render()
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	if p.dryRun {
+		for _, file := range sortedKeys(files) {
+			log.Infof("Would write zone file %s:\n%s", file, files[file])
+		}
+		return nil
+	}
+	return p.storage.save(ctx, files)
+}
+
+// addRecords adds the records of an endpoint that are missing from the zone, and updates the TTL of those that exist.
+func (p *ZoneFileProvider) addRecords(z *zone, ep *endpoint.Endpoint, rrs []dns.RR) {
+	for _, rr := range rrs {
+		if z.contains(rr) {
+			if ep.RecordTTL.IsConfigured() {
+				z.setTTL(rr)
+			}
+			continue
+		}
+		log.Infof("Adding %s to zone %s", strings.TrimSpace(rr.String()), z.name)
+		z.add(rr, ep.RecordTTL.IsConfigured())
+	}
+}
+
+// recordsFor returns the resource records of the targets of an endpoint.
+func recordsFor(ep *endpoint.Endpoint) ([]dns.RR, error) {
+	ttl := int64(defaultTTL)
+	if ep.RecordTTL.IsConfigured() {
+		ttl = int64(ep.RecordTTL)
+	}
+	rrs := make([]dns.RR, 0, len(ep.Targets))
+	for _, target := range ep.Targets {
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(ep.DNSName), ttl, ep.RecordType, targetRdata(ep.RecordType, target)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s record %s: %w", ep.RecordType, ep.DNSName, err)
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
+
+// targetRdata returns the presentation format of an endpoint target in a zone file,
+// in which domain names must be fully qualified and text must be quoted.
+func targetRdata(recordType, target string) string {
+	switch recordType {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypePTR:
+		return dns.Fqdn(target)
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		fields := strings.Fields(target)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = dns.Fqdn(fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	case endpoint.RecordTypeTXT:
+		if strings.HasPrefix(target, `"`) {
+			return target
+		}
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(target) + `"`
+	default:
+		return target
+	}
+}
+
+func containsRR(rrs []dns.RR, rr dns.RR) bool {
+	for _, other := range rrs {
+		if dns.IsDuplicate(other, rr) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zonefile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const testProviderZone = `$TTL 300
+@	IN	SOA	ns1.example.com. hostmaster.example.com. 7 3600 600 86400 300
+@	IN	NS	ns1.example.com.
+@	IN	MX	10 mail.example.com.
+ns1	IN	A	192.0.2.1
+; managed by external-dns
+www	IN	A	192.0.2.10
+www	IN	A	192.0.2.11
+www	IN	TXT	"heritage=external-dns,external-dns/owner=default"
+_sip._tcp	IN	SRV	0 50 5060 sip.example.com.
+`
+
+func TestNewZoneFileProvider(t *testing.T) {
+	_, err := NewZoneFileProvider(Config{})
+	assert.ErrorContains(t, err, "no zone file directory or ConfigMap specified")
+
+	_, err = NewZoneFileProvider(Config{ConfigMap: "zones"})
+	assert.ErrorContains(t, err, "expected namespace/name")
+
+	_, err = NewZoneFileProvider(Config{ConfigMap: "dns/zones"})
+	assert.ErrorContains(t, err, "a Kubernetes client is required")
+
+	_, err = NewZoneFileProvider(Config{ConfigMap: "dns/zones", KubeClient: fake.NewClientset()})
+	assert.NoError(t, err)
+}
+
+func TestOriginFromFileName(t *testing.T) {
+	for file, origin := range map[string]string{
+		"example.com":      "example.com.",
+		"example.com.zone": "example.com.",
+		"example.com.db":   "example.com.",
+		"db.example.com":   "example.com.",
+	} {
+		assert.Equal(t, origin, originFromFileName(file), file)
+	}
+}
+
+func TestZoneFileProviderRecords(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "example.com.zone"), []byte(testProviderZone), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.org.zone"), []byte("@ 300 IN SOA ns1 hostmaster 1 3600 600 86400 300\nwww 300 IN A 192.0.2.99\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a zone\n"), 0o644))
+
+	p, err := NewZoneFileProvider(Config{Directory: dir, DomainFilter: endpoint.NewDomainFilter([]string{"example.com"})})
+	require.NoError(t, err)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("ns1.example.com", endpoint.RecordTypeA, 300, "192.0.2.1"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.10", "192.0.2.11"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, `"heritage=external-dns,external-dns/owner=default"`),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "0 50 5060 sip.example.com"),
+	}, records)
+}
+
+func TestZoneFileProviderApplyChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example.com.zone")
+	require.NoError(t, os.WriteFile(path, []byte(testProviderZone), 0o640))
+
+	p, err := NewZoneFileProvider(Config{Directory: dir})
+	require.NoError(t, err)
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"),
+			endpoint.NewEndpoint("www.unknown.org", endpoint.RecordTypeA, "192.0.2.50"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.10", "192.0.2.11"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "192.0.2.11", "192.0.2.12"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "0 50 5060 sip.example.com"),
+		},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `$TTL 300
+@	IN	SOA	ns1.example.com. hostmaster.example.com. 8 3600 600 86400 300
+@	IN	NS	ns1.example.com.
+@	IN	MX	10 mail.example.com.
+ns1	IN	A	192.0.2.1
+; managed by external-dns
+www	IN	A	192.0.2.11
+www	IN	TXT	"heritage=external-dns,external-dns/owner=default"
+www	300	IN	A	192.0.2.12
+api	IN	CNAME	www.example.com.
+api	IN	TXT	"heritage=external-dns,external-dns/owner=default"
+`, string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestZoneFileProviderApplyChangesNoChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example.com.zone")
+	require.NoError(t, os.WriteFile(path, []byte(testProviderZone), 0o644))
+
+	p, err := NewZoneFileProvider(Config{Directory: dir})
+	require.NoError(t, err)
+
+	// Creating existing records does not change the zone nor its serial.
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "192.0.2.10"),
+		},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testProviderZone, string(data))
+}
+
+func TestZoneFileProviderDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "example.com.zone")
+	require.NoError(t, os.WriteFile(path, []byte(testProviderZone), 0o644))
+
+	p, err := NewZoneFileProvider(Config{Directory: dir, DryRun: true})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "192.0.2.20"),
+		},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testProviderZone, string(data))
+}
+
+func TestZoneFileProviderConfigMap(t *testing.T) {
+	kubeClient := fake.NewClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dns", Name: "zones"},
+		Data: map[string]string{
+			"example.com.zone": testProviderZone,
+			"example.org.zone": "@ 300 IN SOA ns1 hostmaster 1 3600 600 86400 300\n",
+		},
+	})
+
+	p, err := NewZoneFileProvider(Config{ConfigMap: "dns/zones", KubeClient: kubeClient})
+	require.NoError(t, err)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeAAAA, 60, "2001:db8::1"),
+		},
+	}))
+
+	cm, err := kubeClient.CoreV1().ConfigMaps("dns").Get(context.Background(), "zones", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testProviderZone, cm.Data["example.com.zone"])
+	assert.Equal(t, "@ 300 IN SOA ns1 hostmaster 2 3600 600 86400 300\nwww\t60\tIN\tAAAA\t2001:db8::1\n", cm.Data["example.org.zone"])
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, records, endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeAAAA, 60, "2001:db8::1"))
+}
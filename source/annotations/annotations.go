@@ -29,9 +29,10 @@ const (
 	WebhookPrefix    = "external-dns.alpha.kubernetes.io/webhook-"
 	CloudflarePrefix = "external-dns.alpha.kubernetes.io/cloudflare-"
 
-	TtlKey     = "external-dns.alpha.kubernetes.io/ttl"
-	ttlMinimum = 1
-	ttlMaximum = math.MaxInt32
+	TtlKey = "external-dns.alpha.kubernetes.io/ttl"
+	// TTLMinimum and TTLMaximum are the bounds of a valid TTL, in seconds
+	TTLMinimum = 1
+	TTLMaximum = math.MaxInt32
 
 	SetIdentifierKey = "external-dns.alpha.kubernetes.io/set-identifier"
 	AliasKey         = "external-dns.alpha.kubernetes.io/alias"
@@ -44,6 +45,8 @@ const (
 	AccessKey = "external-dns.alpha.kubernetes.io/access"
 	// The annotation used for specifying the type of endpoints to use for headless services
 	EndpointsTypeKey = "external-dns.alpha.kubernetes.io/endpoints-type"
+	// The annotation used for publishing additional records per topology zone for headless services
+	TopologyZoneRecordsKey = "external-dns.alpha.kubernetes.io/topology-zone-records"
 	// The annotation used to determine the source of hostnames for ingresses.  This is an optional field - all
 	// available hostname sources are used if not specified.
 	IngressHostnameSourceKey = "external-dns.alpha.kubernetes.io/ingress-hostname-source"
@@ -51,4 +54,6 @@ const (
 	ControllerValue = "dns-controller"
 	// The annotation used for defining the desired hostname
 	InternalHostnameKey = "external-dns.alpha.kubernetes.io/internal-hostname"
+	// The annotation used for overriding other annotations for specific hostnames
+	HostnameOverridesKey = "external-dns.alpha.kubernetes.io/hostname-overrides"
 )
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	log "github.com/sirupsen/logrus"
+)
+
+const annotationKeyPrefix = "external-dns.alpha.kubernetes.io/"
+
+// HostnameOverrides holds the annotations overridden for specific hostnames, keyed by hostname.
+type HostnameOverrides map[string]map[string]string
+
+// HostnameOverridesFromAnnotations parses the hostname overrides annotation of the given resource.
+// The annotation is a YAML or JSON object keyed by hostname, whose values map annotation names,
+// with or without the "external-dns.alpha.kubernetes.io/" prefix, to their value for that hostname:
+//
+//	api.example.com:
+//	  ttl: 60
+//	  cloudflare-proxied: false
+//	static.example.com:
+//	  target: cdn.example.net
+//
+// It returns nil if the annotation is missing or invalid.
+func HostnameOverridesFromAnnotations(annotations map[string]string, resource string) HostnameOverrides {
+	annotation, ok := annotations[HostnameOverridesKey]
+	if !ok {
+		return nil
+	}
+	var parsed map[string]map[string]any
+	if err := yaml.Unmarshal([]byte(annotation), &parsed); err != nil {
+		log.Warnf("%s: %q is not a valid hostname overrides value: %v", resource, annotation, err)
+		return nil
+	}
+
+	overrides := make(HostnameOverrides, len(parsed))
+	for hostname, values := range parsed {
+		hostOverrides := make(map[string]string, len(values))
+		for key, value := range values {
+			if !strings.Contains(key, "/") {
+				key = annotationKeyPrefix + key
+			}
+			switch v := value.(type) {
+			case []any:
+				targets := make([]string, 0, len(v))
+				for _, t := range v {
+					targets = append(targets, fmt.Sprint(t))
+				}
+				hostOverrides[key] = strings.Join(targets, ",")
+			case map[string]any:
+				log.Warnf("%s: ignoring %q override of hostname %q, the value must not be an object", resource, key, hostname)
+			default:
+				hostOverrides[key] = fmt.Sprint(v)
+			}
+		}
+		overrides[normalizeHostname(hostname)] = hostOverrides
+	}
+	return overrides
+}
+
+// Apply returns the annotations to use for the given hostname: annotations with the overrides of
+// hostname applied. annotations is returned unchanged when hostname has no overrides.
+func (o HostnameOverrides) Apply(annotations map[string]string, hostname string) map[string]string {
+	hostOverrides, ok := o[normalizeHostname(hostname)]
+	if !ok {
+		return annotations
+	}
+	result := maps.Clone(annotations)
+	if result == nil {
+		result = make(map[string]string, len(hostOverrides))
+	}
+	maps.Copy(result, hostOverrides)
+	return result
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.ToLower(strings.TrimSuffix(hostname, "."))
+}
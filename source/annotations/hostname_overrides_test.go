@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostnameOverridesFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    HostnameOverrides
+	}{
+		{
+			name:        "no annotation",
+			annotations: map[string]string{TtlKey: "60"},
+			expected:    nil,
+		},
+		{
+			name: "yaml",
+			annotations: map[string]string{HostnameOverridesKey: `
+API.example.com.:
+  ttl: 60
+  cloudflare-proxied: false
+  external-dns.alpha.kubernetes.io/aws-weight: 10
+static.example.com:
+  target:
+    - cdn1.example.net
+    - cdn2.example.net
+`},
+			expected: HostnameOverrides{
+				"api.example.com": {
+					TtlKey:               "60",
+					CloudflareProxiedKey: "false",
+					AWSPrefix + "weight": "10",
+				},
+				"static.example.com": {
+					TargetKey: "cdn1.example.net,cdn2.example.net",
+				},
+			},
+		},
+		{
+			name:        "json",
+			annotations: map[string]string{HostnameOverridesKey: `{"api.example.com": {"ttl": "1m", "set-identifier": "blue"}}`},
+			expected: HostnameOverrides{
+				"api.example.com": {
+					TtlKey:           "1m",
+					SetIdentifierKey: "blue",
+				},
+			},
+		},
+		{
+			name:        "objects are ignored",
+			annotations: map[string]string{HostnameOverridesKey: `{"api.example.com": {"ttl": {"value": 60}}}`},
+			expected:    HostnameOverrides{"api.example.com": {}},
+		},
+		{
+			name:        "invalid",
+			annotations: map[string]string{HostnameOverridesKey: `api.example.com: 60`},
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HostnameOverridesFromAnnotations(tt.annotations, "ingress/default/test"))
+		})
+	}
+}
+
+func TestHostnameOverridesApply(t *testing.T) {
+	annotations := map[string]string{TtlKey: "300", TargetKey: "lb.example.net"}
+	overrides := HostnameOverrides{"api.example.com": {TtlKey: "60"}}
+
+	assert.Equal(t, map[string]string{TtlKey: "60", TargetKey: "lb.example.net"}, overrides.Apply(annotations, "API.example.com."))
+	assert.Equal(t, annotations, overrides.Apply(annotations, "www.example.com"))
+	assert.Equal(t, "300", annotations[TtlKey], "the annotations must not be modified")
+	assert.Equal(t, map[string]string{TtlKey: "60"}, overrides.Apply(nil, "api.example.com"))
+
+	var none HostnameOverrides
+	assert.Equal(t, annotations, none.Apply(annotations, "api.example.com"))
+}
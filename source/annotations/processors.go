@@ -32,29 +32,34 @@ func hasAliasFromAnnotations(annotations map[string]string) bool {
 
 // TTLFromAnnotations extracts the TTL from the annotations of the given resource.
 func TTLFromAnnotations(annotations map[string]string, resource string) endpoint.TTL {
-	ttlNotConfigured := endpoint.TTL(0)
 	ttlAnnotation, ok := annotations[TtlKey]
 	if !ok {
-		return ttlNotConfigured
+		return endpoint.TTL(0)
 	}
-	ttlValue, err := parseTTL(ttlAnnotation)
+	return TTLFromValue(ttlAnnotation, resource)
+}
+
+// TTLFromValue parses the TTL of the given resource with ParseTTL, returning 0 if it is invalid or out of range.
+func TTLFromValue(value string, resource string) endpoint.TTL {
+	ttlNotConfigured := endpoint.TTL(0)
+	ttlValue, err := ParseTTL(value)
 	if err != nil {
-		log.Warnf("%s: %q is not a valid TTL value: %v", resource, ttlAnnotation, err)
+		log.Warnf("%s: %q is not a valid TTL value: %v", resource, value, err)
 		return ttlNotConfigured
 	}
-	if ttlValue < ttlMinimum || ttlValue > ttlMaximum {
-		log.Warnf("TTL value %q must be between [%d, %d]", ttlValue, ttlMinimum, ttlMaximum)
+	if ttlValue < TTLMinimum || ttlValue > TTLMaximum {
+		log.Warnf("TTL value %q must be between [%d, %d]", ttlValue, TTLMinimum, TTLMaximum)
 		return ttlNotConfigured
 	}
 	return endpoint.TTL(ttlValue)
 }
 
-// parseTTL parses TTL from string, returning duration in seconds.
-// parseTTL supports both integers like "600" and durations based
+// ParseTTL parses TTL from string, returning duration in seconds.
+// ParseTTL supports both integers like "600" and durations based
 // on Go Duration like "10m", hence "600" and "10m" represent the same value.
 //
 // Note: for durations like "1.5s" the fraction is omitted (resulting in 1 second for the example).
-func parseTTL(s string) (int64, error) {
+func ParseTTL(s string) (int64, error) {
 	ttlDuration, errDuration := time.ParseDuration(s)
 	if errDuration != nil {
 		ttlInt, err := strconv.ParseInt(s, 10, 64)
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ChangeTracker is implemented by sources that can report which objects changed
+// between two reconciliations, so the controller can skip or narrow its work.
+type ChangeTracker interface {
+	// ChangedKeys returns the keys of the objects that changed since the previous call and resets them.
+	// The boolean is false when the changes are unknown, e.g. on the first call, and a full
+	// reconciliation is required.
+	ChangedKeys() ([]string, bool)
+}
+
+// changedKeys returns the changes reported by src, or false if src does not track changes.
+func changedKeys(src Source) ([]string, bool) {
+	if tracker, ok := src.(ChangeTracker); ok {
+		return tracker.ChangedKeys()
+	}
+	return nil, false
+}
+
+// changeTracker collects the keys of objects reported by informer event handlers.
+// Handlers are only registered on the first call to ChangedKeys, so sources pay
+// nothing for tracking unless incremental reconciliation is enabled.
+type changeTracker struct {
+	register func(record func(key string))
+	once     sync.Once
+	mutex    sync.Mutex
+	keys     map[string]struct{}
+}
+
+func newChangeTracker(register func(record func(key string))) *changeTracker {
+	return &changeTracker{register: register, keys: map[string]struct{}{}}
+}
+
+// ChangedKeys implements ChangeTracker.
+func (t *changeTracker) ChangedKeys() ([]string, bool) {
+	started := true
+	t.once.Do(func() {
+		started = false
+		t.register(t.record)
+	})
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !started {
+		clear(t.keys)
+		return nil, false
+	}
+	keys := slices.Sorted(maps.Keys(t.keys))
+	clear(t.keys)
+	return keys, true
+}
+
+func (t *changeTracker) record(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.keys[key] = struct{}{}
+}
+
+// changeEventHandler returns an event handler reporting the key of every changed object of the given kind.
+// Objects delivered as part of the informer's initial list are ignored. If relevant is not nil, updates for
+// which it returns false are ignored as well.
+func changeEventHandler(kind string, record func(key string), relevant func(oldObj, newObj interface{}) bool) cache.ResourceEventHandler {
+	recordObject := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			log.Debugf("Failed to get key of changed %s: %v", kind, err)
+			return
+		}
+		record(kind + "/" + key)
+	}
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				recordObject(obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if relevant == nil || relevant(oldObj, newObj) {
+				recordObject(newObj)
+			}
+		},
+		DeleteFunc: recordObject,
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	networkv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestIngressSourceChangedKeys(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	existing := &networkv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "existing"}}
+	_, err := kubeClient.NetworkingV1().Ingresses("default").Create(t.Context(), existing, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	src, err := NewIngressSource(t.Context(), kubeClient, "", "", "", false, false, false, false, labels.Everything(), nil)
+	require.NoError(t, err)
+	tracker, ok := src.(ChangeTracker)
+	require.True(t, ok)
+
+	// the first call starts tracking, changes before it are unknown
+	keys, tracked := tracker.ChangedKeys()
+	assert.False(t, tracked)
+	assert.Empty(t, keys)
+
+	// objects of the initial list are not reported
+	keys, tracked = tracker.ChangedKeys()
+	assert.True(t, tracked)
+	assert.Empty(t, keys)
+
+	created := &networkv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "created"}}
+	_, err = kubeClient.NetworkingV1().Ingresses("default").Create(t.Context(), created, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, kubeClient.NetworkingV1().Ingresses("default").Delete(t.Context(), "existing", metav1.DeleteOptions{}))
+
+	var collected []string
+	require.Eventually(t, func() bool {
+		keys, _ := tracker.ChangedKeys()
+		collected = append(collected, keys...)
+		return len(collected) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []string{"ingress/default/created", "ingress/default/existing"}, collected)
+}
+
+func TestNodeSourceChangedKeysIgnoresHeartbeats(t *testing.T) {
+	kubeClient := fake.NewClientset()
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	node, err := kubeClient.CoreV1().Nodes().Create(t.Context(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	src, err := NewNodeSource(t.Context(), kubeClient, "", "", labels.Everything(), false, false, false)
+	require.NoError(t, err)
+	tracker := src.(ChangeTracker)
+	_, _ = tracker.ChangedKeys()
+
+	heartbeat := node.DeepCopy()
+	heartbeat.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, LastHeartbeatTime: metav1.Now()}}
+	_, err = kubeClient.CoreV1().Nodes().UpdateStatus(t.Context(), heartbeat, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	readdressed := heartbeat.DeepCopy()
+	readdressed.Status.Addresses = []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}}
+	_, err = kubeClient.CoreV1().Nodes().UpdateStatus(t.Context(), readdressed, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	var collected []string
+	require.Eventually(t, func() bool {
+		keys, _ := tracker.ChangedKeys()
+		collected = append(collected, keys...)
+		return len(collected) > 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"node/node-1"}, collected)
+}
+
+// staticTracker is a Source reporting preset changes.
+type staticTracker struct {
+	emptySource
+	keys    []string
+	tracked bool
+	calls   int
+}
+
+func (s *staticTracker) ChangedKeys() ([]string, bool) {
+	s.calls++
+	return s.keys, s.tracked
+}
+
+func TestWrappedSourcesChangedKeys(t *testing.T) {
+	first := &staticTracker{keys: []string{"ingress/default/a"}, tracked: true}
+	second := &staticTracker{keys: []string{"service/default/b"}, tracked: true}
+
+	src := NewTargetFilterSource(NewNAT64Source(NewDedupSource(NewMultiSource([]Source{first, second}, nil, false)), nil), endpoint.NewTargetNetFilterWithExclusions(nil, nil))
+	keys, tracked := src.(ChangeTracker).ChangedKeys()
+	assert.True(t, tracked)
+	assert.Equal(t, []string{"ingress/default/a", "service/default/b"}, keys)
+
+	second.tracked = false
+	keys, tracked = src.(ChangeTracker).ChangedKeys()
+	assert.False(t, tracked)
+	assert.Empty(t, keys)
+	assert.Equal(t, 2, first.calls, "all nested sources must be drained")
+
+	untracked := NewMultiSource([]Source{first, NewEmptySource()}, nil, false)
+	_, tracked = untracked.(ChangeTracker).ChangedKeys()
+	assert.False(t, tracked)
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+const (
+	// ClaimPolicyAllow lets namespaces publish hostnames not claimed by any policy.
+	ClaimPolicyAllow = "allow"
+	// ClaimPolicyDeny only lets namespaces publish the hostnames allowed by their policies.
+	ClaimPolicyDeny = "deny"
+)
+
+var rejectedEndpointsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "source",
+		Name:      "claim_policy_rejected_endpoints_total",
+		Help:      "Number of endpoints rejected by the hostname claim policies, partitioned by namespace (vector).",
+	},
+	[]string{"namespace"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(rejectedEndpointsTotal)
+}
+
+// ClaimPolicyConfig is the configuration file of the hostname claim policies.
+type ClaimPolicyConfig struct {
+	// DefaultAction applies to hostnames not claimed by any policy: "deny" (the default) or "allow".
+	DefaultAction string `json:"defaultAction,omitempty"`
+	// Policies map namespaces to the hostnames they may publish.
+	Policies []ClaimPolicyRule `json:"policies"`
+}
+
+// ClaimPolicyRule allows the matching namespaces to publish the matching hostnames.
+type ClaimPolicyRule struct {
+	Name string `json:"name,omitempty"`
+	// Namespaces lists the names of the namespaces the rule applies to.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces the rule applies to by their labels.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Domains lists the domains, including their subdomains, the namespaces may publish.
+	Domains []string `json:"domains,omitempty"`
+	// Regexes lists regular expressions matching the hostnames the namespaces may publish.
+	Regexes []string `json:"regexes,omitempty"`
+}
+
+// ClaimPolicy decides which namespaces may publish which hostnames.
+type ClaimPolicy struct {
+	defaultAction string
+	rules         []claimPolicyRule
+}
+
+type claimPolicyRule struct {
+	name       string
+	namespaces []string
+	selector   labels.Selector
+	domains    *endpoint.DomainFilter
+	regexes    []*regexp.Regexp
+}
+
+// LoadClaimPolicy reads the hostname claim policies from a YAML or JSON file.
+func LoadClaimPolicy(path string) (*ClaimPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claim policy file: %w", err)
+	}
+	var config ClaimPolicyConfig
+	if err := yaml.UnmarshalWithOptions(data, &config, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("failed to parse claim policy file %s: %w", path, err)
+	}
+	return NewClaimPolicy(config)
+}
+
+// NewClaimPolicy validates and compiles the given claim policy configuration.
+func NewClaimPolicy(config ClaimPolicyConfig) (*ClaimPolicy, error) {
+	policy := &ClaimPolicy{defaultAction: config.DefaultAction}
+	switch policy.defaultAction {
+	case "":
+		policy.defaultAction = ClaimPolicyDeny
+	case ClaimPolicyAllow, ClaimPolicyDeny:
+	default:
+		return nil, fmt.Errorf("invalid claim policy default action %q, must be %q or %q", config.DefaultAction, ClaimPolicyAllow, ClaimPolicyDeny)
+	}
+
+	for i, r := range config.Policies {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		if len(r.Namespaces) == 0 && r.NamespaceSelector == nil {
+			return nil, fmt.Errorf("claim policy %s: namespaces or namespaceSelector must be set", name)
+		}
+		rule := claimPolicyRule{
+			name:       name,
+			namespaces: r.Namespaces,
+			domains:    endpoint.NewDomainFilter(r.Domains),
+		}
+		if r.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(r.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("claim policy %s: invalid namespace selector: %w", name, err)
+			}
+			rule.selector = selector
+		}
+		for _, expr := range r.Regexes {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("claim policy %s: invalid regex %q: %w", name, expr, err)
+			}
+			rule.regexes = append(rule.regexes, re)
+		}
+		policy.rules = append(policy.rules, rule)
+	}
+	return policy, nil
+}
+
+// usesSelectors reports whether namespace labels are needed to evaluate the policy.
+func (p *ClaimPolicy) usesSelectors() bool {
+	return slices.ContainsFunc(p.rules, func(r claimPolicyRule) bool { return r.selector != nil })
+}
+
+// Allowed reports whether the namespace with the given labels may publish hostname.
+func (p *ClaimPolicy) Allowed(namespace string, namespaceLabels labels.Set, hostname string) bool {
+	claimed := false
+	for _, rule := range p.rules {
+		if !rule.matchesHostname(hostname) {
+			continue
+		}
+		if rule.matchesNamespace(namespace, namespaceLabels) {
+			return true
+		}
+		claimed = true
+	}
+	return !claimed && p.defaultAction == ClaimPolicyAllow
+}
+
+func (r *claimPolicyRule) matchesNamespace(namespace string, namespaceLabels labels.Set) bool {
+	if slices.Contains(r.namespaces, namespace) {
+		return true
+	}
+	return r.selector != nil && r.selector.Matches(namespaceLabels)
+}
+
+func (r *claimPolicyRule) matchesHostname(hostname string) bool {
+	if r.domains.IsConfigured() && r.domains.Match(hostname) {
+		return true
+	}
+	return slices.ContainsFunc(r.regexes, func(re *regexp.Regexp) bool { return re.MatchString(hostname) })
+}
+
+// claimPolicySource is a Source that removes the endpoints of namespaces not allowed to publish their hostname.
+type claimPolicySource struct {
+	source            Source
+	policy            *ClaimPolicy
+	namespaces        corelisters.NamespaceLister
+	namespaceInformer cache.SharedIndexInformer
+	namespaceTracker  *changeTracker
+	// rejected holds the status of the endpoints rejected by the last call to Endpoints,
+	// which ReportStatus adds to the statuses of the reconciliation
+	rejected []EndpointStatus
+}
+
+// NewClaimPolicySource creates a new claimPolicySource wrapping the provided Source.
+// Namespace labels are only watched if the policy uses namespace selectors.
+func NewClaimPolicySource(ctx context.Context, source Source, policy *ClaimPolicy, clients ClientGenerator) (Source, error) {
+	src := &claimPolicySource{source: source, policy: policy}
+	if !policy.usesSelectors() {
+		return src, nil
+	}
+
+	kubeClient, err := clients.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	informerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	nsInformer := informerFactory.Core().V1().Namespaces()
+	nsInformer.Informer() // Register with factory before starting.
+	informerFactory.Start(ctx.Done())
+	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+	src.namespaces = nsInformer.Lister()
+	src.namespaceInformer = nsInformer.Informer()
+	src.namespaceTracker = newChangeTracker(func(record func(string)) {
+		_, _ = src.namespaceInformer.AddEventHandler(changeEventHandler("namespace", record, namespaceLabelsChanged))
+	})
+	return src, nil
+}
+
+// Endpoints collects endpoints from its wrapped source and returns
+// them without the endpoints rejected by the claim policy.
+func (cs *claimPolicySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := cs.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	cs.rejected = nil
+	for _, ep := range endpoints {
+		if _, ok := ep.Labels[endpoint.ResourceLabelKey]; !ok {
+			// without a resource label the owner of an endpoint is unknown, so it may
+			// only publish hostnames no policy claims, and only if those are allowed
+			if !cs.policy.Allowed("", nil, ep.DNSName) {
+				log.WithField("endpoint", ep).Warnf("Skipping endpoint %q without resource label because the claim policy does not allow it", ep.DNSName)
+				rejectedEndpointsTotal.CounterVec.WithLabelValues("").Inc()
+				continue
+			}
+			result = append(result, ep)
+			continue
+		}
+		namespace, ok := resourceNamespace(ep)
+		if !ok {
+			// endpoints of cluster-scoped resources are not subject to the claim policy
+			result = append(result, ep)
+			continue
+		}
+		if !cs.policy.Allowed(namespace, cs.namespaceLabels(namespace), ep.DNSName) {
+			log.WithField("endpoint", ep).Warnf("Skipping endpoint because namespace %q is not allowed to publish %q", namespace, ep.DNSName)
+			rejectedEndpointsTotal.CounterVec.WithLabelValues(namespace).Inc()
+			cs.rejected = append(cs.rejected, EndpointStatus{
+				Endpoint: ep,
+				Reason:   StatusReasonRejected,
+				Message:  fmt.Sprintf("namespace %s is not allowed to publish %s by the claim policy", namespace, ep.DNSName),
+			})
+			continue
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+func (cs *claimPolicySource) namespaceLabels(namespace string) labels.Set {
+	if cs.namespaces == nil {
+		return nil
+	}
+	ns, err := cs.namespaces.Get(namespace)
+	if err != nil {
+		log.Debugf("Failed to get namespace %q: %v", namespace, err)
+		return nil
+	}
+	return ns.Labels
+}
+
+// resourceNamespace returns the namespace of the resource an endpoint was generated from,
+// based on its "kind/namespace/name" resource label.
+func resourceNamespace(ep *endpoint.Endpoint) (string, bool) {
+	parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/")
+	if len(parts) != 3 || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// AddEventHandler registers the handler with the wrapped source and, if the policy uses
+// namespace selectors, on namespace label changes, which can change the allowed endpoints.
+func (cs *claimPolicySource) AddEventHandler(ctx context.Context, handler func()) {
+	cs.source.AddEventHandler(ctx, handler)
+	if cs.namespaceInformer == nil {
+		return
+	}
+	log.Debug("Adding event handler for namespaces of the claim policy")
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	_, _ = cs.namespaceInformer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				handler()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if namespaceLabelsChanged(oldObj, newObj) {
+				handler()
+			}
+		},
+		DeleteFunc: func(obj interface{}) { handler() },
+	})
+}
+
+// namespaceLabelsChanged reports whether a namespace update changed its labels.
+func namespaceLabelsChanged(oldObj, newObj interface{}) bool {
+	oldNs, ok := oldObj.(*v1.Namespace)
+	if !ok {
+		return true
+	}
+	newNs, ok := newObj.(*v1.Namespace)
+	if !ok {
+		return true
+	}
+	return !maps.Equal(oldNs.Labels, newNs.Labels)
+}
+
+// ChangedKeys implements ChangeTracker by delegating to the wrapped source, adding the namespaces
+// whose labels changed, as they can change the endpoints allowed by the policy.
+func (cs *claimPolicySource) ChangedKeys() ([]string, bool) {
+	keys, tracked := changedKeys(cs.source)
+	if cs.namespaceTracker == nil {
+		return keys, tracked
+	}
+	// always consume the namespace changes, even if the wrapped source does not track its changes
+	namespaceKeys, namespacesTracked := cs.namespaceTracker.ChangedKeys()
+	if !tracked || !namespacesTracked {
+		return nil, false
+	}
+	return append(keys, namespaceKeys...), true
+}
+
+// ReportStatus implements StatusReporter by delegating to the wrapped source,
+// with the status of the endpoints rejected by the claim policy.
+func (cs *claimPolicySource) ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus) {
+	if len(cs.rejected) > 0 {
+		statuses = maps.Clone(statuses)
+		for _, status := range cs.rejected {
+			resource := status.Endpoint.Labels[endpoint.ResourceLabelKey]
+			statuses[resource] = append(slices.Clip(statuses[resource]), status)
+		}
+	}
+	reportStatus(ctx, cs.source, statuses)
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestNewClaimPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config ClaimPolicyConfig
+		err    string
+	}{
+		{
+			name:   "empty",
+			config: ClaimPolicyConfig{},
+		},
+		{
+			name:   "invalid default action",
+			config: ClaimPolicyConfig{DefaultAction: "reject"},
+			err:    `invalid claim policy default action "reject"`,
+		},
+		{
+			name:   "missing namespaces",
+			config: ClaimPolicyConfig{Policies: []ClaimPolicyRule{{Name: "payments", Domains: []string{"payments.example.com"}}}},
+			err:    "claim policy payments: namespaces or namespaceSelector must be set",
+		},
+		{
+			name:   "invalid regex",
+			config: ClaimPolicyConfig{Policies: []ClaimPolicyRule{{Namespaces: []string{"a"}, Regexes: []string{"("}}}},
+			err:    `claim policy #0: invalid regex "("`,
+		},
+		{
+			name: "invalid selector",
+			config: ClaimPolicyConfig{Policies: []ClaimPolicyRule{{NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+			}}}},
+			err: "claim policy #0: invalid namespace selector",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClaimPolicy(tt.config)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestClaimPolicyAllowed(t *testing.T) {
+	rules := []ClaimPolicyRule{
+		{
+			Name:       "payments",
+			Namespaces: []string{"payments"},
+			Domains:    []string{"payments.example.com"},
+		},
+		{
+			Name:              "web",
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "web"}},
+			Domains:           []string{"www.example.com"},
+			Regexes:           []string{`^web-[a-z]+\.example\.com$`},
+		},
+	}
+
+	for _, tt := range []struct {
+		defaultAction string
+		namespace     string
+		labels        labels.Set
+		hostname      string
+		allowed       bool
+	}{
+		{ClaimPolicyDeny, "payments", nil, "payments.example.com", true},
+		{ClaimPolicyDeny, "payments", nil, "api.payments.example.com", true},
+		{ClaimPolicyDeny, "team-a", nil, "payments.example.com", false},
+		{ClaimPolicyDeny, "team-a", labels.Set{"team": "web"}, "www.example.com", true},
+		{ClaimPolicyDeny, "team-a", labels.Set{"team": "web"}, "web-shop.example.com", true},
+		{ClaimPolicyDeny, "team-a", labels.Set{"team": "web"}, "payments.example.com", false},
+		{ClaimPolicyDeny, "team-a", nil, "unclaimed.example.com", false},
+		{ClaimPolicyAllow, "team-a", nil, "unclaimed.example.com", true},
+		{ClaimPolicyAllow, "team-a", nil, "api.payments.example.com", false},
+		{ClaimPolicyAllow, "payments", nil, "web-shop.example.com", false},
+	} {
+		t.Run(tt.defaultAction+"/"+tt.namespace+"/"+tt.hostname, func(t *testing.T) {
+			policy, err := NewClaimPolicy(ClaimPolicyConfig{DefaultAction: tt.defaultAction, Policies: rules})
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, policy.Allowed(tt.namespace, tt.labels, tt.hostname))
+		})
+	}
+}
+
+func TestLoadClaimPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claims.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+defaultAction: allow
+policies:
+  - name: payments
+    namespaceSelector:
+      matchLabels:
+        team: payments
+    domains:
+      - payments.example.com
+`), 0o600))
+
+	policy, err := LoadClaimPolicy(path)
+	require.NoError(t, err)
+	assert.True(t, policy.usesSelectors())
+	assert.True(t, policy.Allowed("checkout", labels.Set{"team": "payments"}, "payments.example.com"))
+	assert.False(t, policy.Allowed("checkout", nil, "payments.example.com"))
+
+	require.NoError(t, os.WriteFile(path, []byte("policies:\n  - namespace: payments\n"), 0o600))
+	_, err = LoadClaimPolicy(path)
+	require.ErrorContains(t, err, "failed to parse claim policy file")
+
+	_, err = LoadClaimPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestClaimPolicySource(t *testing.T) {
+	kubeClient := fake.NewClientset(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "payments"}},
+	})
+	clients := new(MockClientGenerator)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	policy, err := NewClaimPolicy(ClaimPolicyConfig{Policies: []ClaimPolicyRule{{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		Domains:           []string{"payments.example.com"},
+	}}})
+	require.NoError(t, err)
+
+	withResource := func(ep *endpoint.Endpoint, resource string) *endpoint.Endpoint {
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+		return ep
+	}
+	allowed := withResource(endpoint.NewEndpoint("payments.example.com", endpoint.RecordTypeA, "1.2.3.4"), "ingress/shop/checkout")
+	hijacked := withResource(endpoint.NewEndpoint("payments.example.com", endpoint.RecordTypeA, "5.6.7.8"), "ingress/team-a/payments")
+	clusterScoped := withResource(endpoint.NewEndpoint("node1.example.com", endpoint.RecordTypeA, "10.0.0.1"), "node/node1")
+	unlabelled := endpoint.NewEndpoint("static.example.com", endpoint.RecordTypeA, "10.0.0.2")
+
+	src, err := NewClaimPolicySource(context.Background(), NewEchoSource([]*endpoint.Endpoint{allowed, hijacked, clusterScoped, unlabelled}), policy, clients)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{allowed, clusterScoped}, endpoints)
+	clients.AssertExpectations(t)
+
+	allowPolicy, err := NewClaimPolicy(ClaimPolicyConfig{
+		DefaultAction: ClaimPolicyAllow,
+		Policies:      []ClaimPolicyRule{{Namespaces: []string{"shop"}, Domains: []string{"payments.example.com"}}},
+	})
+	require.NoError(t, err)
+	unlabelledClaimed := endpoint.NewEndpoint("payments.example.com", endpoint.RecordTypeA, "10.0.0.3")
+	src, err = NewClaimPolicySource(context.Background(), NewEchoSource([]*endpoint.Endpoint{unlabelled, unlabelledClaimed}), allowPolicy, clients)
+	require.NoError(t, err)
+
+	endpoints, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{unlabelled}, endpoints)
+}
+
+func TestClaimPolicySourceAddEventHandler(t *testing.T) {
+	kubeClient := fake.NewClientset(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "payments"}},
+	})
+	clients := new(MockClientGenerator)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	policy, err := NewClaimPolicy(ClaimPolicyConfig{Policies: []ClaimPolicyRule{{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		Domains:           []string{"payments.example.com"},
+	}}})
+	require.NoError(t, err)
+
+	src, err := NewClaimPolicySource(t.Context(), NewEchoSource(nil), policy, clients)
+	require.NoError(t, err)
+
+	var counter atomic.Int32
+	src.AddEventHandler(t.Context(), func() {
+		counter.Add(1)
+	})
+
+	ns, err := kubeClient.CoreV1().Namespaces().Get(t.Context(), "shop", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// annotation changes do not change the policy decisions
+	annotated := ns.DeepCopy()
+	annotated.Annotations = map[string]string{"owner": "payments"}
+	_, err = kubeClient.CoreV1().Namespaces().Update(t.Context(), annotated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	relabelled := annotated.DeepCopy()
+	relabelled.Labels = map[string]string{"team": "web"}
+	_, err = kubeClient.CoreV1().Namespaces().Update(t.Context(), relabelled, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool {
+		return counter.Load() > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestClaimPolicySourceChangedKeys(t *testing.T) {
+	kubeClient := fake.NewClientset(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "payments"}},
+	})
+	clients := new(MockClientGenerator)
+	clients.On("KubeClient").Return(kubeClient, nil)
+
+	policy, err := NewClaimPolicy(ClaimPolicyConfig{Policies: []ClaimPolicyRule{{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+		Domains:           []string{"payments.example.com"},
+	}}})
+	require.NoError(t, err)
+
+	wrapped := &staticTracker{tracked: true}
+	src, err := NewClaimPolicySource(t.Context(), wrapped, policy, clients)
+	require.NoError(t, err)
+	tracker := src.(ChangeTracker)
+
+	// namespace changes are unknown until the first call
+	_, tracked := tracker.ChangedKeys()
+	assert.False(t, tracked)
+
+	ns, err := kubeClient.CoreV1().Namespaces().Get(t.Context(), "shop", metav1.GetOptions{})
+	require.NoError(t, err)
+	ns.Labels = map[string]string{"team": "web"}
+	_, err = kubeClient.CoreV1().Namespaces().Update(t.Context(), ns, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	wrapped.keys = []string{"ingress/shop/checkout"}
+	require.Eventually(t, func() bool {
+		keys, tracked := tracker.ChangedKeys()
+		return tracked && slices.Contains(keys, "namespace/shop")
+	}, time.Second, 10*time.Millisecond)
+
+	wrapped.tracked = false
+	_, tracked = tracker.ChangedKeys()
+	assert.False(t, tracked)
+}
+
+func TestClaimPolicySourceReportStatus(t *testing.T) {
+	policy, err := NewClaimPolicy(ClaimPolicyConfig{Policies: []ClaimPolicyRule{{
+		Namespaces: []string{"shop"},
+		Domains:    []string{"payments.example.com"},
+	}}})
+	require.NoError(t, err)
+
+	allowed := endpoint.NewEndpoint("payments.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "httproute/shop/checkout")
+	hijacked := endpoint.NewEndpoint("payments.example.com", endpoint.RecordTypeA, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "httproute/team-a/payments")
+	recorder := &statusRecorder{}
+	src, err := NewClaimPolicySource(context.Background(), &echoStatusRecorder{statusRecorder: recorder, endpoints: []*endpoint.Endpoint{allowed, hijacked}}, policy, new(MockClientGenerator))
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{allowed}, endpoints)
+
+	statuses := map[string][]EndpointStatus{
+		"httproute/shop/checkout": {{Endpoint: allowed, Reason: StatusReasonProgrammed}},
+	}
+	src.(StatusReporter).ReportStatus(context.Background(), statuses)
+	assert.Len(t, statuses, 1, "the statuses of the controller are not modified")
+	assert.Equal(t, statuses["httproute/shop/checkout"], recorder.statuses["httproute/shop/checkout"])
+	assert.Equal(t, []EndpointStatus{{
+		Endpoint: hijacked,
+		Reason:   StatusReasonRejected,
+		Message:  "namespace team-a is not allowed to publish payments.example.com by the claim policy",
+	}}, recorder.statuses["httproute/team-a/payments"])
+}
+
+// echoStatusRecorder returns fixed endpoints and records the reported statuses.
+type echoStatusRecorder struct {
+	*statusRecorder
+	endpoints []*endpoint.Endpoint
+}
+
+func (s *echoStatusRecorder) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return s.endpoints, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	apiv1beta1 "sigs.k8s.io/external-dns/apis/v1beta1"
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
@@ -51,15 +53,45 @@ type crdSource struct {
 	codec            runtime.ParameterCodec
 	annotationFilter string
 	labelSelector    labels.Selector
+	zoneDelegation   bool
+	v1beta1          bool
 	informer         *cache.SharedInformer
+	// delegationInformer watches the DNSZoneDelegations, only with zoneDelegation
+	delegationInformer cache.SharedInformer
+	tracker            *changeTracker
+}
+
+// ChangedKeys implements ChangeTracker. Changes can only be tracked when the informer is running.
+func (cs *crdSource) ChangedKeys() ([]string, bool) {
+	if cs.tracker == nil {
+		return nil, false
+	}
+	return cs.tracker.ChangedKeys()
 }
 
 func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) error {
-	scheme.AddKnownTypes(groupVersion,
-		&apiv1alpha1.DNSEndpoint{},
-		&apiv1alpha1.DNSEndpointList{},
-	)
+	if groupVersion.Version == apiv1beta1.GroupVersion.Version {
+		scheme.AddKnownTypes(groupVersion,
+			&apiv1beta1.DNSEndpoint{},
+			&apiv1beta1.DNSEndpointList{},
+		)
+	} else {
+		scheme.AddKnownTypes(groupVersion,
+			&apiv1alpha1.DNSEndpoint{},
+			&apiv1alpha1.DNSEndpointList{},
+		)
+	}
 	metav1.AddToGroupVersion(scheme, groupVersion)
+
+	// DNSZoneDelegations are only served in v1alpha1
+	delegationVersion := schema.GroupVersion{Group: groupVersion.Group, Version: apiv1alpha1.GroupVersion.Version}
+	scheme.AddKnownTypes(delegationVersion,
+		&apiv1alpha1.DNSZoneDelegation{},
+		&apiv1alpha1.DNSZoneDelegationList{},
+	)
+	if delegationVersion != groupVersion {
+		metav1.AddToGroupVersion(scheme, delegationVersion)
+	}
 	return nil
 }
 
@@ -111,30 +143,61 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 }
 
 // NewCRDSource creates a new crdSource with the given config.
-func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool) (Source, error) {
+// The DNSEndpoints are read in the version of crdClient, either v1alpha1 or v1beta1.
+// With zoneDelegation, the endpoints are only published if they fall within a DNSZoneDelegation of their namespace.
+func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, zoneDelegation bool, scheme *runtime.Scheme, startInformer bool) (Source, error) {
 	sourceCrd := crdSource{
 		crdResource:      strings.ToLower(kind) + "s",
 		namespace:        namespace,
 		annotationFilter: annotationFilter,
 		labelSelector:    labelSelector,
+		zoneDelegation:   zoneDelegation,
+		v1beta1:          crdClient.APIVersion().Version == apiv1beta1.GroupVersion.Version,
 		crdClient:        crdClient,
 		codec:            runtime.NewParameterCodec(scheme),
 	}
 	if startInformer {
 		// external-dns already runs its sync-handler periodically (controlled by `--interval` flag) to ensure any
 		// missed or dropped events are handled. specify resync period 0 to avoid unnecessary sync handler invocations.
+		var objType runtime.Object = &apiv1alpha1.DNSEndpoint{}
+		if sourceCrd.v1beta1 {
+			objType = &apiv1beta1.DNSEndpoint{}
+		}
 		informer := cache.NewSharedInformer(
 			&cache.ListWatch{
 				ListWithContextFunc: func(ctx context.Context, lo metav1.ListOptions) (result runtime.Object, err error) {
+					if sourceCrd.v1beta1 {
+						return sourceCrd.listV1beta1(ctx, &lo)
+					}
 					return sourceCrd.List(ctx, &lo)
 				},
 				WatchFuncWithContext: func(ctx context.Context, lo metav1.ListOptions) (watch.Interface, error) {
 					return sourceCrd.watch(ctx, &lo)
 				},
 			},
-			&apiv1alpha1.DNSEndpoint{},
+			objType,
 			0)
 		sourceCrd.informer = &informer
+		if zoneDelegation {
+			sourceCrd.delegationInformer = cache.NewSharedInformer(
+				&cache.ListWatch{
+					ListWithContextFunc: func(ctx context.Context, lo metav1.ListOptions) (runtime.Object, error) {
+						return sourceCrd.listZoneDelegations(ctx)
+					},
+					WatchFuncWithContext: func(ctx context.Context, lo metav1.ListOptions) (watch.Interface, error) {
+						return sourceCrd.watchZoneDelegations(ctx, &lo)
+					},
+				},
+				&apiv1alpha1.DNSZoneDelegation{},
+				0)
+			go sourceCrd.delegationInformer.Run(wait.NeverStop)
+		}
+		sourceCrd.tracker = newChangeTracker(func(record func(string)) {
+			_, _ = informer.AddEventHandler(changeEventHandler("dnsendpoint", record, nil))
+			if sourceCrd.delegationInformer != nil {
+				_, _ = sourceCrd.delegationInformer.AddEventHandler(changeEventHandler("dnszonedelegation", record, nil))
+			}
+		})
 		go informer.Run(wait.NeverStop)
 	}
 	return &sourceCrd, nil
@@ -160,6 +223,11 @@ func (cs *crdSource) AddEventHandler(_ context.Context, handler func()) {
 			},
 		)
 	}
+	if cs.delegationInformer != nil {
+		log.Debug("Adding event handler for DNSZoneDelegations")
+		// delegation changes can admit or reject DNSEndpoints
+		_, _ = cs.delegationInformer.AddEventHandler(eventHandlerFunc(handler))
+	}
 }
 
 // Endpoints returns endpoint objects.
@@ -181,6 +249,20 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		return nil, err
 	}
 
+	var delegations *zoneDelegations
+	if cs.zoneDelegation {
+		delegationList, err := cs.listZoneDelegations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		delegations = newZoneDelegations(delegationList.Items)
+		// the oldest DNSEndpoints are admitted first, so that new DNSEndpoints exceeding
+		// the quotas do not take over the records of existing ones
+		slices.SortStableFunc(result.Items, func(a, b apiv1alpha1.DNSEndpoint) int {
+			return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+		})
+	}
+
 	for _, dnsEndpoint := range result.Items {
 		var crdEndpoints []*endpoint.Endpoint
 		for _, ep := range dnsEndpoint.Spec.Endpoints {
@@ -209,6 +291,14 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 			crdEndpoints = append(crdEndpoints, ep)
 		}
 
+		if delegations != nil {
+			if err := delegations.admit(dnsEndpoint.Namespace, crdEndpoints); err != nil {
+				// the generation of a rejected DNSEndpoint is not observed, as its endpoints are not published
+				log.Warnf("Skipping DNSEndpoint %s/%s: %v", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+				continue
+			}
+		}
+
 		endpoints = append(endpoints, crdEndpoints...)
 
 		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
@@ -235,8 +325,34 @@ func (cs *crdSource) watch(ctx context.Context, opts *metav1.ListOptions) (watch
 		Watch(ctx)
 }
 
+// List lists the DNSEndpoints, converted to v1alpha1 if they are read in v1beta1.
 func (cs *crdSource) List(ctx context.Context, opts *metav1.ListOptions) (result *apiv1alpha1.DNSEndpointList, err error) {
 	result = &apiv1alpha1.DNSEndpointList{}
+	if cs.v1beta1 {
+		list, err := cs.listV1beta1(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.ListMeta = list.ListMeta
+		result.Items = make([]apiv1alpha1.DNSEndpoint, len(list.Items))
+		for i := range list.Items {
+			if err := list.Items[i].ConvertTo(&result.Items[i]); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+	err = cs.crdClient.Get().
+		Namespace(cs.namespace).
+		Resource(cs.crdResource).
+		VersionedParams(opts, cs.codec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (cs *crdSource) listV1beta1(ctx context.Context, opts *metav1.ListOptions) (result *apiv1beta1.DNSEndpointList, err error) {
+	result = &apiv1beta1.DNSEndpointList{}
 	err = cs.crdClient.Get().
 		Namespace(cs.namespace).
 		Resource(cs.crdResource).
@@ -246,7 +362,27 @@ func (cs *crdSource) List(ctx context.Context, opts *metav1.ListOptions) (result
 	return
 }
 
+func (cs *crdSource) watchZoneDelegations(ctx context.Context, opts *metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return cs.crdClient.Get().
+		AbsPath("/apis", cs.crdClient.APIVersion().Group, apiv1alpha1.GroupVersion.Version, "dnszonedelegations").
+		VersionedParams(opts, cs.codec).
+		Watch(ctx)
+}
+
+func (cs *crdSource) listZoneDelegations(ctx context.Context) (result *apiv1alpha1.DNSZoneDelegationList, err error) {
+	result = &apiv1alpha1.DNSZoneDelegationList{}
+	err = cs.crdClient.Get().
+		AbsPath("/apis", cs.crdClient.APIVersion().Group, apiv1alpha1.GroupVersion.Version, "dnszonedelegations").
+		Do(ctx).
+		Into(result)
+	return
+}
+
 func (cs *crdSource) UpdateStatus(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (result *apiv1alpha1.DNSEndpoint, err error) {
+	if cs.v1beta1 {
+		return cs.updateStatusV1beta1(ctx, dnsEndpoint)
+	}
 	result = &apiv1alpha1.DNSEndpoint{}
 	err = cs.crdClient.Put().
 		Namespace(dnsEndpoint.Namespace).
@@ -259,6 +395,27 @@ func (cs *crdSource) UpdateStatus(ctx context.Context, dnsEndpoint *apiv1alpha1.
 	return
 }
 
+func (cs *crdSource) updateStatusV1beta1(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (*apiv1alpha1.DNSEndpoint, error) {
+	body := &apiv1beta1.DNSEndpoint{}
+	if err := body.ConvertFrom(dnsEndpoint); err != nil {
+		return nil, err
+	}
+	updated := &apiv1beta1.DNSEndpoint{}
+	err := cs.crdClient.Put().
+		Namespace(dnsEndpoint.Namespace).
+		Resource(cs.crdResource).
+		Name(dnsEndpoint.Name).
+		SubResource("status").
+		Body(body).
+		Do(ctx).
+		Into(updated)
+	if err != nil {
+		return nil, err
+	}
+	result := &apiv1alpha1.DNSEndpoint{}
+	return result, updated.ConvertTo(result)
+}
+
 // filterByAnnotations filters a list of dnsendpoints by a given annotation selector.
 func (cs *crdSource) filterByAnnotations(dnsendpoints *apiv1alpha1.DNSEndpointList) (*apiv1alpha1.DNSEndpointList, error) {
 	selector, err := annotations.ParseFilter(cs.annotationFilter)
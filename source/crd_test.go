@@ -42,6 +42,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	apiv1beta1 "sigs.k8s.io/external-dns/apis/v1beta1"
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
@@ -494,7 +495,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			// At present, client-go's fake.RESTClient (used by crd_test.go) is known to cause race conditions when used
 			// with informers: https://github.com/kubernetes/kubernetes/issues/95372
 			// So don't start the informer during testing.
-			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false)
+			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, false, scheme, false)
 			require.NoError(t, err)
 
 			receivedEndpoints, err := cs.Endpoints(t.Context())
@@ -551,6 +552,41 @@ func TestCRDSource_AddEventHandler_Add(t *testing.T) {
 	}, time.Second, 10*time.Millisecond)
 }
 
+func TestCRDSource_AddEventHandler_ZoneDelegation(t *testing.T) {
+	ctx := t.Context()
+	watcher, cs := helperCreateWatcherWithInformer(t)
+
+	delegationWatcher := cachetesting.NewFakeControllerSource()
+	cs.delegationInformer = cache.NewSharedInformer(delegationWatcher, &unstructured.Unstructured{}, 0)
+	go cs.delegationInformer.RunWithContext(ctx)
+	require.Eventually(t, func() bool {
+		return cache.WaitForCacheSync(ctx.Done(), cs.delegationInformer.HasSynced)
+	}, time.Second, 10*time.Millisecond)
+
+	var counter atomic.Int32
+	cs.AddEventHandler(ctx, func() {
+		counter.Add(1)
+	})
+
+	obj := &unstructured.Unstructured{}
+	obj.SetName("team-a")
+
+	delegationWatcher.Add(obj)
+
+	require.Eventually(t, func() bool {
+		return counter.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	obj = &unstructured.Unstructured{}
+	obj.SetName("test")
+
+	watcher.Add(obj)
+
+	require.Eventually(t, func() bool {
+		return counter.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
 func TestCRDSource_AddEventHandler_Update(t *testing.T) {
 	ctx := t.Context()
 	watcher, cs := helperCreateWatcherWithInformer(t)
@@ -721,6 +757,67 @@ func TestDNSEndpointsWithSetResourceLabels(t *testing.T) {
 	}
 }
 
+func TestCRDSourceV1beta1(t *testing.T) {
+	groupVersion := apiv1beta1.GroupVersion
+	scheme := runtime.NewScheme()
+	require.NoError(t, addKnownTypes(scheme, groupVersion))
+	codecFactory := serializer.WithoutConversionCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
+	codec := codecFactory.LegacyCodec(groupVersion)
+
+	weight := int64(10)
+	dnsEndpoint := &apiv1beta1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1},
+		Spec: apiv1beta1.DNSEndpointSpec{Endpoints: []apiv1beta1.Endpoint{{
+			DNSName:       "www.example.com",
+			RecordType:    endpoint.RecordTypeA,
+			Targets:       []string{"1.2.3.4"},
+			TTL:           &metav1.Duration{Duration: time.Minute},
+			SetIdentifier: "blue",
+			RoutingPolicy: &apiv1beta1.RoutingPolicy{Weight: &weight},
+		}}},
+	}
+
+	var statusUpdated atomic.Bool
+	client := &fake.RESTClient{
+		GroupVersion:         groupVersion,
+		VersionedAPIPath:     "/apis/" + groupVersion.String(),
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/apis/"+groupVersion.String()+"/dnsendpoints" && m == http.MethodGet:
+				list := &apiv1beta1.DNSEndpointList{Items: []apiv1beta1.DNSEndpoint{*dnsEndpoint}}
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+			case p == "/apis/"+groupVersion.String()+"/namespaces/default/dnsendpoints/test/status" && m == http.MethodPut:
+				var body apiv1beta1.DNSEndpoint
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				if body.APIVersion != groupVersion.String() || body.Status.ObservedGeneration != 1 {
+					return nil, fmt.Errorf("unexpected status update: %#v", body)
+				}
+				statusUpdated.Store(true)
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, &body)}, nil
+			default:
+				return nil, fmt.Errorf("unexpected request: %#v", req.URL)
+			}
+		}),
+	}
+
+	cs, err := NewCRDSource(client, "", "DNSEndpoint", "", labels.Everything(), false, scheme, false)
+	require.NoError(t, err)
+
+	endpoints, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	ep := endpoints[0]
+	require.Equal(t, "www.example.com", ep.DNSName)
+	require.Equal(t, endpoint.TTL(60), ep.RecordTTL)
+	require.Equal(t, "blue", ep.SetIdentifier)
+	require.Equal(t, endpoint.ProviderSpecific{{Name: "aws/weight", Value: "10"}}, ep.ProviderSpecific)
+	require.Equal(t, "crd/default/test", ep.Labels[endpoint.ResourceLabelKey])
+	require.True(t, statusUpdated.Load())
+}
+
 func helperCreateWatcherWithInformer(t *testing.T) (*cachetesting.FakeControllerSource, crdSource) {
 	t.Helper()
 	ctx := t.Context()
@@ -67,3 +67,13 @@ func (ms *dedupSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 func (ms *dedupSource) AddEventHandler(ctx context.Context, handler func()) {
 	ms.source.AddEventHandler(ctx, handler)
 }
+
+// ChangedKeys implements ChangeTracker by delegating to the wrapped source.
+func (ms *dedupSource) ChangedKeys() ([]string, bool) {
+	return changedKeys(ms.source)
+}
+
+// ReportStatus implements StatusReporter by delegating to the wrapped source.
+func (ms *dedupSource) ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus) {
+	reportStatus(ctx, ms.source, statuses)
+}
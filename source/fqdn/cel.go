@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fqdn
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// celObjectVariable is the name of the variable holding the object in CEL expressions.
+const celObjectVariable = "object"
+
+// celCostLimit bounds the evaluation cost of a CEL expression, to guard against runaway expressions.
+const celCostLimit = 1000000
+
+// compileCEL compiles an expression evaluating to a hostname, a list of hostnames,
+// or a map of hostnames to the targets they may point to.
+func compileCEL(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(celObjectVariable, cel.MapType(cel.StringType, cel.DynType)),
+		ext.Strings(),
+		cel.Function("isIPv4",
+			cel.Overload("isIPv4_string", []*cel.Type{cel.StringType}, cel.BoolType,
+				cel.UnaryBinding(func(v ref.Val) ref.Val { return types.Bool(isIPv4String(string(v.(types.String)))) }))),
+		cel.Function("isIPv6",
+			cel.Overload("isIPv6_string", []*cel.Type{cel.StringType}, cel.BoolType,
+				cel.UnaryBinding(func(v ref.Val) ref.Val { return types.Bool(isIPv6String(string(v.(types.String)))) }))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expr)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("invalid CEL expression %q: %w", expr, issues.Err())
+	}
+	switch ast.OutputType().Kind() {
+	case types.StringKind, types.ListKind, types.MapKind, types.DynKind:
+	default:
+		return nil, fmt.Errorf("invalid CEL expression %q: must evaluate to a hostname, a list of hostnames or a map of hostnames to targets, not %s", expr, ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(celCostLimit))
+}
+
+func evalCEL(program cel.Program, data any) (*Result, error) {
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(data)
+	if err != nil {
+		return nil, err
+	}
+	val, _, err := program.Eval(map[string]any{celObjectVariable: object})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	switch v := val.(type) {
+	case types.String:
+		result.Hostnames = []string{string(v)}
+	case traits.Mapper:
+		native, err := v.ConvertToNative(reflect.TypeOf(map[string][]string{}))
+		if err != nil {
+			return nil, fmt.Errorf("expression must evaluate to a map of hostnames to lists of targets: %w", err)
+		}
+		result.targets = map[string][]string{}
+		for hostname, targets := range native.(map[string][]string) {
+			if hostname = normalizeHostname(hostname); hostname != "" {
+				result.Hostnames = append(result.Hostnames, hostname)
+				result.targets[hostname] = append(result.targets[hostname], targets...)
+			}
+		}
+		sort.Strings(result.Hostnames)
+		return result, nil
+	case traits.Lister:
+		native, err := v.ConvertToNative(reflect.TypeOf([]string{}))
+		if err != nil {
+			return nil, fmt.Errorf("expression must evaluate to a list of hostnames: %w", err)
+		}
+		result.Hostnames = native.([]string)
+	default:
+		return nil, fmt.Errorf("expression must evaluate to a hostname, a list of hostnames or a map of hostnames to targets, not %s", val.Type())
+	}
+
+	hostnames := result.Hostnames[:0]
+	for _, hostname := range result.Hostnames {
+		if hostname = normalizeHostname(hostname); hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	result.Hostnames = hostnames
+	return result, nil
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.TrimSpace(hostname), ".")
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fqdn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func testService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Labels:      map[string]string{"team": "Payments"},
+			Annotations: map[string]string{"example.com/public": "true"},
+		},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{
+			{IP: "10.0.0.1"},
+			{IP: "203.0.113.1"},
+			{IP: "2001:db8::1"},
+		}}},
+	}
+}
+
+func TestParseCELTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		expr        string
+		expectError string
+	}{
+		{
+			name: "string",
+			expr: `object.metadata.name + ".example.com"`,
+		},
+		{
+			name: "list",
+			expr: `[object.metadata.name + ".example.com", "www.example.com"]`,
+		},
+		{
+			name: "map",
+			expr: `{"www.example.com": ["1.2.3.4"]}`,
+		},
+		{
+			name: "dyn",
+			expr: `object.metadata.labels`,
+		},
+		{
+			name:        "syntax error",
+			expr:        `object.metadata.name +`,
+			expectError: "invalid CEL expression",
+		},
+		{
+			name:        "undeclared reference",
+			expr:        `service.metadata.name`,
+			expectError: "undeclared reference",
+		},
+		{
+			name:        "invalid output type",
+			expr:        `object.metadata.name == "web"`,
+			expectError: "must evaluate to a hostname",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(CELPrefix + tt.expr)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				assert.Nil(t, tmpl)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, tmpl)
+			}
+		})
+	}
+}
+
+func TestExecCELTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		expr    string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "string",
+			expr: `object.metadata.name + "." + object.metadata.namespace + ".example.com."`,
+			want: []string{"web.default.example.com"},
+		},
+		{
+			name: "branch on labels and annotations",
+			expr: `object.metadata.annotations["example.com/public"] == "true" ? [object.metadata.labels.team.lowerAscii() + ".example.com"] : []`,
+			want: []string{"payments.example.com"},
+		},
+		{
+			name: "empty hostnames are skipped",
+			expr: `["", " a.example.com "]`,
+			want: []string{"a.example.com"},
+		},
+		{
+			name: "map keys are sorted",
+			expr: `{"b.example.com": [], "a.example.com": []}`,
+			want: []string{"a.example.com", "b.example.com"},
+		},
+		{
+			name:    "missing field",
+			expr:    `object.metadata.labels.missing + ".example.com"`,
+			wantErr: "no such key",
+		},
+		{
+			name:    "invalid result",
+			expr:    `dyn(object.metadata.name.size())`,
+			wantErr: "must evaluate to a hostname",
+		},
+		{
+			name:    "invalid list element",
+			expr:    `[object.metadata.name.size()]`,
+			wantErr: "must evaluate to a list of hostnames",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(CELPrefix + tt.expr)
+			require.NoError(t, err)
+
+			got, err := ExecTemplate(tmpl, testService())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResultFilterTargets(t *testing.T) {
+	newEndpoints := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "10.0.0.1", "203.0.113.1"),
+			endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		tmpl string
+		want []*endpoint.Endpoint
+	}{
+		{
+			name: "go template",
+			tmpl: "{{ .Name }}.example.com",
+			want: newEndpoints(),
+		},
+		{
+			name: "hostnames only",
+			tmpl: CELPrefix + `[object.metadata.name + ".example.com"]`,
+			want: newEndpoints(),
+		},
+		{
+			name: "public IPv4 targets",
+			tmpl: CELPrefix + `{object.metadata.name + ".example.com": object.status.loadBalancer.ingress.map(i, i.ip).filter(ip, isIPv4(ip) && !ip.startsWith("10."))}`,
+			want: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "203.0.113.1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+			},
+		},
+		{
+			name: "IPv6 targets",
+			tmpl: CELPrefix + `{object.metadata.name + ".example.com": object.status.loadBalancer.ingress.map(i, i.ip).filter(ip, isIPv6(ip))}`,
+			want: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.tmpl)
+			require.NoError(t, err)
+
+			result, err := tmpl.Evaluate(testService())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.FilterTargets(newEndpoints()))
+		})
+	}
+}
+
+func TestResultNil(t *testing.T) {
+	var result *Result
+	assert.True(t, result.Allowed("web.example.com", "1.2.3.4"))
+	endpoints := []*endpoint.Endpoint{endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	assert.Equal(t, endpoints, result.FilterTargets(endpoints))
+}
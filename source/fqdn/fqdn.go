@@ -20,18 +20,40 @@ import (
 	"bytes"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
 	"text/template"
 	"unicode"
 
+	"github.com/google/cel-go/cel"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
-func ParseTemplate(input string) (tmpl *template.Template, err error) {
+// CELPrefix marks a template as a CEL expression rather than a Go template.
+const CELPrefix = "cel:"
+
+// Template computes the hostnames of an object, with either a Go template or a CEL expression.
+type Template struct {
+	tmpl    *template.Template
+	program cel.Program
+}
+
+// ParseTemplate parses a Go template or, when prefixed with CELPrefix, compiles a CEL expression.
+// It returns nil if the input is empty.
+func ParseTemplate(input string) (*Template, error) {
 	if input == "" {
 		return nil, nil
 	}
+	if expr, ok := strings.CutPrefix(input, CELPrefix); ok {
+		program, err := compileCEL(expr)
+		if err != nil {
+			return nil, err
+		}
+		return &Template{program: program}, nil
+	}
 	funcs := template.FuncMap{
 		"contains":   strings.Contains,
 		"trimPrefix": strings.TrimPrefix,
@@ -42,7 +64,47 @@ func ParseTemplate(input string) (tmpl *template.Template, err error) {
 		"isIPv6":     isIPv6String,
 		"isIPv4":     isIPv4String,
 	}
-	return template.New("endpoint").Funcs(funcs).Parse(input)
+	tmpl, err := template.New("endpoint").Funcs(funcs).Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Result holds the hostnames computed by a Template, and the targets they may point to.
+type Result struct {
+	Hostnames []string
+	// targets maps the hostnames to their allowed targets, nil unless a CEL expression evaluates to a map.
+	targets map[string][]string
+}
+
+// Evaluate returns the hostnames computed from data, a Kubernetes object or any value serializable to JSON,
+// with the targets they may point to. The result is meant to be reused to filter the targets of the hostnames,
+// so that the template is executed once per object.
+func (t *Template) Evaluate(data any) (*Result, error) {
+	if t.program != nil {
+		return evalCEL(t.program, data)
+	}
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	for _, name := range strings.Split(buf.String(), ",") {
+		name = strings.TrimFunc(name, unicode.IsSpace)
+		name = strings.TrimSuffix(name, ".")
+		result.Hostnames = append(result.Hostnames, name)
+	}
+	return result, nil
+}
+
+// Execute returns the hostnames computed from data, a Kubernetes object or any value serializable to JSON.
+func (t *Template) Execute(data any) ([]string, error) {
+	result, err := t.Evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+	return result.Hostnames, nil
 }
 
 type kubeObject interface {
@@ -50,22 +112,59 @@ type kubeObject interface {
 	metav1.Object
 }
 
-func ExecTemplate(tmpl *template.Template, obj kubeObject) ([]string, error) {
+// EvalTemplate evaluates the template on a Kubernetes object, see Template.Evaluate.
+func EvalTemplate(tmpl *Template, obj kubeObject) (*Result, error) {
 	if obj == nil {
 		return nil, fmt.Errorf("object is nil")
 	}
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, obj); err != nil {
+	result, err := tmpl.Evaluate(obj)
+	if err != nil {
 		kind := obj.GetObjectKind().GroupVersionKind().Kind
 		return nil, fmt.Errorf("failed to apply template on %s %s/%s: %w", kind, obj.GetNamespace(), obj.GetName(), err)
 	}
-	var hostnames []string
-	for _, name := range strings.Split(buf.String(), ",") {
-		name = strings.TrimFunc(name, unicode.IsSpace)
-		name = strings.TrimSuffix(name, ".")
-		hostnames = append(hostnames, name)
+	return result, nil
+}
+
+func ExecTemplate(tmpl *Template, obj kubeObject) ([]string, error) {
+	result, err := EvalTemplate(tmpl, obj)
+	if err != nil {
+		return nil, err
+	}
+	return result.Hostnames, nil
+}
+
+// Allowed returns whether a target may be used for a hostname, as selected by a CEL expression
+// evaluating to a map of hostnames to targets. Every target is allowed by other templates, for
+// hostnames missing from the map, and by a nil Result.
+func (r *Result) Allowed(hostname, target string) bool {
+	if r == nil || r.targets == nil {
+		return true
+	}
+	allowed, ok := r.targets[hostname]
+	return !ok || slices.Contains(allowed, target)
+}
+
+// FilterTargets removes the targets of the endpoints not allowed by the result,
+// and the endpoints left without targets.
+func (r *Result) FilterTargets(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if r == nil || r.targets == nil || len(endpoints) == 0 {
+		return endpoints
+	}
+	filtered := endpoints[:0:0]
+	for _, ep := range endpoints {
+		var targets endpoint.Targets
+		for _, target := range ep.Targets {
+			if r.Allowed(ep.DNSName, target) {
+				targets = append(targets, target)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		ep.Targets = targets
+		filtered = append(filtered, ep)
 	}
-	return hostnames, nil
+	return filtered
 }
 
 // replace all instances of oldValue with newValue in target string.
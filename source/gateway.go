@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"net/netip"
+	"slices"
 	"sort"
 	"strings"
-	"text/template"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -48,6 +50,13 @@ import (
 const (
 	gatewayGroup = "gateway.networking.k8s.io"
 	gatewayKind  = "Gateway"
+
+	// gatewayRouteProgrammedCondition is the condition added to the parents of routes
+	// to report whether their DNS records are programmed.
+	gatewayRouteProgrammedCondition = "dns.external-dns.io/Programmed"
+	// gatewayRouteStatusControllerName is the controller name of the status.parents entries
+	// of routes holding the DNS Programmed condition.
+	gatewayRouteStatusControllerName v1.GatewayController = "external-dns.io/external-dns"
 )
 
 type gatewayRoute interface {
@@ -63,6 +72,8 @@ type gatewayRoute interface {
 	Protocol() v1.ProtocolType
 	// RouteStatus returns the route's common status.
 	RouteStatus() v1.RouteStatus
+	// UpdateRouteStatus replaces the route's common status using the given client.
+	UpdateRouteStatus(ctx context.Context, client gateway.Interface, status v1.RouteStatus) error
 }
 
 type newGatewayRouteInformerFunc func(gwinformers.SharedInformerFactory) gatewayRouteInformer
@@ -100,9 +111,12 @@ type gatewayRouteSource struct {
 
 	nsInformer coreinformers.NamespaceInformer
 
-	fqdnTemplate             *template.Template
+	fqdnTemplate             *fqdn.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
+
+	// client is nil unless the status of routes is reported.
+	client gateway.Interface
 }
 
 func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string, newInformerFn newGatewayRouteInformerFunc) (Source, error) {
@@ -184,6 +198,9 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		combineFQDNAnnotation:    config.CombineFQDNAndAnnotation,
 		ignoreHostnameAnnotation: config.IgnoreHostnameAnnotation,
 	}
+	if config.GatewayRouteStatus {
+		src.client = client
+	}
 	return src, nil
 }
 
@@ -227,7 +244,7 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		}
 
 		// Get Route hostnames and their targets.
-		hostTargets, err := resolver.resolve(rt)
+		hostTargets, templateResult, err := resolver.resolve(rt)
 		if err != nil {
 			return nil, err
 		}
@@ -239,11 +256,14 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 		// Create endpoints from hostnames and targets.
 		var routeEndpoints []*endpoint.Endpoint
 		resource := fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name)
-		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(annots)
-		ttl := annotations.TTLFromAnnotations(annots, resource)
+		overrides := annotations.HostnameOverridesFromAnnotations(annots, resource)
 		for host, targets := range hostTargets {
+			hostAnnotations := overrides.Apply(annots, host)
+			providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(hostAnnotations)
+			ttl := annotations.TTLFromAnnotations(hostAnnotations, resource)
 			routeEndpoints = append(routeEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
+		routeEndpoints = templateResult.FilterTargets(routeEndpoints)
 		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
 
 		endpoints = append(endpoints, routeEndpoints...)
@@ -251,6 +271,114 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 	return endpoints, nil
 }
 
+// ReportStatus implements StatusReporter. If enabled, it sets a DNS Programmed condition
+// on the accepted Gateway parents of each route the endpoints were generated from.
+func (src *gatewayRouteSource) ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus) {
+	if src.client == nil {
+		return
+	}
+	routes, err := src.rtInformer.List(src.rtNamespace, src.rtLabels)
+	if err != nil {
+		log.Errorf("Failed to list %s to report their status: %v", src.rtKind, err)
+		return
+	}
+	kind := strings.ToLower(src.rtKind)
+	for _, rt := range routes {
+		meta := rt.Metadata()
+		rtStatuses, ok := statuses[fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name)]
+		if !ok {
+			continue
+		}
+		status, changed := gwRouteStatusWithCondition(rt, gwRouteProgrammedCondition(rtStatuses, meta.Generation))
+		if !changed {
+			continue
+		}
+		if err := rt.UpdateRouteStatus(ctx, src.client, status); err != nil {
+			log.Warnf("Failed to update the status of %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, err)
+			continue
+		}
+		log.Debugf("Updated the DNS status of %s %s/%s", src.rtKind, meta.Namespace, meta.Name)
+	}
+}
+
+// gwRouteProgrammedCondition returns the DNS Programmed condition summarizing the status of the
+// endpoints of a route. The condition is only true if every endpoint is programmed.
+func gwRouteProgrammedCondition(statuses []EndpointStatus, generation int64) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               gatewayRouteProgrammedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             StatusReasonProgrammed,
+		ObservedGeneration: generation,
+	}
+	var hostnames, messages []string
+	for _, reason := range []string{StatusReasonFailed, StatusReasonConflicted, StatusReasonRejected, StatusReasonFiltered} {
+		for _, status := range statuses {
+			if status.Reason == reason && !slices.Contains(messages, status.Message) {
+				messages = append(messages, status.Message)
+			}
+		}
+		if len(messages) > 0 && cond.Status == metav1.ConditionTrue {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = reason
+		}
+	}
+	if cond.Status == metav1.ConditionFalse {
+		cond.Message = strings.Join(messages, "; ")
+		return cond
+	}
+	for _, status := range statuses {
+		if !slices.Contains(hostnames, status.Endpoint.DNSName) {
+			hostnames = append(hostnames, status.Endpoint.DNSName)
+		}
+	}
+	sort.Strings(hostnames)
+	cond.Message = "DNS records are programmed for " + strings.Join(hostnames, ", ")
+	return cond
+}
+
+// gwRouteStatusWithCondition returns a copy of the status of a route with the condition set on the entries
+// of external-dns for its accepted Gateway parents, and whether the status changed. The entries of the
+// Gateway controllers are left untouched, and the entries of parents no longer accepted are removed.
+func gwRouteStatusWithCondition(rt gatewayRoute, cond metav1.Condition) (v1.RouteStatus, bool) {
+	meta := rt.Metadata()
+	current := rt.RouteStatus()
+	var accepted []v1.ParentReference
+	for _, rps := range current.Parents {
+		ref := rps.ParentRef
+		group := strVal((*string)(ref.Group), gatewayGroup)
+		kind := strVal((*string)(ref.Kind), gatewayKind)
+		if rps.ControllerName == gatewayRouteStatusControllerName || group != gatewayGroup || kind != gatewayKind ||
+			!gwRouteHasParentRef(rt.ParentRefs(), ref, meta) || !gwRouteIsAccepted(rps.Conditions) {
+			continue
+		}
+		if !slices.ContainsFunc(accepted, func(r v1.ParentReference) bool { return apiequality.Semantic.DeepEqual(r, ref) }) {
+			accepted = append(accepted, ref)
+		}
+	}
+
+	status := v1.RouteStatus{}
+	for _, rps := range current.Parents {
+		if rps.ControllerName != gatewayRouteStatusControllerName {
+			status.Parents = append(status.Parents, *rps.DeepCopy())
+			continue
+		}
+		i := slices.IndexFunc(accepted, func(r v1.ParentReference) bool { return apiequality.Semantic.DeepEqual(r, rps.ParentRef) })
+		if i < 0 {
+			continue
+		}
+		accepted = slices.Delete(accepted, i, i+1)
+		own := *rps.DeepCopy()
+		apimeta.SetStatusCondition(&own.Conditions, cond)
+		status.Parents = append(status.Parents, own)
+	}
+	for _, ref := range accepted {
+		rps := v1.RouteParentStatus{ParentRef: ref, ControllerName: gatewayRouteStatusControllerName}
+		apimeta.SetStatusCondition(&rps.Conditions, cond)
+		status.Parents = append(status.Parents, rps)
+	}
+	return status, !apiequality.Semantic.DeepEqual(current.Parents, status.Parents)
+}
+
 func namespacedName(namespace, name string) types.NamespacedName {
 	return types.NamespacedName{Namespace: namespace, Name: name}
 }
@@ -264,6 +392,7 @@ type gatewayRouteResolver struct {
 type gatewayListeners struct {
 	gateway   *v1beta1.Gateway
 	listeners map[v1.SectionName][]v1.Listener
+	overrides annotations.HostnameOverrides
 }
 
 func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace) *gatewayRouteResolver {
@@ -278,6 +407,7 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 		gws[namespacedName(gw.Namespace, gw.Name)] = gatewayListeners{
 			gateway:   gw,
 			listeners: lss,
+			overrides: annotations.HostnameOverridesFromAnnotations(gw.Annotations, fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)),
 		}
 	}
 	// Create Namespace lookup table.
@@ -292,10 +422,12 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 	}
 }
 
-func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, error) {
-	rtHosts, err := c.hosts(rt)
+// resolve returns the targets of the hostnames of a route, and the result of the FQDN template
+// if it was evaluated for the route.
+func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Targets, *fqdn.Result, error) {
+	rtHosts, templateResult, err := c.hosts(rt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	hostTargets := make(map[string]endpoint.Targets)
 
@@ -303,11 +435,15 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 
 	if len(routeParentRefs) == 0 {
 		log.Debugf("No parent references found for %s %s/%s", c.src.rtKind, rt.Metadata().Namespace, rt.Metadata().Name)
-		return hostTargets, nil
+		return hostTargets, templateResult, nil
 	}
 
 	meta := rt.Metadata()
 	for _, rps := range rt.RouteStatus().Parents {
+		// Ignore the entries holding the DNS status of the route.
+		if rps.ControllerName == gatewayRouteStatusControllerName {
+			continue
+		}
 		// Confirm the Parent is the standard Gateway kind.
 		ref := rps.ParentRef
 		namespace := strVal((*string)(ref.Namespace), meta.Namespace)
@@ -377,7 +513,7 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 				if !ok {
 					continue
 				}
-				override := annotations.TargetsFromTargetAnnotation(gw.gateway.Annotations)
+				override := annotations.TargetsFromTargetAnnotation(gw.overrides.Apply(gw.gateway.Annotations, host))
 				hostTargets[host] = append(hostTargets[host], override...)
 				if len(override) == 0 {
 					for _, addr := range gw.gateway.Status.Addresses {
@@ -396,10 +532,10 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 	for host, targets := range hostTargets {
 		hostTargets[host] = uniqueTargets(targets)
 	}
-	return hostTargets, nil
+	return hostTargets, templateResult, nil
 }
 
-func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
+func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, *fqdn.Result, error) {
 	var hostnames []string
 	for _, name := range rt.Hostnames() {
 		hostnames = append(hostnames, string(name))
@@ -410,12 +546,14 @@ func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
 		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(rt.Metadata().Annotations)...)
 	}
 	// TODO: The combine-fqdn-annotation flag is similarly vague.
+	var templateResult *fqdn.Result
 	if c.src.fqdnTemplate != nil && (len(hostnames) == 0 || c.src.combineFQDNAnnotation) {
-		hosts, err := fqdn.ExecTemplate(c.src.fqdnTemplate, rt.Object())
+		var err error
+		templateResult, err = fqdn.EvalTemplate(c.src.fqdnTemplate, rt.Object())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		hostnames = append(hostnames, hosts...)
+		hostnames = append(hostnames, templateResult.Hostnames...)
 	}
 	// This means that the route doesn't specify a hostname and should use any provided by
 	// attached Gateway Listeners. This is only useful for {HTTP,TLS}Routes, but it doesn't
@@ -423,7 +561,7 @@ func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
 	if len(rt.Hostnames()) == 0 {
 		hostnames = append(hostnames, "")
 	}
-	return hostnames, nil
+	return hostnames, templateResult, nil
 }
 
 func (c *gatewayRouteResolver) routeIsAllowed(gw *v1beta1.Gateway, lis *v1.Listener, rt gatewayRoute) bool {
@@ -17,9 +17,12 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	informers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 	informers_v1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1"
 )
@@ -40,6 +43,13 @@ func (rt *gatewayGRPCRoute) ParentRefs() []v1.ParentReference { return rt.route.
 func (rt *gatewayGRPCRoute) Protocol() v1.ProtocolType        { return v1.HTTPSProtocolType }
 func (rt *gatewayGRPCRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+func (rt *gatewayGRPCRoute) UpdateRouteStatus(ctx context.Context, client gateway.Interface, status v1.RouteStatus) error {
+	route := rt.route
+	route.Status.RouteStatus = status
+	_, err := client.GatewayV1().GRPCRoutes(route.Namespace).UpdateStatus(ctx, &route, metav1.UpdateOptions{})
+	return err
+}
+
 type gatewayGRPCRouteInformer struct {
 	informers_v1.GRPCRouteInformer
 }
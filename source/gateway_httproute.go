@@ -17,10 +17,13 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	informers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 	informers_v1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
 )
@@ -41,6 +44,13 @@ func (rt *gatewayHTTPRoute) ParentRefs() []v1.ParentReference { return rt.route.
 func (rt *gatewayHTTPRoute) Protocol() v1.ProtocolType        { return v1.HTTPProtocolType }
 func (rt *gatewayHTTPRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+func (rt *gatewayHTTPRoute) UpdateRouteStatus(ctx context.Context, client gateway.Interface, status v1.RouteStatus) error {
+	route := v1beta1.HTTPRoute(rt.route)
+	route.Status.RouteStatus = status
+	_, err := client.GatewayV1beta1().HTTPRoutes(route.Namespace).UpdateStatus(ctx, &route, metav1.UpdateOptions{})
+	return err
+}
+
 type gatewayHTTPRouteInformer struct {
 	informers_v1beta1.HTTPRouteInformer
 }
@@ -23,6 +23,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	kubefake "k8s.io/client-go/kubernetes/fake"
@@ -1120,6 +1121,48 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 					WithSetIdentifier("test-set-identifier"),
 			},
 		},
+		{
+			title:      "HostnameOverrides",
+			config:     Config{},
+			namespaces: namespaces("default"),
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+					Annotations: map[string]string{
+						annotations.HostnameOverridesKey: `static.example.com: {target: cdn.example.net}`,
+					},
+				},
+				Spec: v1.GatewaySpec{
+					Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			routes: []*v1beta1.HTTPRoute{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hostname-overrides",
+					Namespace: "default",
+					Annotations: map[string]string{
+						ttlAnnotationKey:                 "15s",
+						annotations.HostnameOverridesKey: `api.example.com: {ttl: 60, set-identifier: blue}`,
+					},
+				},
+				Spec: v1.HTTPRouteSpec{
+					CommonRouteSpec: v1.CommonRouteSpec{
+						ParentRefs: []v1.ParentReference{
+							gwParentRef("default", "test"),
+						},
+					},
+					Hostnames: hostnames("api.example.com", "static.example.com"),
+				},
+				Status: httpRouteStatus(gwParentRef("default", "test")),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("api.example.com", "A", 60, "1.2.3.4").
+					WithSetIdentifier("blue"),
+				newTestEndpointWithTTL("static.example.com", "CNAME", 15, "cdn.example.net"),
+			},
+		},
 		{
 			title:      "DifferentHostnameDifferentGateway",
 			config:     Config{},
@@ -1585,3 +1628,117 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 }
 
 func hostnamePtr(val v1.Hostname) *v1.Hostname { return &val }
+
+func TestGatewayHTTPRouteSourceReportStatus(t *testing.T) {
+	ctx := context.Background()
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{Protocol: v1.HTTPProtocolType}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}
+	route := func(name string) *v1beta1.HTTPRoute {
+		return &v1beta1.HTTPRoute{
+			ObjectMeta: omWithGeneration(metav1.ObjectMeta{Namespace: "default", Name: name}, 3),
+			Spec: v1.HTTPRouteSpec{
+				Hostnames:       []v1.Hostname{v1.Hostname(name + ".example.internal")},
+				CommonRouteSpec: v1.CommonRouteSpec{ParentRefs: []v1.ParentReference{gwParentRef("default", "gw")}},
+			},
+			Status: httpRouteStatus(gwParentRef("default", "gw")),
+		}
+	}
+
+	for _, tt := range []struct {
+		title   string
+		config  Config
+		reason  string
+		status  metav1.ConditionStatus
+		message string
+	}{
+		{
+			title: "disabled",
+		},
+		{
+			title:   "programmed",
+			config:  Config{GatewayRouteStatus: true},
+			reason:  StatusReasonProgrammed,
+			status:  metav1.ConditionTrue,
+			message: "DNS records are programmed for programmed.example.internal",
+		},
+		{
+			title:   "conflicted",
+			config:  Config{GatewayRouteStatus: true},
+			reason:  StatusReasonConflicted,
+			status:  metav1.ConditionFalse,
+			message: `DNS name conflicted.example.internal is owned by "other"`,
+		},
+		{
+			title:   "rejected",
+			config:  Config{GatewayRouteStatus: true},
+			reason:  StatusReasonRejected,
+			status:  metav1.ConditionFalse,
+			message: "namespace default is not allowed to publish rejected.example.internal by the claim policy",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			gwClient := gatewayfake.NewSimpleClientset()
+			_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+			require.NoError(t, err, "failed to create Gateway")
+			for _, rt := range []*v1beta1.HTTPRoute{route(tt.title), route("unrelated")} {
+				_, err = gwClient.GatewayV1beta1().HTTPRoutes(rt.Namespace).Create(ctx, rt, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create HTTPRoute")
+			}
+
+			clients := new(MockClientGenerator)
+			clients.On("GatewayClient").Return(gwClient, nil)
+			clients.On("KubeClient").Return(kubefake.NewSimpleClientset(), nil)
+
+			src, err := NewGatewayHTTPRouteSource(clients, &tt.config)
+			require.NoError(t, err, "failed to create Gateway HTTPRoute Source")
+			endpoints, err := src.Endpoints(ctx)
+			require.NoError(t, err, "failed to get Endpoints")
+
+			statuses := map[string][]EndpointStatus{}
+			for _, ep := range endpoints {
+				status := EndpointStatus{Endpoint: ep, Reason: tt.reason, Message: tt.message}
+				resource := ep.Labels[endpoint.ResourceLabelKey]
+				statuses[resource] = append(statuses[resource], status)
+			}
+			delete(statuses, "httproute/default/unrelated")
+			src.(StatusReporter).ReportStatus(ctx, statuses)
+
+			unrelated, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Get(ctx, "unrelated", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Nil(t, apimeta.FindStatusCondition(unrelated.Status.Parents[0].Conditions, gatewayRouteProgrammedCondition))
+
+			rt, err := gwClient.GatewayV1beta1().HTTPRoutes("default").Get(ctx, tt.title, metav1.GetOptions{})
+			require.NoError(t, err)
+			// the entry of the Gateway controller is left untouched
+			require.Nil(t, apimeta.FindStatusCondition(rt.Status.Parents[0].Conditions, gatewayRouteProgrammedCondition))
+			if tt.reason == "" {
+				require.Len(t, rt.Status.Parents, 1)
+				return
+			}
+			require.Len(t, rt.Status.Parents, 2)
+			require.Equal(t, gatewayRouteStatusControllerName, rt.Status.Parents[1].ControllerName)
+			require.Equal(t, rt.Status.Parents[0].ParentRef, rt.Status.Parents[1].ParentRef)
+			cond := apimeta.FindStatusCondition(rt.Status.Parents[1].Conditions, gatewayRouteProgrammedCondition)
+			require.NotNil(t, cond)
+			require.Equal(t, tt.status, cond.Status)
+			require.Equal(t, tt.reason, cond.Reason)
+			require.Equal(t, tt.message, cond.Message)
+			require.Equal(t, int64(3), cond.ObservedGeneration)
+
+			// the status is only updated when the condition changes
+			_, changed := gwRouteStatusWithCondition(&gatewayHTTPRoute{v1.HTTPRoute(*rt)}, *cond)
+			require.False(t, changed)
+
+			// the entry is removed once the Gateway no longer accepts the route
+			rt.Status.Parents[0].Conditions[0].Status = metav1.ConditionFalse
+			status, changed := gwRouteStatusWithCondition(&gatewayHTTPRoute{v1.HTTPRoute(*rt)}, *cond)
+			require.True(t, changed)
+			require.Equal(t, rt.Status.Parents[:1], status.Parents)
+		})
+	}
+}
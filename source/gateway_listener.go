@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/apisx/v1alpha1"
+	informers_v1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
+	informers_v1alpha1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apisx/v1alpha1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// gatewayListenerSource is an implementation of Source publishing the hostnames
+// of the listeners of Gateways, and of the XListenerSets attached to them,
+// independently of the routes attached to the listeners.
+type gatewayListenerSource struct {
+	gwName        string
+	gwNamespace   string
+	gwLabels      labels.Selector
+	gwAnnotations labels.Selector
+	gwInformer    informers_v1beta1.GatewayInformer
+
+	// lsInformer is nil unless XListenerSets are enabled.
+	lsInformer informers_v1alpha1.XListenerSetInformer
+	// nsInformer is nil unless XListenerSets are enabled, and only started
+	// once a Gateway allows XListenerSets from selected namespaces.
+	nsInformerFactory kubeinformers.SharedInformerFactory
+	nsInformer        coreinformers.NamespaceInformer
+	nsStarted         bool
+
+	ignoreHostnameAnnotation bool
+}
+
+// NewGatewayListenerSource creates a new Gateway listener source with the given config.
+func NewGatewayListenerSource(clients ClientGenerator, config *Config) (Source, error) {
+	ctx := context.TODO()
+
+	gwLabels, err := getLabelSelector(config.GatewayLabelFilter)
+	if err != nil {
+		return nil, err
+	}
+	gwAnnotations, err := getLabelSelector(config.AnnotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clients.GatewayClient()
+	if err != nil {
+		return nil, err
+	}
+
+	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
+	gwInformer := informerFactory.Gateway().V1beta1().Gateways()
+	gwInformer.Informer() // Register with factory before starting.
+
+	// XListenerSets may be attached from any namespace the Gateway allows,
+	// so they are not restricted to the namespace and labels of the Gateways.
+	lsInformerFactory := informerFactory
+	var lsInformer informers_v1alpha1.XListenerSetInformer
+	var kubeInformerFactory kubeinformers.SharedInformerFactory
+	var nsInformer coreinformers.NamespaceInformer
+	if config.GatewayListenerSets {
+		lsInformerFactory = newGatewayInformerFactory(client, "", nil)
+		lsInformer = lsInformerFactory.Experimental().V1alpha1().XListenerSets()
+		lsInformer.Informer() // Register with factory before starting.
+
+		kubeClient, err := clients.KubeClient()
+		if err != nil {
+			return nil, err
+		}
+		kubeInformerFactory = kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+		nsInformer = kubeInformerFactory.Core().V1().Namespaces()
+		nsInformer.Informer() // Register with factory before starting.
+	}
+
+	informerFactory.Start(wait.NeverStop)
+	if lsInformerFactory != informerFactory {
+		lsInformerFactory.Start(wait.NeverStop)
+
+		if err := informers.WaitForCacheSync(ctx, lsInformerFactory); err != nil {
+			return nil, err
+		}
+	}
+	if err := informers.WaitForCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &gatewayListenerSource{
+		gwName:        config.GatewayName,
+		gwNamespace:   config.GatewayNamespace,
+		gwLabels:      gwLabels,
+		gwAnnotations: gwAnnotations,
+		gwInformer:    gwInformer,
+
+		lsInformer:        lsInformer,
+		nsInformerFactory: kubeInformerFactory,
+		nsInformer:        nsInformer,
+
+		ignoreHostnameAnnotation: config.IgnoreHostnameAnnotation,
+	}, nil
+}
+
+func (src *gatewayListenerSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handlers for Gateway listeners")
+	eventHandler := eventHandlerFunc(handler)
+	_, _ = src.gwInformer.Informer().AddEventHandler(eventHandler)
+	if src.lsInformer != nil {
+		_, _ = src.lsInformer.Informer().AddEventHandler(eventHandler)
+		// the handler is only called once the namespace informer is started
+		_, _ = src.nsInformer.Informer().AddEventHandler(eventHandler)
+	}
+}
+
+// Endpoints returns endpoint objects for the listener hostnames of each Gateway,
+// pointing to the addresses of the Gateway.
+func (src *gatewayListenerSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	gateways, err := src.gwInformer.Lister().Gateways(src.gwNamespace).List(src.gwLabels)
+	if err != nil {
+		return nil, err
+	}
+	var listenerSets []*v1alpha1.XListenerSet
+	var nss map[string]*corev1.Namespace
+	if src.lsInformer != nil {
+		listenerSets, err = src.lsInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(gateways, gwAllowsListenersFromSelector) {
+			nss, err = src.namespaces(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, gw := range gateways {
+		if src.gwName != "" && src.gwName != gw.Name {
+			continue
+		}
+		// Filter by annotations.
+		if !src.gwAnnotations.Matches(labels.Set(gw.Annotations)) {
+			continue
+		}
+		// Check controller annotation to see if we are responsible.
+		if v, ok := gw.Annotations[controllerAnnotationKey]; ok && v != controllerAnnotationValue {
+			log.Debugf("Skipping Gateway %s/%s because controller value does not match, found: %s, required: %s",
+				gw.Namespace, gw.Name, v, controllerAnnotationValue)
+			continue
+		}
+
+		gwEndpoints := src.endpointsFromGateway(gw, listenerSets, nss)
+		if len(gwEndpoints) == 0 {
+			log.Debugf("No endpoints could be generated from Gateway %s/%s", gw.Namespace, gw.Name)
+			continue
+		}
+		log.Debugf("Endpoints generated from Gateway %s/%s: %v", gw.Namespace, gw.Name, gwEndpoints)
+		endpoints = append(endpoints, gwEndpoints...)
+	}
+	return endpoints, nil
+}
+
+// namespaces returns the namespaces by name, starting the namespace informer on first use.
+func (src *gatewayListenerSource) namespaces(ctx context.Context) (map[string]*corev1.Namespace, error) {
+	if !src.nsStarted {
+		log.Debug("Starting the namespace informer for XListenerSets allowed by namespace selectors")
+		src.nsInformerFactory.Start(wait.NeverStop)
+		if err := informers.WaitForCacheSync(ctx, src.nsInformerFactory); err != nil {
+			return nil, err
+		}
+		src.nsStarted = true
+	}
+	namespaces, err := src.nsInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nss := make(map[string]*corev1.Namespace, len(namespaces))
+	for _, ns := range namespaces {
+		nss[ns.Name] = ns
+	}
+	return nss, nil
+}
+
+// gwAllowsListenersFromSelector returns whether the Gateway allows XListenerSets from selected namespaces.
+func gwAllowsListenersFromSelector(gw *v1beta1.Gateway) bool {
+	allowed := gw.Spec.AllowedListeners
+	return allowed != nil && allowed.Namespaces != nil && allowed.Namespaces.From != nil &&
+		*allowed.Namespaces.From == v1.NamespacesFromSelector
+}
+
+// listenerHostname is a hostname declared by the listener of a resource.
+type listenerHostname struct {
+	hostname string
+	resource string
+}
+
+func (src *gatewayListenerSource) endpointsFromGateway(gw *v1beta1.Gateway, listenerSets []*v1alpha1.XListenerSet, nss map[string]*corev1.Namespace) []*endpoint.Endpoint {
+	resource := fmt.Sprintf("gateway/%s/%s", gw.Namespace, gw.Name)
+
+	var hostnames []listenerHostname
+	for _, lis := range gw.Spec.Listeners {
+		if lis.Hostname != nil {
+			hostnames = append(hostnames, listenerHostname{string(*lis.Hostname), resource})
+		}
+	}
+	for _, ls := range listenerSets {
+		if !src.listenerSetIsAttached(gw, ls, nss) {
+			continue
+		}
+		lsResource := fmt.Sprintf("xlistenerset/%s/%s", ls.Namespace, ls.Name)
+		for _, lis := range ls.Spec.Listeners {
+			if lis.Hostname != nil {
+				hostnames = append(hostnames, listenerHostname{string(*lis.Hostname), lsResource})
+			}
+		}
+	}
+	if !src.ignoreHostnameAnnotation {
+		for _, hostname := range annotations.HostnamesFromAnnotations(gw.Annotations) {
+			hostnames = append(hostnames, listenerHostname{hostname, resource})
+		}
+	}
+
+	overrides := annotations.HostnameOverridesFromAnnotations(gw.Annotations, resource)
+
+	hostTargets := make(map[string]endpoint.Targets)
+	hostResources := make(map[string]string)
+	for _, lh := range hostnames {
+		host, ok := gwHost(lh.hostname)
+		if !ok || host == "" {
+			log.Debugf("Skipping invalid hostname %q of %s", lh.hostname, lh.resource)
+			continue
+		}
+		if _, ok := hostTargets[host]; ok {
+			continue
+		}
+		hostResources[host] = lh.resource
+		targets := annotations.TargetsFromTargetAnnotation(overrides.Apply(gw.Annotations, host))
+		if len(targets) == 0 {
+			for _, addr := range gw.Status.Addresses {
+				targets = append(targets, addr.Value)
+			}
+		}
+		hostTargets[host] = uniqueTargets(targets)
+	}
+
+	hosts := make([]string, 0, len(hostTargets))
+	for host := range hostTargets {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var endpoints []*endpoint.Endpoint
+	for _, host := range hosts {
+		hostAnnotations := overrides.Apply(gw.Annotations, host)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(hostAnnotations)
+		ttl := annotations.TTLFromAnnotations(hostAnnotations, resource)
+		endpoints = append(endpoints, endpointsForHostname(host, hostTargets[host], ttl, providerSpecific, setIdentifier, hostResources[host])...)
+	}
+	return endpoints
+}
+
+// listenerSetIsAttached returns whether the XListenerSet is attached to the Gateway,
+// accepted, and allowed by the Gateway.
+func (src *gatewayListenerSource) listenerSetIsAttached(gw *v1beta1.Gateway, ls *v1alpha1.XListenerSet, nss map[string]*corev1.Namespace) bool {
+	ref := ls.Spec.ParentRef
+	group := strVal((*string)(ref.Group), gatewayGroup)
+	kind := strVal((*string)(ref.Kind), gatewayKind)
+	namespace := strVal((*string)(ref.Namespace), ls.Namespace)
+	if group != gatewayGroup || kind != gatewayKind || namespace != gw.Namespace || string(ref.Name) != gw.Name {
+		return false
+	}
+	if !meta.IsStatusConditionTrue(ls.Status.Conditions, string(v1alpha1.ListenerSetConditionAccepted)) {
+		log.Debugf("Gateway %s/%s has not accepted XListenerSet %s/%s", gw.Namespace, gw.Name, ls.Namespace, ls.Name)
+		return false
+	}
+
+	// While XListenerSets are experimental, Gateways allow none by default.
+	from := v1.NamespacesFromNone
+	allowed := gw.Spec.AllowedListeners
+	if allowed != nil && allowed.Namespaces != nil && allowed.Namespaces.From != nil {
+		from = *allowed.Namespaces.From
+	}
+	switch from {
+	case v1.NamespacesFromAll:
+		return true
+	case v1.NamespacesFromSame:
+		return ls.Namespace == gw.Namespace
+	case v1.NamespacesFromSelector:
+		selector, err := metav1.LabelSelectorAsSelector(allowed.Namespaces.Selector)
+		if err != nil {
+			log.Debugf("Gateway %s/%s has invalid allowed listeners namespace selector: %v", gw.Namespace, gw.Name, err)
+			return false
+		}
+		ns, ok := nss[ls.Namespace]
+		if !ok {
+			log.Errorf("Namespace not found for XListenerSet %s/%s", ls.Namespace, ls.Name)
+			return false
+		}
+		return selector.Matches(labels.Set(ns.Labels))
+	default:
+		log.Debugf("Gateway %s/%s does not allow XListenerSet %s/%s", gw.Namespace, gw.Name, ls.Namespace, ls.Name)
+		return false
+	}
+}
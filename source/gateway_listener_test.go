@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	v1 "sigs.k8s.io/gateway-api/apis/v1"
+	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/apisx/v1alpha1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// This is a compile-time validation that gatewayListenerSource is a Source.
+var _ Source = &gatewayListenerSource{}
+
+func listenerSet(namespace, name, gwNamespace, gwName string, accepted bool, hostnames ...v1.Hostname) *v1alpha1.XListenerSet {
+	ls := &v1alpha1.XListenerSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.ListenerSetSpec{
+			ParentRef: v1alpha1.ParentGatewayReference{Name: v1.ObjectName(gwName)},
+		},
+	}
+	if gwNamespace != namespace {
+		ns := v1.Namespace(gwNamespace)
+		ls.Spec.ParentRef.Namespace = &ns
+	}
+	for i, hostname := range hostnames {
+		ls.Spec.Listeners = append(ls.Spec.Listeners, v1alpha1.ListenerEntry{
+			Name:     v1.SectionName(string(rune('a' + i))),
+			Hostname: &hostname,
+			Protocol: v1.TLSProtocolType,
+			Port:     443,
+		})
+	}
+	status := metav1.ConditionFalse
+	if accepted {
+		status = metav1.ConditionTrue
+	}
+	ls.Status.Conditions = []metav1.Condition{{Type: string(v1alpha1.ListenerSetConditionAccepted), Status: status}}
+	return ls
+}
+
+func allowedListeners(from v1.FromNamespaces, selector *metav1.LabelSelector) *v1.AllowedListeners {
+	return &v1.AllowedListeners{Namespaces: &v1.ListenerNamespaces{From: &from, Selector: selector}}
+}
+
+func TestGatewayListenerSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		title        string
+		config       Config
+		namespaces   []*corev1.Namespace
+		gateways     []*v1beta1.Gateway
+		listenerSets []*v1alpha1.XListenerSet
+		endpoints    []*endpoint.Endpoint
+		// resources are the expected resource labels by DNS name, if set
+		resources map[string]string
+		// namespacesWatched is whether the namespace informer is expected to be started
+		namespacesWatched bool
+	}{
+		{
+			title: "listener hostnames",
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+				Spec: v1.GatewaySpec{Listeners: []v1.Listener{
+					{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("tls.example.com")},
+					{Name: "https", Protocol: v1.HTTPSProtocolType, Port: 443, Hostname: hostnamePtr("*.example.com")},
+					{Name: "https-alt", Protocol: v1.HTTPSProtocolType, Port: 8443, Hostname: hostnamePtr("TLS.example.com")},
+					{Name: "tcp", Protocol: v1.TCPProtocolType, Port: 5432},
+					{Name: "ip", Protocol: v1.HTTPProtocolType, Port: 80, Hostname: hostnamePtr("1.2.3.4")},
+				}},
+				Status: gatewayStatus("1.2.3.4", "2001:db8::1"),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("*.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("*.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+				newTestEndpoint("tls.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("tls.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			},
+		},
+		{
+			title: "annotations",
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Annotations: map[string]string{
+					hostnameAnnotationKey: "extra.example.com",
+					targetAnnotationKey:   "lb.example.com",
+					ttlAnnotationKey:      "60",
+				}},
+				Spec: v1.GatewaySpec{Listeners: []v1.Listener{
+					{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("tls.example.com")},
+				}},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpointWithTTL("extra.example.com", endpoint.RecordTypeCNAME, 60, "lb.example.com"),
+				newTestEndpointWithTTL("tls.example.com", endpoint.RecordTypeCNAME, 60, "lb.example.com"),
+			},
+		},
+		{
+			title:  "gateway name, annotation filter and controller",
+			config: Config{GatewayName: "gw", AnnotationFilter: "public=true"},
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Annotations: map[string]string{"public": "true"}},
+					Spec: v1.GatewaySpec{Listeners: []v1.Listener{
+						{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("gw.example.com")},
+					}},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "gw", Annotations: map[string]string{"public": "false"}},
+					Spec: v1.GatewaySpec{Listeners: []v1.Listener{
+						{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("private.example.com")},
+					}},
+					Status: gatewayStatus("1.2.3.5"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "gw2", Annotations: map[string]string{"public": "true"}},
+					Spec: v1.GatewaySpec{Listeners: []v1.Listener{
+						{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("gw2.example.com")},
+					}},
+					Status: gatewayStatus("1.2.3.6"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "controller", Name: "gw", Annotations: map[string]string{"public": "true", controllerAnnotationKey: "other"}},
+					Spec: v1.GatewaySpec{Listeners: []v1.Listener{
+						{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("controller.example.com")},
+					}},
+					Status: gatewayStatus("1.2.3.7"),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("gw.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			title:  "listener sets",
+			config: Config{GatewayListenerSets: true},
+			namespaces: []*corev1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"gateway-access": "true"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+			},
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "selector"},
+					Spec: v1.GatewaySpec{
+						AllowedListeners: allowedListeners(v1.NamespacesFromSelector, &metav1.LabelSelector{MatchLabels: map[string]string{"gateway-access": "true"}}),
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "same"},
+					Spec: v1.GatewaySpec{
+						AllowedListeners: allowedListeners(v1.NamespacesFromSame, nil),
+					},
+					Status: gatewayStatus("1.2.3.5"),
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "none"},
+					Status:     gatewayStatus("1.2.3.6"),
+				},
+			},
+			listenerSets: []*v1alpha1.XListenerSet{
+				listenerSet("team-a", "allowed", "infra", "selector", true, "a.example.com", "a2.example.com"),
+				listenerSet("team-a", "not-accepted", "infra", "selector", false, "not-accepted.example.com"),
+				listenerSet("team-b", "not-selected", "infra", "selector", true, "b.example.com"),
+				listenerSet("infra", "same", "infra", "same", true, "same.example.com"),
+				listenerSet("team-a", "other-namespace", "infra", "same", true, "other-namespace.example.com"),
+				listenerSet("infra", "none", "infra", "none", true, "none.example.com"),
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("a2.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("same.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+			},
+			resources: map[string]string{
+				"a.example.com":    "xlistenerset/team-a/allowed",
+				"a2.example.com":   "xlistenerset/team-a/allowed",
+				"same.example.com": "xlistenerset/infra/same",
+			},
+			namespacesWatched: true,
+		},
+		{
+			title:  "listener sets without namespace selector",
+			config: Config{GatewayListenerSets: true},
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "gw"},
+				Spec: v1.GatewaySpec{
+					Listeners:        []v1.Listener{{Name: "tls", Protocol: v1.TLSProtocolType, Port: 443, Hostname: hostnamePtr("gw.example.com")}},
+					AllowedListeners: allowedListeners(v1.NamespacesFromAll, nil),
+				},
+				Status: gatewayStatus("1.2.3.4"),
+			}},
+			listenerSets: []*v1alpha1.XListenerSet{
+				listenerSet("team-a", "ls", "infra", "gw", true, "ls.example.com", "gw.example.com"),
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("gw.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				newTestEndpoint("ls.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+			resources: map[string]string{
+				"gw.example.com": "gateway/infra/gw",
+				"ls.example.com": "xlistenerset/team-a/ls",
+			},
+		},
+		{
+			title: "listener sets disabled",
+			gateways: []*v1beta1.Gateway{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "gw"},
+				Spec:       v1.GatewaySpec{AllowedListeners: allowedListeners(v1.NamespacesFromAll, nil)},
+				Status:     gatewayStatus("1.2.3.4"),
+			}},
+			listenerSets: []*v1alpha1.XListenerSet{
+				listenerSet("infra", "ls", "infra", "gw", true, "ls.example.com"),
+			},
+			endpoints: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			gwClient := gatewayfake.NewSimpleClientset()
+			for _, gw := range tt.gateways {
+				_, err := gwClient.GatewayV1beta1().Gateways(gw.Namespace).Create(ctx, gw, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Gateway")
+			}
+			for _, ls := range tt.listenerSets {
+				_, err := gwClient.ExperimentalV1alpha1().XListenerSets(ls.Namespace).Create(ctx, ls, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create XListenerSet")
+			}
+			kubeClient := kubefake.NewSimpleClientset()
+			for _, ns := range tt.namespaces {
+				_, err := kubeClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+				require.NoError(t, err, "failed to create Namespace")
+			}
+
+			clients := new(MockClientGenerator)
+			clients.On("GatewayClient").Return(gwClient, nil)
+			clients.On("KubeClient").Return(kubeClient, nil)
+
+			src, err := NewGatewayListenerSource(clients, &tt.config)
+			require.NoError(t, err, "failed to create Gateway listener Source")
+
+			endpoints, err := src.Endpoints(ctx)
+			require.NoError(t, err, "failed to get Endpoints")
+			validateEndpoints(t, endpoints, tt.endpoints)
+			for _, ep := range endpoints {
+				if resource, ok := tt.resources[ep.DNSName]; ok {
+					require.Equal(t, resource, ep.Labels[endpoint.ResourceLabelKey], "resource of %s", ep.DNSName)
+				}
+			}
+			require.Equal(t, tt.namespacesWatched, src.(*gatewayListenerSource).nsStarted)
+		})
+	}
+}
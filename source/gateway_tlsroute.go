@@ -17,10 +17,13 @@ limitations under the License.
 package source
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	informers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 	informers_v1a2 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1alpha2"
 )
@@ -41,6 +44,13 @@ func (rt *gatewayTLSRoute) ParentRefs() []v1.ParentReference { return rt.route.S
 func (rt *gatewayTLSRoute) Protocol() v1.ProtocolType        { return v1.TLSProtocolType }
 func (rt *gatewayTLSRoute) RouteStatus() v1.RouteStatus      { return rt.route.Status.RouteStatus }
 
+func (rt *gatewayTLSRoute) UpdateRouteStatus(ctx context.Context, client gateway.Interface, status v1.RouteStatus) error {
+	route := rt.route
+	route.Status.RouteStatus = status
+	_, err := client.GatewayV1alpha2().TLSRoutes(route.Namespace).UpdateStatus(ctx, &route, metav1.UpdateOptions{})
+	return err
+}
+
 type gatewayTLSRouteInformer struct {
 	informers_v1a2.TLSRouteInformer
 }
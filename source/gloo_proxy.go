@@ -168,14 +168,31 @@ func (gs *glooSource) generateEndpointsFromProxy(ctx context.Context, proxy *pro
 			}
 			ttl := annotations.TTLFromAnnotations(ants, resource)
 			providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ants)
+			owner := virtualHostResource(virtualHost, resource)
 			for _, domain := range virtualHost.Domains {
-				endpoints = append(endpoints, endpointsForHostname(strings.TrimSuffix(domain, "."), targets, ttl, providerSpecific, setIdentifier, "")...)
+				endpoints = append(endpoints, endpointsForHostname(strings.TrimSuffix(domain, "."), targets, ttl, providerSpecific, setIdentifier, owner)...)
 			}
 		}
 	}
 	return endpoints, nil
 }
 
+// virtualHostResource returns the resource label of the virtual service a virtual host comes from,
+// or the given proxy resource if the proxy does not record it.
+func virtualHostResource(virtualHost proxyVirtualHost, proxyResource string) string {
+	for _, src := range virtualHost.Metadata.Source {
+		if sourceKind(src.Kind) != nil {
+			return fmt.Sprintf("virtualservice/%s/%s", src.Namespace, src.Name)
+		}
+	}
+	for _, src := range virtualHost.MetadataStatic.Source {
+		if sourceKind(src.ResourceKind) != nil {
+			return fmt.Sprintf("virtualservice/%s/%s", src.ResourceRef.Namespace, src.ResourceRef.Name)
+		}
+	}
+	return proxyResource
+}
+
 func (gs *glooSource) annotationsFromProxySource(ctx context.Context, virtualHost proxyVirtualHost) (map[string]string, error) {
 	ants := map[string]string{}
 	for _, src := range virtualHost.Metadata.Source {
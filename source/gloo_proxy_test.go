@@ -488,7 +488,7 @@ func TestGlooSource(t *testing.T) {
 			Targets:          []string{internalProxySvc.Status.LoadBalancer.Ingress[0].IP, internalProxySvc.Status.LoadBalancer.Ingress[1].IP, internalProxySvc.Status.LoadBalancer.Ingress[2].IP},
 			RecordType:       endpoint.RecordTypeA,
 			RecordTTL:        0,
-			Labels:           endpoint.Labels{},
+			Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "proxy/gloo-system/internal"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
 		{
@@ -496,7 +496,7 @@ func TestGlooSource(t *testing.T) {
 			Targets:          []string{internalProxySvc.Status.LoadBalancer.Ingress[0].IP, internalProxySvc.Status.LoadBalancer.Ingress[1].IP, internalProxySvc.Status.LoadBalancer.Ingress[2].IP},
 			RecordType:       endpoint.RecordTypeA,
 			RecordTTL:        0,
-			Labels:           endpoint.Labels{},
+			Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "proxy/gloo-system/internal"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
 		{
@@ -505,7 +505,7 @@ func TestGlooSource(t *testing.T) {
 			RecordType:    endpoint.RecordTypeA,
 			SetIdentifier: "identifier",
 			RecordTTL:     42,
-			Labels:        endpoint.Labels{},
+			Labels:        endpoint.Labels{endpoint.ResourceLabelKey: "virtualservice/internal/my-internal-svc"},
 			ProviderSpecific: endpoint.ProviderSpecific{
 				endpoint.ProviderSpecificProperty{
 					Name:  "aws/geolocation-country-code",
@@ -518,7 +518,7 @@ func TestGlooSource(t *testing.T) {
 			Targets:          []string{externalProxySvc.Status.LoadBalancer.Ingress[0].Hostname, externalProxySvc.Status.LoadBalancer.Ingress[1].Hostname, externalProxySvc.Status.LoadBalancer.Ingress[2].Hostname},
 			RecordType:       endpoint.RecordTypeCNAME,
 			RecordTTL:        0,
-			Labels:           endpoint.Labels{},
+			Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "proxy/gloo-system/external"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
 		{
@@ -527,7 +527,7 @@ func TestGlooSource(t *testing.T) {
 			RecordType:    endpoint.RecordTypeCNAME,
 			SetIdentifier: "identifier-external",
 			RecordTTL:     24,
-			Labels:        endpoint.Labels{},
+			Labels:        endpoint.Labels{endpoint.ResourceLabelKey: "virtualservice/external/my-external-svc"},
 			ProviderSpecific: endpoint.ProviderSpecific{
 				endpoint.ProviderSpecificProperty{
 					Name:  "aws/geolocation-country-code",
@@ -540,7 +540,7 @@ func TestGlooSource(t *testing.T) {
 			Targets:          []string{proxyMetadataStaticSvc.Status.LoadBalancer.Ingress[0].IP, proxyMetadataStaticSvc.Status.LoadBalancer.Ingress[1].IP, proxyMetadataStaticSvc.Status.LoadBalancer.Ingress[2].IP},
 			RecordType:       endpoint.RecordTypeA,
 			RecordTTL:        0,
-			Labels:           endpoint.Labels{},
+			Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "proxy/gloo-system/internal-static"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
 		{
@@ -548,7 +548,7 @@ func TestGlooSource(t *testing.T) {
 			Targets:          []string{proxyMetadataStaticSvc.Status.LoadBalancer.Ingress[0].IP, proxyMetadataStaticSvc.Status.LoadBalancer.Ingress[1].IP, proxyMetadataStaticSvc.Status.LoadBalancer.Ingress[2].IP},
 			RecordType:       endpoint.RecordTypeA,
 			RecordTTL:        0,
-			Labels:           endpoint.Labels{},
+			Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "proxy/gloo-system/internal-static"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
 		{
@@ -557,7 +557,7 @@ func TestGlooSource(t *testing.T) {
 			RecordType:    endpoint.RecordTypeA,
 			SetIdentifier: "identifier",
 			RecordTTL:     420,
-			Labels:        endpoint.Labels{},
+			Labels:        endpoint.Labels{endpoint.ResourceLabelKey: "virtualservice/internal-static/my-internal-static-svc"},
 			ProviderSpecific: endpoint.ProviderSpecific{
 				endpoint.ProviderSpecificProperty{
 					Name:  "aws/geolocation-country-code",
@@ -569,7 +569,7 @@ func TestGlooSource(t *testing.T) {
 			DNSName:          "i.test",
 			Targets:          []string{"203.2.45.7"},
 			RecordType:       endpoint.RecordTypeA,
-			Labels:           endpoint.Labels{},
+			Labels:           endpoint.Labels{endpoint.ResourceLabelKey: "proxy/gloo-system/target-ann"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
 		{
@@ -578,7 +578,7 @@ func TestGlooSource(t *testing.T) {
 			RecordType:    endpoint.RecordTypeA,
 			SetIdentifier: "identifier-annotated",
 			RecordTTL:     460,
-			Labels:        endpoint.Labels{},
+			Labels:        endpoint.Labels{endpoint.ResourceLabelKey: "virtualservice/internal/my-annotated-svc"},
 			ProviderSpecific: endpoint.ProviderSpecific{
 				endpoint.ProviderSpecificProperty{
 					Name:  "aws/geolocation-country-code",
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"text/template"
 
 	log "github.com/sirupsen/logrus"
 	networkv1 "k8s.io/api/networking/v1"
@@ -57,13 +56,15 @@ type ingressSource struct {
 	namespace                string
 	annotationFilter         string
 	ingressClassNames        []string
-	fqdnTemplate             *template.Template
+	fqdnTemplate             *fqdn.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
 	ingressInformer          netinformers.IngressInformer
 	ignoreIngressTLSSpec     bool
 	ignoreIngressRulesSpec   bool
 	labelSelector            labels.Selector
+
+	*changeTracker
 }
 
 // NewIngressSource creates a new ingressSource with the given config.
@@ -115,6 +116,9 @@ func NewIngressSource(
 	}
 
 	sc := &ingressSource{
+		changeTracker: newChangeTracker(func(record func(string)) {
+			_, _ = ingressInformer.Informer().AddEventHandler(changeEventHandler("ingress", record, nil))
+		}),
 		client:                   kubeClient,
 		namespace:                namespace,
 		annotationFilter:         annotationFilter,
@@ -186,27 +190,18 @@ func (sc *ingressSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 }
 
 func (sc *ingressSource) endpointsFromTemplate(ing *networkv1.Ingress) ([]*endpoint.Endpoint, error) {
-	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, ing)
+	result, err := fqdn.EvalTemplate(sc.fqdnTemplate, ing)
 	if err != nil {
 		return nil, err
 	}
 
-	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
-
-	ttl := annotations.TTLFromAnnotations(ing.Annotations, resource)
-
-	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
-	if len(targets) == 0 {
-		targets = targetsFromIngressStatus(ing.Status)
-	}
-
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
+	endpointsForHost := ingressHostnameEndpoints(ing)
 
 	var endpoints []*endpoint.Endpoint
-	for _, hostname := range hostnames {
-		endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+	for _, hostname := range result.Hostnames {
+		endpoints = append(endpoints, endpointsForHost(hostname)...)
 	}
-	return endpoints, nil
+	return result.FilterTargets(endpoints), nil
 }
 
 // filterByAnnotations filters a list of ingresses by a given annotation selector.
@@ -279,17 +274,7 @@ func (sc *ingressSource) filterByIngressClass(ingresses []*networkv1.Ingress) ([
 
 // endpointsFromIngress extracts the endpoints from ingress object
 func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool) []*endpoint.Endpoint {
-	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
-
-	ttl := annotations.TTLFromAnnotations(ing.Annotations, resource)
-
-	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
-
-	if len(targets) == 0 {
-		targets = targetsFromIngressStatus(ing.Status)
-	}
-
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
+	endpointsForHost := ingressHostnameEndpoints(ing)
 
 	// Gather endpoints defined on hosts sections of the ingress
 	var definedHostsEndpoints []*endpoint.Endpoint
@@ -299,7 +284,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 			if rule.Host == "" {
 				continue
 			}
-			definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHostname(rule.Host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHost(rule.Host)...)
 		}
 	}
 
@@ -310,7 +295,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 				if host == "" {
 					continue
 				}
-				definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+				definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHost(host)...)
 			}
 		}
 	}
@@ -319,7 +304,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	var annotationEndpoints []*endpoint.Endpoint
 	if !ignoreHostnameAnnotation {
 		for _, hostname := range annotations.HostnamesFromAnnotations(ing.Annotations) {
-			annotationEndpoints = append(annotationEndpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			annotationEndpoints = append(annotationEndpoints, endpointsForHost(hostname)...)
 		}
 	}
 
@@ -340,6 +325,25 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	return endpoints
 }
 
+// ingressHostnameEndpoints returns a function generating the endpoints of a hostname of the ingress,
+// from the annotations of the ingress and their overrides for that hostname.
+func ingressHostnameEndpoints(ing *networkv1.Ingress) func(hostname string) []*endpoint.Endpoint {
+	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
+	overrides := annotations.HostnameOverridesFromAnnotations(ing.Annotations, resource)
+	statusTargets := targetsFromIngressStatus(ing.Status)
+
+	return func(hostname string) []*endpoint.Endpoint {
+		hostAnnotations := overrides.Apply(ing.Annotations, hostname)
+		ttl := annotations.TTLFromAnnotations(hostAnnotations, resource)
+		targets := annotations.TargetsFromTargetAnnotation(hostAnnotations)
+		if len(targets) == 0 {
+			targets = statusTargets
+		}
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(hostAnnotations)
+		return endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)
+	}
+}
+
 func targetsFromIngressStatus(status networkv1.IngressStatus) endpoint.Targets {
 	var targets endpoint.Targets
 
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
 )
 
 // Validates that ingressSource is a Source
@@ -250,6 +251,47 @@ func testEndpointsFromIngress(t *testing.T) {
 			expected:               []*endpoint.Endpoint{},
 			ignoreIngressRulesSpec: true,
 		},
+		{
+			title: "hostname overrides",
+			ingress: fakeIngress{
+				dnsnames: []string{"api.example.org", "static.example.org", "www.example.org"},
+				ips:      []string{"8.8.8.8"},
+				annotations: map[string]string{
+					annotations.TtlKey:               "300",
+					annotations.CloudflareProxiedKey: "true",
+					annotations.HostnameOverridesKey: `
+api.example.org:
+  ttl: 60
+  cloudflare-proxied: false
+static.example.org.:
+  target: [cdn.example.net]
+`,
+				},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:          "api.example.org",
+					RecordType:       endpoint.RecordTypeA,
+					Targets:          endpoint.Targets{"8.8.8.8"},
+					RecordTTL:        endpoint.TTL(60),
+					ProviderSpecific: endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedKey, Value: "false"}},
+				},
+				{
+					DNSName:          "static.example.org",
+					RecordType:       endpoint.RecordTypeCNAME,
+					Targets:          endpoint.Targets{"cdn.example.net"},
+					RecordTTL:        endpoint.TTL(300),
+					ProviderSpecific: endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedKey, Value: "true"}},
+				},
+				{
+					DNSName:          "www.example.org",
+					RecordType:       endpoint.RecordTypeA,
+					Targets:          endpoint.Targets{"8.8.8.8"},
+					RecordTTL:        endpoint.TTL(300),
+					ProviderSpecific: endpoint.ProviderSpecific{{Name: annotations.CloudflareProxiedKey, Value: "true"}},
+				},
+			},
+		},
 		{
 			title: "invalid hostname does not generate endpoints",
 			ingress: fakeIngress{
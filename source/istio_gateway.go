@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"text/template"
 
 	log "github.com/sirupsen/logrus"
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -53,7 +52,7 @@ type gatewaySource struct {
 	istioClient              istioclient.Interface
 	namespace                string
 	annotationFilter         string
-	fqdnTemplate             *template.Template
+	fqdnTemplate             *fqdn.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
 	serviceInformer          coreinformers.ServiceInformer
@@ -155,16 +154,17 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 		}
 
 		// apply template if host is missing on gateway
+		var templateResult *fqdn.Result
 		if (sc.combineFQDNAnnotation || len(gwHostnames) == 0) && sc.fqdnTemplate != nil {
-			iHostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, gateway)
+			templateResult, err = fqdn.EvalTemplate(sc.fqdnTemplate, gateway)
 			if err != nil {
 				return nil, err
 			}
 
 			if sc.combineFQDNAnnotation {
-				gwHostnames = append(gwHostnames, iHostnames...)
+				gwHostnames = append(gwHostnames, templateResult.Hostnames...)
 			} else {
-				gwHostnames = iHostnames
+				gwHostnames = templateResult.Hostnames
 			}
 		}
 
@@ -178,6 +178,8 @@ func (sc *gatewaySource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			return nil, err
 		}
 
+		gwEndpoints = templateResult.FilterTargets(gwEndpoints)
+
 		if len(gwEndpoints) == 0 {
 			log.Debugf("No endpoints could be generated from gateway %s/%s", gateway.Namespace, gateway.Name)
 			continue
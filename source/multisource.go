@@ -74,6 +74,29 @@ func (ms *multiSource) AddEventHandler(ctx context.Context, handler func()) {
 	}
 }
 
+// ChangedKeys implements ChangeTracker. Changes are only known if every nested Source tracks them;
+// all nested Sources are drained regardless, so that stale keys do not leak into the next call.
+func (ms *multiSource) ChangedKeys() ([]string, bool) {
+	var result []string
+	tracked := true
+	for _, s := range ms.children {
+		keys, ok := changedKeys(s)
+		tracked = tracked && ok
+		result = append(result, keys...)
+	}
+	if !tracked {
+		return nil, false
+	}
+	return result, true
+}
+
+// ReportStatus implements StatusReporter by forwarding the statuses to every nested Source.
+func (ms *multiSource) ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus) {
+	for _, s := range ms.children {
+		reportStatus(ctx, s, statuses)
+	}
+}
+
 // NewMultiSource creates a new multiSource.
 func NewMultiSource(children []Source, defaultTargets []string, forceDefaultTargets bool) Source {
 	return &multiSource{children: children, defaultTargets: defaultTargets, forceDefaultTargets: forceDefaultTargets}
@@ -110,3 +110,13 @@ func (s *nat64Source) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 func (s *nat64Source) AddEventHandler(ctx context.Context, handler func()) {
 	s.source.AddEventHandler(ctx, handler)
 }
+
+// ChangedKeys implements ChangeTracker by delegating to the wrapped source.
+func (s *nat64Source) ChangedKeys() ([]string, bool) {
+	return changedKeys(s.source)
+}
+
+// ReportStatus implements StatusReporter by delegating to the wrapped source.
+func (s *nat64Source) ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus) {
+	reportStatus(ctx, s.source, statuses)
+}
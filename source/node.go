@@ -19,7 +19,8 @@ package source
 import (
 	"context"
 	"fmt"
-	"text/template"
+	"maps"
+	"slices"
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
@@ -40,13 +41,15 @@ const warningMsg = "The default behavior of exposing internal IPv6 addresses wil
 type nodeSource struct {
 	client                kubernetes.Interface
 	annotationFilter      string
-	fqdnTemplate          *template.Template
+	fqdnTemplate          *fqdn.Template
 	combineFQDNAnnotation bool
 
 	nodeInformer         coreinformers.NodeInformer
 	labelSelector        labels.Selector
 	excludeUnschedulable bool
 	exposeInternalIPv6   bool
+
+	*changeTracker
 }
 
 // NewNodeSource creates a new nodeSource with the given config.
@@ -85,6 +88,9 @@ func NewNodeSource(
 	}
 
 	return &nodeSource{
+		changeTracker: newChangeTracker(func(record func(string)) {
+			_, _ = nodeInformer.Informer().AddEventHandler(changeEventHandler("node", record, nodeUpdateRelevant))
+		}),
 		client:                kubeClient,
 		annotationFilter:      annotationFilter,
 		fqdnTemplate:          tmpl,
@@ -137,7 +143,7 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 			}
 		}
 
-		dnsNames, err := ns.collectDNSNames(node)
+		dnsNames, templateResult, err := ns.collectDNSNames(node)
 		if err != nil {
 			return nil, err
 		}
@@ -146,6 +152,9 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 			log.Debugf("adding endpoint with %d targets", len(addrs))
 
 			for _, addr := range addrs {
+				if !templateResult.Allowed(dns, addr) {
+					continue
+				}
 				ep := endpoint.NewEndpointWithTTL(dns, suitableType(addr), ttl)
 				ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("node/%s", node.Name))
 
@@ -172,7 +181,41 @@ func (ns *nodeSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 	return endpointsSlice, nil
 }
 
-func (ns *nodeSource) AddEventHandler(_ context.Context, _ func()) {
+func (ns *nodeSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for node")
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	_, _ = ns.nodeInformer.Informer().AddEventHandler(nodeEventHandler(handler))
+}
+
+// nodeEventHandler returns an event handler that ignores node updates which
+// cannot change the generated endpoints, e.g. kubelet heartbeats.
+func nodeEventHandler(handler func()) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { handler() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if nodeUpdateRelevant(oldObj, newObj) {
+				handler()
+			}
+		},
+		DeleteFunc: func(obj interface{}) { handler() },
+	}
+}
+
+// nodeUpdateRelevant reports whether an informer update event may change the generated endpoints.
+func nodeUpdateRelevant(oldObj, newObj interface{}) bool {
+	oldNode, okOld := oldObj.(*v1.Node)
+	newNode, okNew := newObj.(*v1.Node)
+	return !okOld || !okNew || nodeChanged(oldNode, newNode)
+}
+
+// nodeChanged reports whether any field relevant to endpoint generation differs between two node revisions.
+func nodeChanged(oldNode, newNode *v1.Node) bool {
+	return !maps.Equal(oldNode.Labels, newNode.Labels) ||
+		!maps.Equal(oldNode.Annotations, newNode.Annotations) ||
+		oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+		!slices.Equal(oldNode.Status.Addresses, newNode.Status.Addresses)
 }
 
 // nodeAddress returns the node's externalIP and if that's not found, the node's internalIP
@@ -239,21 +282,23 @@ func (ns *nodeSource) filterByAnnotations(nodes []*v1.Node) ([]*v1.Node, error)
 // the templated names. If no FQDN template is provided, the result will include only
 // the Node's name.
 //
+// The result of the template is returned to filter the targets of the DNS names.
+//
 // Returns an error if template rendering fails.
-func (ns *nodeSource) collectDNSNames(node *v1.Node) (map[string]bool, error) {
+func (ns *nodeSource) collectDNSNames(node *v1.Node) (map[string]bool, *fqdn.Result, error) {
 	dnsNames := make(map[string]bool)
 	// If no FQDN template is configured, fallback to the node name
 	if ns.fqdnTemplate == nil {
 		dnsNames[node.Name] = true
-		return dnsNames, nil
+		return dnsNames, nil, nil
 	}
 
-	names, err := fqdn.ExecTemplate(ns.fqdnTemplate, node)
+	result, err := fqdn.EvalTemplate(ns.fqdnTemplate, node)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	for _, name := range names {
+	for _, name := range result.Hostnames {
 		dnsNames[name] = true
 		log.Debugf("applied template for %s, converting to %s", node.Name, name)
 	}
@@ -262,5 +307,5 @@ func (ns *nodeSource) collectDNSNames(node *v1.Node) (map[string]bool, error) {
 		dnsNames[node.Name] = true
 	}
 
-	return dnsNames, nil
+	return dnsNames, result, nil
 }
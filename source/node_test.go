@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"maps"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -619,6 +620,100 @@ func TestResourceLabelIsSetForEachNodeEndpoint(t *testing.T) {
 	}
 }
 
+func TestNodeSourceAddEventHandler(t *testing.T) {
+	kubeClient := fake.NewClientset()
+
+	client, err := NewNodeSource(t.Context(), kubeClient, "", "", labels.Everything(), false, false, false)
+	require.NoError(t, err)
+
+	var counter atomic.Int32
+	client.AddEventHandler(t.Context(), func() {
+		counter.Add(1)
+	})
+
+	node := &helperNodeBuilder().withNode(nil).build().Items[0]
+	node, err = kubeClient.CoreV1().Nodes().Create(t.Context(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// heartbeat-like status updates must not trigger the handler
+	heartbeat := node.DeepCopy()
+	heartbeat.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue, LastHeartbeatTime: metav1.Now()}}
+	_, err = kubeClient.CoreV1().Nodes().UpdateStatus(t.Context(), heartbeat, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	readdressed := heartbeat.DeepCopy()
+	readdressed.Status.Addresses = []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}}
+	_, err = kubeClient.CoreV1().Nodes().UpdateStatus(t.Context(), readdressed, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, kubeClient.CoreV1().Nodes().Delete(t.Context(), node.Name, metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		return counter.Load() == 3
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNodeChanged(t *testing.T) {
+	base := helperNodeBuilder().withNode(nil).build().Items[0]
+
+	for _, tc := range []struct {
+		title    string
+		modify   func(n *v1.Node)
+		expected bool
+	}{
+		{
+			title:    "no change",
+			modify:   func(n *v1.Node) {},
+			expected: false,
+		},
+		{
+			title: "condition heartbeat",
+			modify: func(n *v1.Node) {
+				n.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, LastHeartbeatTime: metav1.Now()}}
+			},
+			expected: false,
+		},
+		{
+			title:    "resource version",
+			modify:   func(n *v1.Node) { n.ResourceVersion = "42" },
+			expected: false,
+		},
+		{
+			title:    "label",
+			modify:   func(n *v1.Node) { n.Labels["new"] = "label" },
+			expected: true,
+		},
+		{
+			title:    "annotation",
+			modify:   func(n *v1.Node) { n.Annotations[ttlAnnotationKey] = "60" },
+			expected: true,
+		},
+		{
+			title:    "unschedulable",
+			modify:   func(n *v1.Node) { n.Spec.Unschedulable = true },
+			expected: true,
+		},
+		{
+			title: "addresses",
+			modify: func(n *v1.Node) {
+				n.Status.Addresses = append(n.Status.Addresses, v1.NodeAddress{Type: v1.NodeExternalIP, Address: "1.2.3.4"})
+			},
+			expected: true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			updated := base.DeepCopy()
+			tc.modify(updated)
+			assert.Equal(t, tc.expected, nodeChanged(&base, updated))
+		})
+	}
+}
+
 type nodeListBuilder struct {
 	nodes []v1.Node
 }
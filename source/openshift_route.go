@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"text/template"
 	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
@@ -47,7 +46,7 @@ type ocpRouteSource struct {
 	client                   versioned.Interface
 	namespace                string
 	annotationFilter         string
-	fqdnTemplate             *template.Template
+	fqdnTemplate             *fqdn.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
 	routeInformer            routeInformer.RouteInformer
@@ -171,7 +170,7 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 }
 
 func (ors *ocpRouteSource) endpointsFromTemplate(ocpRoute *routev1.Route) ([]*endpoint.Endpoint, error) {
-	hostnames, err := fqdn.ExecTemplate(ors.fqdnTemplate, ocpRoute)
+	result, err := fqdn.EvalTemplate(ors.fqdnTemplate, ocpRoute)
 	if err != nil {
 		return nil, err
 	}
@@ -189,10 +188,10 @@ func (ors *ocpRouteSource) endpointsFromTemplate(ocpRoute *routev1.Route) ([]*en
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ocpRoute.Annotations)
 
 	var endpoints []*endpoint.Endpoint
-	for _, hostname := range hostnames {
+	for _, hostname := range result.Hostnames {
 		endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
 	}
-	return endpoints, nil
+	return result.FilterTargets(endpoints), nil
 }
 
 func (ors *ocpRouteSource) filterByAnnotations(ocpRoutes []*routev1.Route) ([]*routev1.Route, error) {
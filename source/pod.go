@@ -17,10 +17,11 @@ limitations under the License.
 package source
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"maps"
-	"text/template"
+	"slices"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -40,7 +41,7 @@ import (
 type podSource struct {
 	client                kubernetes.Interface
 	namespace             string
-	fqdnTemplate          *template.Template
+	fqdnTemplate          *fqdn.Template
 	combineFQDNAnnotation bool
 
 	podInformer              coreinformers.PodInformer
@@ -48,6 +49,10 @@ type podSource struct {
 	compatibility            string
 	ignoreNonHostNetworkPods bool
 	podSourceDomain          string
+	// splitByNamespace labels the endpoints with their pod, for the claim policy.
+	splitByNamespace bool
+
+	*changeTracker
 }
 
 // NewPodSource creates a new podSource with the given config.
@@ -60,6 +65,7 @@ func NewPodSource(
 	podSourceDomain string,
 	fqdnTemplate string,
 	combineFqdnAnnotation bool,
+	splitByNamespace bool,
 ) (Source, error) {
 	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
 	podInformer := informerFactory.Core().V1().Pods()
@@ -91,6 +97,10 @@ func NewPodSource(
 	}
 
 	return &podSource{
+		changeTracker: newChangeTracker(func(record func(string)) {
+			_, _ = podInformer.Informer().AddEventHandler(changeEventHandler("pod", record, podUpdateRelevant))
+			_, _ = nodeInformer.Informer().AddEventHandler(changeEventHandler("node", record, nodeUpdateRelevant))
+		}),
 		client:                   kubeClient,
 		podInformer:              podInformer,
 		nodeInformer:             nodeInformer,
@@ -100,10 +110,48 @@ func NewPodSource(
 		podSourceDomain:          podSourceDomain,
 		fqdnTemplate:             tmpl,
 		combineFQDNAnnotation:    combineFqdnAnnotation,
+		splitByNamespace:         splitByNamespace,
 	}, nil
 }
 
-func (*podSource) AddEventHandler(_ context.Context, _ func()) {
+func (ps *podSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for pod")
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	_, _ = ps.podInformer.Informer().AddEventHandler(podEventHandler(handler))
+	_, _ = ps.nodeInformer.Informer().AddEventHandler(nodeEventHandler(handler))
+}
+
+// podEventHandler returns an event handler that ignores pod updates which
+// cannot change the generated endpoints, e.g. container status transitions.
+func podEventHandler(handler func()) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { handler() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if podUpdateRelevant(oldObj, newObj) {
+				handler()
+			}
+		},
+		DeleteFunc: func(obj interface{}) { handler() },
+	}
+}
+
+// podUpdateRelevant reports whether an informer update event may change the generated endpoints.
+func podUpdateRelevant(oldObj, newObj interface{}) bool {
+	oldPod, okOld := oldObj.(*corev1.Pod)
+	newPod, okNew := newObj.(*corev1.Pod)
+	return !okOld || !okNew || podChanged(oldPod, newPod)
+}
+
+// podChanged reports whether any field relevant to endpoint generation differs between two pod revisions.
+func podChanged(oldPod, newPod *corev1.Pod) bool {
+	return !maps.Equal(oldPod.Labels, newPod.Labels) ||
+		!maps.Equal(oldPod.Annotations, newPod.Annotations) ||
+		oldPod.Spec.HostNetwork != newPod.Spec.HostNetwork ||
+		oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+		oldPod.Status.PodIP != newPod.Status.PodIP ||
+		!slices.Equal(oldPod.Status.PodIPs, newPod.Status.PodIPs)
 }
 
 func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
@@ -112,10 +160,36 @@ func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 		return nil, err
 	}
 
-	endpointMap := make(map[endpoint.EndpointKey][]string)
+	// When split by namespace, the endpoints are merged per namespace only, so that the resource label of
+	// each endpoint names a pod of the namespace it comes from. The first pod, by name, generating a key labels it.
+	// Otherwise, the endpoints of all the pods are merged and not labeled.
+	slices.SortFunc(pods, func(a, b *corev1.Pod) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	endpointMaps := make(map[string]map[endpoint.EndpointKey][]string)
+	resources := make(map[string]map[endpoint.EndpointKey]string)
 	for _, pod := range pods {
+		var namespace string
+		if ps.splitByNamespace {
+			namespace = pod.Namespace
+		}
+		endpointMap, ok := endpointMaps[namespace]
+		if !ok {
+			endpointMap = make(map[endpoint.EndpointKey][]string)
+			endpointMaps[namespace] = endpointMap
+			resources[namespace] = make(map[endpoint.EndpointKey]string)
+		}
+		resource := fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name)
+
 		if ps.fqdnTemplate == nil || ps.combineFQDNAnnotation {
-			ps.addPodEndpointsToEndpointMap(endpointMap, pod)
+			podMap := make(map[endpoint.EndpointKey][]string)
+			ps.addPodEndpointsToEndpointMap(podMap, pod)
+			for key, targets := range podMap {
+				if _, ok := endpointMap[key]; !ok {
+					resources[namespace][key] = resource
+				}
+				endpointMap[key] = append(endpointMap[key], targets...)
+			}
 		}
 
 		if ps.fqdnTemplate != nil {
@@ -123,13 +197,22 @@ func (ps *podSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error)
 			if err != nil {
 				return nil, err
 			}
+			for key := range fqdnHosts {
+				resources[namespace][key] = resource
+			}
 			maps.Copy(endpointMap, fqdnHosts)
 		}
 	}
 
 	var endpoints []*endpoint.Endpoint
-	for key, targets := range endpointMap {
-		endpoints = append(endpoints, endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, key.RecordTTL, targets...))
+	for namespace, endpointMap := range endpointMaps {
+		for key, targets := range endpointMap {
+			ep := endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, key.RecordTTL, targets...)
+			if ps.splitByNamespace {
+				ep.WithLabel(endpoint.ResourceLabelKey, resources[namespace][key])
+			}
+			endpoints = append(endpoints, ep)
+		}
 	}
 	return endpoints, nil
 }
@@ -216,18 +299,21 @@ func (ps *podSource) addPodNodeEndpointsToEndpointMap(endpointMap map[endpoint.E
 }
 
 func (ps *podSource) hostsFromTemplate(pod *corev1.Pod) (map[endpoint.EndpointKey][]string, error) {
-	hosts, err := fqdn.ExecTemplate(ps.fqdnTemplate, pod)
+	templateResult, err := fqdn.EvalTemplate(ps.fqdnTemplate, pod)
 	if err != nil {
 		return nil, fmt.Errorf("skipping generating endpoints from template for pod %s: %w", pod.Name, err)
 	}
 
 	result := make(map[endpoint.EndpointKey][]string)
-	for _, target := range hosts {
+	for _, target := range templateResult.Hostnames {
 		for _, address := range pod.Status.PodIPs {
 			if address.IP == "" {
 				log.Debugf("skipping pod %q. PodIP is empty with phase %q", pod.Name, pod.Status.Phase)
 				continue
 			}
+			if !templateResult.Allowed(target, address.IP) {
+				continue
+			}
 			key := endpoint.EndpointKey{
 				DNSName:    target,
 				RecordType: suitableType(address.IP),
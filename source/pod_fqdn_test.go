@@ -58,6 +58,7 @@ func TestNewPodSourceWithFqdn(t *testing.T) {
 				false,
 				"",
 				tt.fqdnTemplate,
+				false,
 				false)
 
 			if tt.expectError {
@@ -405,7 +406,8 @@ func TestPodSourceFqdnTemplatingExamples(t *testing.T) {
 				false,
 				tt.sourceDomain,
 				tt.fqdnTemplate,
-				tt.combineFQDN)
+				tt.combineFQDN,
+				false)
 			require.NoError(t, err)
 
 			endpoints, err := src.Endpoints(t.Context())
@@ -467,7 +469,8 @@ func TestPodSourceFqdnTemplatingExamples_Failed(t *testing.T) {
 				false,
 				tt.sourceDomain,
 				tt.fqdnTemplate,
-				tt.combineFQDN)
+				tt.combineFQDN,
+				false)
 			require.NoError(t, err)
 
 			_, err = src.Endpoints(t.Context())
@@ -20,7 +20,10 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -657,7 +660,7 @@ func TestPodSource(t *testing.T) {
 				}
 			}
 
-			client, err := NewPodSource(ctx, kubernetes, tc.targetNamespace, tc.compatibility, tc.ignoreNonHostNetworkPods, tc.PodSourceDomain, "", false)
+			client, err := NewPodSource(ctx, kubernetes, tc.targetNamespace, tc.compatibility, tc.ignoreNonHostNetworkPods, tc.PodSourceDomain, "", false, false)
 			require.NoError(t, err)
 
 			endpoints, err := client.Endpoints(ctx)
@@ -679,6 +682,53 @@ func TestPodSource(t *testing.T) {
 	}
 }
 
+func TestPodSourceResourceLabel(t *testing.T) {
+	kubernetes := fake.NewClientset()
+	ctx := t.Context()
+
+	newPod := func(namespace, name, ip string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Annotations: map[string]string{internalHostnameAnnotationKey: "a.foo.example.org"},
+			},
+			Status: corev1.PodStatus{PodIP: ip},
+		}
+	}
+	for _, pod := range []*corev1.Pod{
+		newPod("team-a", "my-pod2", "10.0.1.2"),
+		newPod("team-a", "my-pod1", "10.0.1.1"),
+		newPod("team-b", "my-pod3", "10.0.1.3"),
+	} {
+		_, err := kubernetes.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	// without a claim policy, the endpoints of all the namespaces are merged and not labeled
+	client, err := NewPodSource(ctx, kubernetes, "", "", false, "", "", false, false)
+	require.NoError(t, err)
+
+	endpoints, err := client.Endpoints(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "a.foo.example.org", Targets: endpoint.Targets{"10.0.1.1", "10.0.1.2", "10.0.1.3"}, RecordType: endpoint.RecordTypeA},
+	})
+	require.NotContains(t, endpoints[0].Labels, endpoint.ResourceLabelKey)
+
+	// with a claim policy, endpoints of different namespaces are not merged, so that each is labeled with a pod of its namespace
+	client, err = NewPodSource(ctx, kubernetes, "", "", false, "", "", false, true)
+	require.NoError(t, err)
+
+	endpoints, err = client.Endpoints(ctx)
+	require.NoError(t, err)
+	resources := make(map[string]string)
+	for _, ep := range endpoints {
+		resources[strings.Join(ep.Targets, ",")] = ep.Labels[endpoint.ResourceLabelKey]
+	}
+	require.Equal(t, map[string]string{"10.0.1.1,10.0.1.2": "pod/team-a/my-pod1", "10.0.1.3": "pod/team-b/my-pod3"}, resources)
+}
+
 func TestPodSourceLogs(t *testing.T) {
 	t.Parallel()
 	// Generate unique pod names to avoid log conflicts across parallel tests.
@@ -885,7 +935,7 @@ func TestPodSourceLogs(t *testing.T) {
 				}
 			}
 
-			client, err := NewPodSource(ctx, kubernetes, "", "", tc.ignoreNonHostNetworkPods, "", "", false)
+			client, err := NewPodSource(ctx, kubernetes, "", "", tc.ignoreNonHostNetworkPods, "", "", false, false)
 			require.NoError(t, err)
 
 			hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
@@ -960,3 +1010,131 @@ func nodesFixturesIPv4() []*corev1.Node {
 		},
 	}
 }
+
+func TestPodSourceAddEventHandler(t *testing.T) {
+	kubeClient := fake.NewClientset()
+
+	client, err := NewPodSource(t.Context(), kubeClient, "", "", false, "", "", false, false)
+	require.NoError(t, err)
+
+	var counter atomic.Int32
+	client.AddEventHandler(t.Context(), func() {
+		counter.Add(1)
+	})
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-pod",
+			Namespace:   "kube-system",
+			Annotations: map[string]string{hostnameAnnotationKey: "a.foo.example.org"},
+		},
+		Spec: corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+	}
+	pod, err = kubeClient.CoreV1().Pods(pod.Namespace).Create(t.Context(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// container status churn must not trigger the handler
+	running := pod.DeepCopy()
+	running.Status.Phase = corev1.PodRunning
+	running.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", Ready: true}}
+	_, err = kubeClient.CoreV1().Pods(pod.Namespace).UpdateStatus(t.Context(), running, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	assigned := running.DeepCopy()
+	assigned.Status.PodIP = "10.0.1.1"
+	assigned.Status.PodIPs = []corev1.PodIP{{IP: "10.0.1.1"}}
+	_, err = kubeClient.CoreV1().Pods(pod.Namespace).UpdateStatus(t.Context(), assigned, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// node address changes affect pods publishing node IPs
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-node1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeExternalIP, Address: "54.10.11.1"}},
+		},
+	}
+	_, err = kubeClient.CoreV1().Nodes().Create(t.Context(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return counter.Load() == 3
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, kubeClient.CoreV1().Pods(pod.Namespace).Delete(t.Context(), pod.Name, metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		return counter.Load() == 4
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPodChanged(t *testing.T) {
+	base := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-pod",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{hostnameAnnotationKey: "a.foo.example.org"},
+		},
+		Spec: corev1.PodSpec{HostNetwork: true, NodeName: "my-node1"},
+		Status: corev1.PodStatus{
+			PodIP:  "10.0.1.1",
+			PodIPs: []corev1.PodIP{{IP: "10.0.1.1"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		title    string
+		modify   func(p *corev1.Pod)
+		expected bool
+	}{
+		{
+			title:    "no change",
+			modify:   func(p *corev1.Pod) {},
+			expected: false,
+		},
+		{
+			title: "container status",
+			modify: func(p *corev1.Pod) {
+				p.Status.Phase = corev1.PodRunning
+				p.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "app", RestartCount: 1}}
+			},
+			expected: false,
+		},
+		{
+			title:    "label",
+			modify:   func(p *corev1.Pod) { p.Labels["app"] = "api" },
+			expected: true,
+		},
+		{
+			title:    "annotation",
+			modify:   func(p *corev1.Pod) { p.Annotations[hostnameAnnotationKey] = "b.foo.example.org" },
+			expected: true,
+		},
+		{
+			title:    "host network",
+			modify:   func(p *corev1.Pod) { p.Spec.HostNetwork = false },
+			expected: true,
+		},
+		{
+			title:    "node name",
+			modify:   func(p *corev1.Pod) { p.Spec.NodeName = "my-node2" },
+			expected: true,
+		},
+		{
+			title: "pod ips",
+			modify: func(p *corev1.Pod) {
+				p.Status.PodIPs = append(p.Status.PodIPs, corev1.PodIP{IP: "2001:db8::1"})
+			},
+			expected: true,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			updated := base.DeepCopy()
+			tc.modify(updated)
+			require.Equal(t, tc.expected, podChanged(base, updated))
+		})
+	}
+}
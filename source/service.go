@@ -24,14 +24,15 @@ import (
 	"slices"
 	"sort"
 	"strings"
-	"text/template"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/publicsuffix"
 	v1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	discoveryinformers "k8s.io/client-go/informers/discovery/v1"
@@ -66,7 +67,7 @@ type serviceSource struct {
 	namespace             string
 	annotationFilter      string
 	labelSelector         labels.Selector
-	fqdnTemplate          *template.Template
+	fqdnTemplate          *fqdn.Template
 	combineFQDNAnnotation bool
 
 	ignoreHostnameAnnotation       bool
@@ -84,6 +85,8 @@ type serviceSource struct {
 
 	// process Services with legacy annotations
 	compatibility string
+
+	*changeTracker
 }
 
 // NewServiceSource creates a new serviceSource with the given config.
@@ -161,6 +164,12 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 	}
 
 	return &serviceSource{
+		changeTracker: newChangeTracker(func(record func(string)) {
+			_, _ = serviceInformer.Informer().AddEventHandler(changeEventHandler("service", record, nil))
+			_, _ = endpointSlicesInformer.Informer().AddEventHandler(changeEventHandler("endpointslice", record, nil))
+			_, _ = podInformer.Informer().AddEventHandler(changeEventHandler("pod", record, podUpdateRelevant))
+			_, _ = nodeInformer.Informer().AddEventHandler(changeEventHandler("node", record, nodeUpdateRelevant))
+		}),
 		client:                         kubeClient,
 		namespace:                      namespace,
 		annotationFilter:               annotationFilter,
@@ -217,6 +226,9 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			if err != nil {
 				return nil, err
 			}
+			for _, ep := range svcEndpoints {
+				ep.WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name))
+			}
 		}
 
 		// apply template if none of the above is found
@@ -330,6 +342,8 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	endpointsType := getEndpointsTypeFromAnnotations(svc.Annotations)
 	publishPodIPs := endpointsType != EndpointsTypeNodeExternalIP && endpointsType != EndpointsTypeHostIP && !sc.publishHostIP
 	publishNotReadyAddresses := svc.Spec.PublishNotReadyAddresses || sc.alwaysPublishNotReadyAddresses
+	publishZoneRecords := getTopologyZoneRecordsFromAnnotations(svc.Annotations)
+	zones := map[string]struct{}{}
 
 	targetsByHeadlessDomainAndType := make(map[endpoint.EndpointKey]endpoint.Targets)
 	for _, endpointSlice := range endpointSlices {
@@ -395,17 +409,30 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 						log.Debugf("Generating matching endpoint %s with EndpointSliceAddress IP %s", headlessDomain, address)
 					}
 				}
+				zoneDomains := []string{headlessDomain}
+				if publishZoneRecords && headlessDomain == hostname {
+					for _, zone := range sc.endpointZones(ep, pod) {
+						zones[zone] = struct{}{}
+						zoneDomains = append(zoneDomains, zoneHostname(hostname, zone))
+					}
+				}
 				for _, target := range targets {
-					key := endpoint.EndpointKey{
-						DNSName:    headlessDomain,
-						RecordType: suitableType(target),
+					for _, domain := range zoneDomains {
+						key := endpoint.EndpointKey{
+							DNSName:    domain,
+							RecordType: suitableType(target),
+						}
+						targetsByHeadlessDomainAndType[key] = append(targetsByHeadlessDomainAndType[key], target)
 					}
-					targetsByHeadlessDomainAndType[key] = append(targetsByHeadlessDomainAndType[key], target)
 				}
 			}
 		}
 	}
 
+	if publishZoneRecords {
+		sc.addZoneFallbackTargets(targetsByHeadlessDomainAndType, hostname, zones)
+	}
+
 	headlessKeys := []endpoint.EndpointKey{}
 	for headlessKey := range targetsByHeadlessDomainAndType {
 		headlessKeys = append(headlessKeys, headlessKey)
@@ -447,20 +474,94 @@ func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname stri
 	return endpoints
 }
 
+// endpointZones returns the topology zones an EndpointSlice endpoint serves: the zones of its hints if any,
+// otherwise its own zone or the zone of the node of its pod.
+func (sc *serviceSource) endpointZones(ep discoveryv1.Endpoint, pod *v1.Pod) []string {
+	var zones []string
+	if ep.Hints != nil {
+		for _, hint := range ep.Hints.ForZones {
+			zones = append(zones, hint.Name)
+		}
+	}
+	if len(zones) == 0 && ep.Zone != nil {
+		zones = append(zones, *ep.Zone)
+	}
+	if len(zones) == 0 && pod.Spec.NodeName != "" {
+		node, err := sc.nodeInformer.Lister().Get(pod.Spec.NodeName)
+		if err == nil && node.Labels[v1.LabelTopologyZone] != "" {
+			zones = append(zones, node.Labels[v1.LabelTopologyZone])
+		}
+	}
+
+	valid := zones[:0]
+	for _, zone := range zones {
+		zone = strings.ToLower(zone)
+		if errs := validation.IsDNS1123Label(zone); len(errs) > 0 {
+			log.Debugf("Skipping zone %q of pod %s/%s because it is not a valid DNS label: %v", zone, pod.Namespace, pod.Name, errs)
+			continue
+		}
+		valid = append(valid, zone)
+	}
+	return valid
+}
+
+// addZoneFallbackTargets adds the targets of every zone to the records of the zones of the cluster
+// which have no endpoint of their own, so that clients in these zones fall back to other zones.
+func (sc *serviceSource) addZoneFallbackTargets(targetsByKey map[endpoint.EndpointKey]endpoint.Targets, hostname string, zones map[string]struct{}) {
+	nodes, err := sc.nodeInformer.Lister().List(labels.Everything())
+	if err != nil {
+		log.Errorf("List Nodes error: %v; not adding cross-zone fallback records", err)
+		return
+	}
+	for _, node := range nodes {
+		zone := strings.ToLower(node.Labels[v1.LabelTopologyZone])
+		if zone != "" && len(validation.IsDNS1123Label(zone)) == 0 {
+			zones[zone] = struct{}{}
+		}
+	}
+
+	for zone := range zones {
+		zoneDomain := zoneHostname(hostname, zone)
+		for _, recordType := range []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA} {
+			zoneKey := endpoint.EndpointKey{DNSName: zoneDomain, RecordType: recordType}
+			if len(targetsByKey[zoneKey]) > 0 {
+				continue
+			}
+			targets := targetsByKey[endpoint.EndpointKey{DNSName: hostname, RecordType: recordType}]
+			if len(targets) > 0 {
+				log.Debugf("Generating cross-zone fallback endpoint %s with %d targets", zoneDomain, len(targets))
+				targetsByKey[zoneKey] = append(endpoint.Targets(nil), targets...)
+			}
+		}
+	}
+}
+
+// zoneHostname returns the hostname of the records of a topology zone, e.g. svc.zone-a.example.com for svc.example.com.
+// The zone is prepended to registrable domains and to names without a public suffix, e.g. zone-a.example.com for example.com,
+// so that the records of a zone are never published under another domain.
+func zoneHostname(hostname, zone string) string {
+	trimmed := strings.TrimSuffix(hostname, ".")
+	if apex, err := publicsuffix.EffectiveTLDPlusOne(trimmed); err != nil || apex == trimmed {
+		return zone + "." + hostname
+	}
+	name, domain, _ := strings.Cut(hostname, ".")
+	return name + "." + zone + "." + domain
+}
+
 func (sc *serviceSource) endpointsFromTemplate(svc *v1.Service) ([]*endpoint.Endpoint, error) {
-	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, svc)
+	result, err := fqdn.EvalTemplate(sc.fqdnTemplate, svc)
 	if err != nil {
 		return nil, err
 	}
 
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(svc.Annotations)
+	overrides := annotations.HostnameOverridesFromAnnotations(svc.Annotations, serviceResource(svc))
 
 	var endpoints []*endpoint.Endpoint
-	for _, hostname := range hostnames {
-		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, false)...)
+	for _, hostname := range result.Hostnames {
+		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, overrides, false)...)
 	}
 
-	return endpoints, nil
+	return result.FilterTargets(endpoints), nil
 }
 
 // endpointsFromService extracts the endpoints from a service object
@@ -471,18 +572,18 @@ func (sc *serviceSource) endpoints(svc *v1.Service) []*endpoint.Endpoint {
 		return endpoints
 	}
 
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(svc.Annotations)
+	overrides := annotations.HostnameOverridesFromAnnotations(svc.Annotations, serviceResource(svc))
 	var hostnameList []string
 	var internalHostnameList []string
 
 	hostnameList = annotations.HostnamesFromAnnotations(svc.Annotations)
 	for _, hostname := range hostnameList {
-		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, false)...)
+		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, overrides, false)...)
 	}
 
 	internalHostnameList = annotations.InternalHostnamesFromAnnotations(svc.Annotations)
 	for _, hostname := range internalHostnameList {
-		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, true)...)
+		endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, overrides, true)...)
 	}
 
 	return endpoints
@@ -527,14 +628,20 @@ func (sc *serviceSource) filterByServiceType(services []*v1.Service) []*v1.Servi
 	return result
 }
 
-func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, providerSpecific endpoint.ProviderSpecific, setIdentifier string, useClusterIP bool) (endpoints []*endpoint.Endpoint) {
+// generateEndpoints generates the endpoints of a hostname of the service, from the annotations
+// of the service and their overrides for that hostname.
+func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, overrides annotations.HostnameOverrides, useClusterIP bool) (endpoints []*endpoint.Endpoint) {
 	hostname = strings.TrimSuffix(hostname, ".")
 
-	resource := fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name)
+	resource := serviceResource(svc)
+
+	hostAnnotations := overrides.Apply(svc.Annotations, hostname)
+
+	ttl := annotations.TTLFromAnnotations(hostAnnotations, resource)
 
-	ttl := annotations.TTLFromAnnotations(svc.Annotations, resource)
+	targets := annotations.TargetsFromTargetAnnotation(hostAnnotations)
 
-	targets := annotations.TargetsFromTargetAnnotation(svc.Annotations)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(hostAnnotations)
 
 	if len(targets) == 0 {
 		switch svc.Spec.Type {
@@ -574,6 +681,10 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 	return endpoints
 }
 
+func serviceResource(svc *v1.Service) string {
+	return fmt.Sprintf("service/%s/%s", svc.Namespace, svc.Name)
+}
+
 func extractServiceIps(svc *v1.Service) endpoint.Targets {
 	if svc.Spec.ClusterIP == v1.ClusterIPNone {
 		log.Debugf("Unable to associate %s headless service with a Cluster IP", svc.Name)
@@ -562,6 +562,41 @@ func TestServiceSourceFqdnTemplatingExamples(t *testing.T) {
 				{DNSName: "service-two.org.tld", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.1.20.40"}},
 			},
 		},
+		{
+			title: "cel expression with hostnames from labels and filtered load balancer targets",
+			services: []*v1.Service{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "service-one",
+						Labels:    map[string]string{"team": "Payments"},
+					},
+					Spec: v1.ServiceSpec{
+						Type: v1.ServiceTypeLoadBalancer,
+					},
+					Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{
+						{IP: "10.0.0.1"},
+						{IP: "203.0.113.1"},
+					}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "service-two",
+					},
+					Spec: v1.ServiceSpec{
+						Type: v1.ServiceTypeLoadBalancer,
+					},
+					Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{
+						{IP: "10.0.0.2"},
+					}}},
+				},
+			},
+			fqdnTemplate: `cel:has(object.metadata.labels) && "team" in object.metadata.labels ? {object.metadata.labels.team.lowerAscii() + ".example.tld": object.status.loadBalancer.ingress.map(i, i.ip).filter(ip, !ip.startsWith("10."))} : {}`,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "payments.example.tld", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"203.0.113.1"}},
+			},
+		},
 	} {
 		t.Run(tt.title, func(t *testing.T) {
 			kubeClient := fake.NewClientset()
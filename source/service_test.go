@@ -3658,6 +3658,148 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 	}
 }
 
+// TestHeadlessServicesTopologyZones tests that headless services publish records per topology zone.
+func TestHeadlessServicesTopologyZones(t *testing.T) {
+	t.Parallel()
+
+	strPtr := func(s string) *string { return &s }
+	hints := func(zones ...string) *discoveryv1.EndpointHints {
+		h := &discoveryv1.EndpointHints{}
+		for _, z := range zones {
+			h.ForZones = append(h.ForZones, discoveryv1.ForZone{Name: z})
+		}
+		return h
+	}
+
+	for _, tc := range []struct {
+		title       string
+		annotations map[string]string
+		endpoints   []discoveryv1.Endpoint
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title: "zone records are disabled by default",
+			endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Zone: strPtr("zone-a")},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "db.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+		{
+			title:       "endpoints are published in their zone and missing zones fall back to all endpoints",
+			annotations: map[string]string{annotations.TopologyZoneRecordsKey: "true"},
+			endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Zone: strPtr("zone-a")},
+				{Addresses: []string{"10.0.0.2"}, Zone: strPtr("Zone-B")},
+				{Addresses: []string{"10.0.0.3"}, NodeName: strPtr("node-b")},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "db.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+				{DNSName: "db.zone-a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "db.zone-b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2", "10.0.0.3"}},
+				{DNSName: "db.zone-c.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+			},
+		},
+		{
+			title:       "zone hints take precedence over the zone of endpoints",
+			annotations: map[string]string{annotations.TopologyZoneRecordsKey: "true"},
+			endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Zone: strPtr("zone-a"), Hints: hints("zone-a", "zone-c")},
+				{Addresses: []string{"10.0.0.2"}, Zone: strPtr("zone-b"), Hints: hints("zone-b")},
+				{Addresses: []string{"10.0.0.3"}, Zone: strPtr("zone-b"), Hints: hints("invalid_zone")},
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "db.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1", "10.0.0.2", "10.0.0.3"}},
+				{DNSName: "db.zone-a.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "db.zone-b.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.2"}},
+				{DNSName: "db.zone-c.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			kubernetes := fake.NewClientset()
+			for _, node := range []*v1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{v1.LabelTopologyZone: "zone-a"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{v1.LabelTopologyZone: "zone-b"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-c", Labels: map[string]string{v1.LabelTopologyZone: "zone-c"}}},
+			} {
+				_, err := kubernetes.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			svcAnnotations := map[string]string{hostnameAnnotationKey: "db.example.org"}
+			maps.Copy(svcAnnotations, tc.annotations)
+			service := &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db", Annotations: svcAnnotations},
+				Spec: v1.ServiceSpec{
+					Type:      v1.ServiceTypeClusterIP,
+					ClusterIP: v1.ClusterIPNone,
+					Selector:  map[string]string{"app": "db"},
+				},
+			}
+			_, err := kubernetes.CoreV1().Services("default").Create(ctx, service, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			ready := true
+			for i := range tc.endpoints {
+				podName := fmt.Sprintf("db-%d", i)
+				pod := &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: podName, Labels: map[string]string{"app": "db"}},
+				}
+				if tc.endpoints[i].NodeName != nil {
+					pod.Spec.NodeName = *tc.endpoints[i].NodeName
+				}
+				_, err = kubernetes.CoreV1().Pods("default").Create(ctx, pod, metav1.CreateOptions{})
+				require.NoError(t, err)
+
+				tc.endpoints[i].TargetRef = &v1.ObjectReference{Kind: "Pod", Name: podName}
+				tc.endpoints[i].Conditions.Ready = &ready
+			}
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "db",
+					Labels:    map[string]string{discoveryv1.LabelServiceName: "db"},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints:   tc.endpoints,
+			}
+			_, err = kubernetes.DiscoveryV1().EndpointSlices("default").Create(ctx, endpointSlice, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewServiceSource(ctx, kubernetes, "", "", "", false, "", true, false, false, []string{}, false, labels.Everything(), false, false, false)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(ctx)
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestZoneHostname(t *testing.T) {
+	for _, tc := range []struct {
+		hostname string
+		expected string
+	}{
+		{hostname: "db.example.com", expected: "db.zone-a.example.com"},
+		{hostname: "db.team.example.com", expected: "db.zone-a.team.example.com"},
+		{hostname: "*.example.com", expected: "*.zone-a.example.com"},
+		{hostname: "example.com", expected: "zone-a.example.com"},
+		{hostname: "example.co.uk", expected: "zone-a.example.co.uk"},
+		{hostname: "example.com.", expected: "zone-a.example.com."},
+		{hostname: "db", expected: "zone-a.db"},
+	} {
+		t.Run(tc.hostname, func(t *testing.T) {
+			assert.Equal(t, tc.expected, zoneHostname(tc.hostname, "zone-a"))
+		})
+	}
+}
+
 // TestExternalServices tests that external services generate the correct endpoints.
 func TestExternalServices(t *testing.T) {
 	t.Parallel()
@@ -3843,6 +3985,65 @@ func TestExternalServices(t *testing.T) {
 	}
 }
 
+func TestServiceSourceHostnameOverrides(t *testing.T) {
+	kubernetes := fake.NewClientset()
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testing",
+			Name:      "foo",
+			Annotations: map[string]string{
+				hostnameAnnotationKey:         "api.example.org,static.example.org",
+				internalHostnameAnnotationKey: "internal.example.org",
+				ttlAnnotationKey:              "300",
+				annotations.HostnameOverridesKey: `{
+					"static.example.org": {"ttl": 3600, "target": "cdn.example.net"},
+					"internal.example.org": {"ttl": 30}
+				}`,
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type:      v1.ServiceTypeLoadBalancer,
+			ClusterIP: "10.0.0.1",
+		},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{
+				Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}},
+			},
+		},
+	}
+	_, err := kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	client, err := NewServiceSource(
+		context.TODO(),
+		kubernetes,
+		v1.NamespaceAll,
+		"",
+		"",
+		false,
+		"",
+		false,
+		false,
+		false,
+		[]string{},
+		false,
+		labels.Everything(),
+		false,
+		false,
+		false,
+	)
+	require.NoError(t, err)
+
+	endpoints, err := client.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		{DNSName: "api.example.org", Targets: endpoint.Targets{"1.2.3.4"}, RecordType: endpoint.RecordTypeA, RecordTTL: 300},
+		{DNSName: "static.example.org", Targets: endpoint.Targets{"cdn.example.net"}, RecordType: endpoint.RecordTypeCNAME, RecordTTL: 3600},
+		{DNSName: "internal.example.org", Targets: endpoint.Targets{"10.0.0.1"}, RecordType: endpoint.RecordTypeA, RecordTTL: 30},
+	})
+}
+
 func BenchmarkServiceEndpoints(b *testing.B) {
 	kubernetes := fake.NewClientset()
 
@@ -63,6 +63,10 @@ func getEndpointsTypeFromAnnotations(annotations map[string]string) string {
 	return annotations[endpointsTypeAnnotationKey]
 }
 
+func getTopologyZoneRecordsFromAnnotations(input map[string]string) bool {
+	return input[annotations.TopologyZoneRecordsKey] == "true"
+}
+
 func getLabelSelector(annotationFilter string) (labels.Selector, error) {
 	labelSelector, err := metav1.ParseToLabelSelector(annotationFilter)
 	if err != nil {
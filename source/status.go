@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Reasons for the outcome of the reconciliation of an endpoint.
+const (
+	// StatusReasonProgrammed means the records of the endpoint are up to date in the provider.
+	StatusReasonProgrammed = "Programmed"
+	// StatusReasonConflicted means the DNS name of the endpoint is owned by another owner.
+	StatusReasonConflicted = "Conflicted"
+	// StatusReasonRejected means the endpoint is removed by the claim policy.
+	StatusReasonRejected = "Rejected"
+	// StatusReasonFiltered means the endpoint is excluded by the domain filter or the managed record types.
+	StatusReasonFiltered = "Filtered"
+	// StatusReasonFailed means the changes to the records of the endpoint could not be applied.
+	StatusReasonFailed = "Failed"
+)
+
+// EndpointStatus is the outcome of the reconciliation of a desired endpoint.
+type EndpointStatus struct {
+	Endpoint *endpoint.Endpoint
+	// Reason is one of the StatusReason constants.
+	Reason string
+	// Message is a human readable explanation of the reason.
+	Message string
+}
+
+// Programmed returns whether the records of the endpoint are up to date.
+func (s EndpointStatus) Programmed() bool {
+	return s.Reason == StatusReasonProgrammed
+}
+
+// StatusReporter is implemented by sources that publish the outcome of the reconciliation
+// back to the objects their endpoints are generated from.
+type StatusReporter interface {
+	// ReportStatus is called after each reconciliation with the status of the desired endpoints,
+	// indexed by their resource label.
+	ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus)
+}
+
+// reportStatus forwards the statuses to src if it is a StatusReporter.
+func reportStatus(ctx context.Context, src Source, statuses map[string][]EndpointStatus) {
+	if reporter, ok := src.(StatusReporter); ok {
+		reporter.ReportStatus(ctx, statuses)
+	}
+}
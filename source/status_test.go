@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// statusRecorder is a Source recording the reported statuses.
+type statusRecorder struct {
+	emptySource
+	statuses map[string][]EndpointStatus
+}
+
+func (s *statusRecorder) ReportStatus(_ context.Context, statuses map[string][]EndpointStatus) {
+	s.statuses = statuses
+}
+
+func TestWrappedSourcesReportStatus(t *testing.T) {
+	first := &statusRecorder{}
+	second := &statusRecorder{}
+
+	src := NewTargetFilterSource(NewNAT64Source(NewDedupSource(NewMultiSource([]Source{first, NewEmptySource(), second}, nil, false)), nil), endpoint.NewTargetNetFilterWithExclusions(nil, nil))
+	statuses := map[string][]EndpointStatus{
+		"httproute/default/a": {{Endpoint: endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"), Reason: StatusReasonProgrammed}},
+	}
+	src.(StatusReporter).ReportStatus(context.Background(), statuses)
+	assert.Equal(t, statuses, first.statuses)
+	assert.Equal(t, statuses, second.statuses)
+	assert.True(t, first.statuses["httproute/default/a"][0].Programmed())
+}
@@ -71,3 +71,13 @@ func (ms *targetFilterSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoi
 func (ms *targetFilterSource) AddEventHandler(ctx context.Context, handler func()) {
 	ms.source.AddEventHandler(ctx, handler)
 }
+
+// ChangedKeys implements ChangeTracker by delegating to the wrapped source.
+func (ms *targetFilterSource) ChangedKeys() ([]string, bool) {
+	return changedKeys(ms.source)
+}
+
+// ReportStatus implements StatusReporter by delegating to the wrapped source.
+func (ms *targetFilterSource) ReportStatus(ctx context.Context, statuses map[string][]EndpointStatus) {
+	reportStatus(ctx, ms.source, statuses)
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/fqdn"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// UnstructuredSourceConfig is the configuration file of the unstructured source.
+type UnstructuredSourceConfig struct {
+	Resources []UnstructuredResourceConfig `json:"resources"`
+}
+
+// UnstructuredResourceConfig defines how endpoints are extracted from the objects of a kind.
+// The fields are JSONPath expressions evaluated against each object, e.g. "{.spec.hostnames[*]}",
+// or, when prefixed with "cel:", CEL expressions evaluating to a string or a list of strings.
+type UnstructuredResourceConfig struct {
+	// APIVersion and Kind identify the watched resource, e.g. "example.com/v1" and "WebApp".
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Hostnames selects the hostnames of the endpoints. A CEL expression may also evaluate
+	// to a map of hostnames to the targets they may point to.
+	Hostnames string `json:"hostnames"`
+	// Targets selects the targets of the endpoints. The target annotation takes precedence.
+	Targets string `json:"targets,omitempty"`
+	// TTL selects the TTL of the endpoints, in seconds or as a duration. The TTL annotation is used if empty.
+	TTL string `json:"ttl,omitempty"`
+	// RecordType selects the record type of the endpoints. It is inferred from the targets if empty.
+	RecordType string `json:"recordType,omitempty"`
+}
+
+// LoadUnstructuredSourceConfig reads the configuration of the unstructured source from a YAML or JSON file.
+func LoadUnstructuredSourceConfig(path string) (*UnstructuredSourceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unstructured source config: %w", err)
+	}
+	config := &UnstructuredSourceConfig{}
+	if err := yaml.UnmarshalWithOptions(data, config, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("failed to parse unstructured source config %s: %w", path, err)
+	}
+	if len(config.Resources) == 0 {
+		return nil, fmt.Errorf("unstructured source config %s has no resources", path)
+	}
+	return config, nil
+}
+
+// unstructuredSource is an implementation of Source extracting endpoints
+// from the objects of arbitrary kinds with JSONPath or CEL expressions.
+type unstructuredSource struct {
+	annotationFilter         string
+	ignoreHostnameAnnotation bool
+	namespace                string
+	resources                []*unstructuredResource
+}
+
+type unstructuredResource struct {
+	kind       string
+	informer   kubeinformers.GenericInformer
+	hostnames  *fieldExpr
+	targets    *fieldExpr
+	ttl        *fieldExpr
+	recordType *fieldExpr
+}
+
+// fieldExpr selects values of an object with either a JSONPath or a CEL expression.
+type fieldExpr struct {
+	path *jsonpath.JSONPath
+	cel  *fqdn.Template
+}
+
+// NewUnstructuredSource creates a new unstructuredSource watching the resources of the given config.
+func NewUnstructuredSource(ctx context.Context, dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface, namespace, annotationFilter string, labelSelector labels.Selector, ignoreHostnameAnnotation bool, config *UnstructuredSourceConfig) (Source, error) {
+	// Use shared informer to listen for add/update/delete of the resources in the specified namespace.
+	// Set resync period to 0, to prevent processing when nothing has changed.
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector.String()
+	})
+
+	src := &unstructuredSource{
+		annotationFilter:         annotationFilter,
+		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+		namespace:                namespace,
+	}
+	for i, rc := range config.Resources {
+		name := fmt.Sprintf("resources[%d]", i)
+		if rc.Hostnames == "" {
+			return nil, fmt.Errorf("%s: hostnames must be set", name)
+		}
+		gvr, err := resolveResource(kubeClient, rc.APIVersion, rc.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		resource := &unstructuredResource{
+			kind:     strings.ToLower(rc.Kind),
+			informer: informerFactory.ForResource(gvr),
+		}
+		for _, path := range []struct {
+			field string
+			expr  string
+			dst   **fieldExpr
+		}{
+			{"hostnames", rc.Hostnames, &resource.hostnames},
+			{"targets", rc.Targets, &resource.targets},
+			{"ttl", rc.TTL, &resource.ttl},
+			{"recordType", rc.RecordType, &resource.recordType},
+		} {
+			if path.expr == "" {
+				continue
+			}
+			if *path.dst, err = parseFieldExpr(path.field, path.expr); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		// Add default resource event handlers to properly initialize informer.
+		_, _ = resource.informer.Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+				},
+			},
+		)
+		src.resources = append(src.resources, resource)
+	}
+
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(ctx, informerFactory); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// resolveResource finds the resource serving the given kind with the discovery API.
+func resolveResource(kubeClient kubernetes.Interface, apiVersion, kind string) (schema.GroupVersionResource, error) {
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	apiResourceList, err := kubeClient.Discovery().ServerResourcesForGroupVersion(groupVersion.String())
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("error listing resources in GroupVersion %q: %w", groupVersion.String(), err)
+	}
+	for _, apiResource := range apiResourceList.APIResources {
+		// skip subresources such as status
+		if apiResource.Kind == kind && !strings.Contains(apiResource.Name, "/") {
+			return groupVersion.WithResource(apiResource.Name), nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("unable to find Resource Kind %q in GroupVersion %q", kind, apiVersion)
+}
+
+// parseFieldExpr compiles a CEL expression if expr has the fqdn.CELPrefix,
+// and parses a JSONPath expression otherwise, adding the braces if they are omitted.
+func parseFieldExpr(field, expr string) (*fieldExpr, error) {
+	if strings.HasPrefix(expr, fqdn.CELPrefix) {
+		tmpl, err := fqdn.ParseTemplate(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s expression: %w", field, err)
+		}
+		return &fieldExpr{cel: tmpl}, nil
+	}
+	if !strings.Contains(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	path := jsonpath.New(field).AllowMissingKeys(true)
+	if err := path.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid %s expression %q: %w", field, expr, err)
+	}
+	return &fieldExpr{path: path}, nil
+}
+
+// fieldValues returns the non-empty values selected by expr in obj,
+// and the result of the evaluation of a CEL expression.
+func fieldValues(expr *fieldExpr, obj *unstructured.Unstructured) ([]string, *fqdn.Result, error) {
+	if expr == nil {
+		return nil, nil, nil
+	}
+	if expr.cel != nil {
+		result, err := expr.cel.Evaluate(obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		return slices.DeleteFunc(slices.Clone(result.Hostnames), func(v string) bool { return v == "" }), result, nil
+	}
+	results, err := expr.path.FindResults(obj.Object)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			if !v.IsValid() || !v.CanInterface() {
+				continue
+			}
+			var value string
+			switch i := v.Interface().(type) {
+			case nil:
+				continue
+			case string:
+				value = i
+			default:
+				value = fmt.Sprint(i)
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values, nil, nil
+}
+
+// Endpoints returns endpoint objects for each host-target combination of the watched resources.
+func (us *unstructuredSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	selector, err := annotations.ParseFilter(us.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, resource := range us.resources {
+		objects, err := resource.informer.Lister().ByNamespace(us.namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			obj, ok := object.(*unstructured.Unstructured)
+			if !ok {
+				return nil, errors.New("could not convert")
+			}
+			if !selector.Empty() && !selector.Matches(labels.Set(obj.GetAnnotations())) {
+				continue
+			}
+			// Check controller annotation to see if we are responsible.
+			if v, ok := obj.GetAnnotations()[controllerAnnotationKey]; ok && v != controllerAnnotationValue {
+				log.Debugf("Skipping %s %s/%s because controller value does not match, found: %s, required: %s",
+					resource.kind, obj.GetNamespace(), obj.GetName(), v, controllerAnnotationValue)
+				continue
+			}
+
+			objEndpoints, err := us.endpointsFromObject(resource, obj)
+			if err != nil {
+				return nil, err
+			}
+			if len(objEndpoints) == 0 {
+				log.Debugf("No endpoints could be generated from %s %s/%s", resource.kind, obj.GetNamespace(), obj.GetName())
+				continue
+			}
+			log.Debugf("Endpoints generated from %s %s/%s: %v", resource.kind, obj.GetNamespace(), obj.GetName(), objEndpoints)
+			endpoints = append(endpoints, objEndpoints...)
+		}
+	}
+
+	for _, ep := range endpoints {
+		sort.Sort(ep.Targets)
+	}
+	return endpoints, nil
+}
+
+func (us *unstructuredSource) endpointsFromObject(resource *unstructuredResource, obj *unstructured.Unstructured) ([]*endpoint.Endpoint, error) {
+	resourceLabel := fmt.Sprintf("%s/%s/%s", resource.kind, obj.GetNamespace(), obj.GetName())
+	objAnnotations := obj.GetAnnotations()
+
+	hostnames, hostnamesResult, err := fieldValues(resource.hostnames, obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostnames of %s: %w", resourceLabel, err)
+	}
+	if !us.ignoreHostnameAnnotation {
+		hostnames = append(hostnames, annotations.HostnamesFromAnnotations(objAnnotations)...)
+	}
+
+	targets := annotations.TargetsFromTargetAnnotation(objAnnotations)
+	if len(targets) == 0 {
+		values, _, err := fieldValues(resource.targets, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get targets of %s: %w", resourceLabel, err)
+		}
+		targets = values
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	ttl := annotations.TTLFromAnnotations(objAnnotations, resourceLabel)
+	if values, _, err := fieldValues(resource.ttl, obj); err != nil {
+		return nil, fmt.Errorf("failed to get TTL of %s: %w", resourceLabel, err)
+	} else if len(values) > 0 {
+		ttl = annotations.TTLFromValue(values[0], resourceLabel)
+	}
+
+	var recordType string
+	if values, _, err := fieldValues(resource.recordType, obj); err != nil {
+		return nil, fmt.Errorf("failed to get record type of %s: %w", resourceLabel, err)
+	} else if len(values) > 0 {
+		recordType = strings.ToUpper(values[0])
+	}
+
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(objAnnotations)
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range hostnames {
+		if recordType == "" {
+			endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resourceLabel)...)
+			continue
+		}
+		ep := endpoint.NewEndpointWithTTL(hostname, recordType, ttl, targets...)
+		if ep == nil {
+			continue
+		}
+		ep.ProviderSpecific = providerSpecific
+		ep.SetIdentifier = setIdentifier
+		ep.WithLabel(endpoint.ResourceLabelKey, resourceLabel)
+		endpoints = append(endpoints, ep)
+	}
+	// a CEL expression evaluating to a map of hostnames to targets restricts their targets
+	return hostnamesResult.FilterTargets(endpoints), nil
+}
+
+func (us *unstructuredSource) AddEventHandler(_ context.Context, handler func()) {
+	log.Debug("Adding event handler for unstructured resources")
+
+	// Right now there is no way to remove event handler from informer, see:
+	// https://github.com/kubernetes/kubernetes/issues/79610
+	for _, resource := range us.resources {
+		_, _ = resource.informer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// This is a compile-time validation that unstructuredSource is a Source.
+var _ Source = &unstructuredSource{}
+
+var webAppGroupVersionResource = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "webapps"}
+
+func newWebApp(name string, annotations map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("WebApp")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func TestUnstructuredSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	for _, ti := range []struct {
+		title                    string
+		config                   UnstructuredResourceConfig
+		objects                  []*unstructured.Unstructured
+		annotationFilter         string
+		ignoreHostnameAnnotation bool
+		expected                 []*endpoint.Endpoint
+	}{
+		{
+			title:  "hostnames and targets from fields",
+			config: UnstructuredResourceConfig{Hostnames: ".spec.hosts[*]", Targets: "{.spec.address}"},
+			objects: []*unstructured.Unstructured{
+				newWebApp("app", nil, map[string]interface{}{
+					"hosts":   []interface{}{"a.example.com", "b.example.com"},
+					"address": "1.2.3.4",
+				}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+				endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+			},
+		},
+		{
+			title:  "record type and TTL from fields",
+			config: UnstructuredResourceConfig{Hostnames: ".spec.host", Targets: ".spec.targets[*]", TTL: ".spec.ttl", RecordType: ".spec.type"},
+			objects: []*unstructured.Unstructured{
+				newWebApp("txt", nil, map[string]interface{}{
+					"host":    "txt.example.com",
+					"targets": []interface{}{"b", "a"},
+					"ttl":     int64(60),
+					"type":    "txt",
+				}),
+				newWebApp("cname", nil, map[string]interface{}{
+					"host":    "cname.example.com",
+					"targets": []interface{}{"lb.example.com"},
+					"ttl":     "5m",
+				}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("cname.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.com").WithLabel(endpoint.ResourceLabelKey, "webapp/default/cname"),
+				endpoint.NewEndpointWithTTL("txt.example.com", endpoint.RecordTypeTXT, 60, "a", "b").WithLabel(endpoint.ResourceLabelKey, "webapp/default/txt"),
+			},
+		},
+		{
+			title:  "annotations",
+			config: UnstructuredResourceConfig{Hostnames: ".spec.host", Targets: ".spec.address"},
+			objects: []*unstructured.Unstructured{
+				newWebApp("app", map[string]string{
+					hostnameAnnotationKey: "c.example.com",
+					targetAnnotationKey:   "5.6.7.8",
+					ttlAnnotationKey:      "120",
+				}, map[string]interface{}{"host": "a.example.com", "address": "1.2.3.4"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 120, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+				endpoint.NewEndpointWithTTL("c.example.com", endpoint.RecordTypeA, 120, "5.6.7.8").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+			},
+		},
+		{
+			title:                    "ignore hostname annotation",
+			config:                   UnstructuredResourceConfig{Hostnames: ".spec.host", Targets: ".spec.address"},
+			ignoreHostnameAnnotation: true,
+			objects: []*unstructured.Unstructured{
+				newWebApp("app", map[string]string{hostnameAnnotationKey: "c.example.com"}, map[string]interface{}{"host": "a.example.com", "address": "1.2.3.4"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+			},
+		},
+		{
+			title:            "annotation filter and controller",
+			config:           UnstructuredResourceConfig{Hostnames: ".spec.host", Targets: ".spec.address"},
+			annotationFilter: "team=a",
+			objects: []*unstructured.Unstructured{
+				newWebApp("filtered", map[string]string{"team": "b"}, map[string]interface{}{"host": "b.example.com", "address": "1.2.3.4"}),
+				newWebApp("other-controller", map[string]string{"team": "a", controllerAnnotationKey: "other"}, map[string]interface{}{"host": "c.example.com", "address": "1.2.3.4"}),
+				newWebApp("app", map[string]string{"team": "a"}, map[string]interface{}{"host": "a.example.com", "address": "1.2.3.4"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+			},
+		},
+		{
+			title: "CEL expressions",
+			config: UnstructuredResourceConfig{
+				Hostnames: `cel:object.spec.hosts.map(h, h + ".example.com")`,
+				Targets:   "cel:object.spec.addresses",
+				TTL:       "cel:string(object.spec.ttl) + 's'",
+			},
+			objects: []*unstructured.Unstructured{
+				newWebApp("app", nil, map[string]interface{}{
+					"hosts":     []interface{}{"a", "b"},
+					"addresses": []interface{}{"1.2.3.4"},
+					"ttl":       int64(60),
+				}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+				endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeA, 60, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+			},
+		},
+		{
+			title: "CEL hostnames with targets",
+			config: UnstructuredResourceConfig{
+				Hostnames: `cel:{"public.example.com": object.spec.addresses.filter(a, !a.startsWith("10.")), "private.example.com": object.spec.addresses}`,
+				Targets:   ".spec.addresses[*]",
+			},
+			objects: []*unstructured.Unstructured{
+				newWebApp("app", nil, map[string]interface{}{
+					"addresses": []interface{}{"1.2.3.4", "10.0.0.1"},
+				}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("private.example.com", endpoint.RecordTypeA, "1.2.3.4", "10.0.0.1").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+				endpoint.NewEndpoint("public.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "webapp/default/app"),
+			},
+		},
+		{
+			title:  "missing fields",
+			config: UnstructuredResourceConfig{Hostnames: ".spec.host", Targets: ".status.address"},
+			objects: []*unstructured.Unstructured{
+				newWebApp("app", nil, map[string]interface{}{"host": "a.example.com"}),
+			},
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubeClient := fakeKube.NewClientset()
+			kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+				GroupVersion: "example.com/v1",
+				APIResources: []metav1.APIResource{
+					{Name: "webapps", Kind: "WebApp", Namespaced: true},
+					{Name: "webapps/status", Kind: "WebApp", Namespaced: true},
+				},
+			}}
+			dynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				webAppGroupVersionResource: "WebAppList",
+			})
+			for _, obj := range ti.objects {
+				_, err := dynamicClient.Resource(webAppGroupVersionResource).Namespace(obj.GetNamespace()).Create(context.Background(), obj, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			ti.config.APIVersion = "example.com/v1"
+			ti.config.Kind = "WebApp"
+			src, err := NewUnstructuredSource(context.Background(), dynamicClient, kubeClient, "", ti.annotationFilter, labels.Everything(), ti.ignoreHostnameAnnotation,
+				&UnstructuredSourceConfig{Resources: []UnstructuredResourceConfig{ti.config}})
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, ti.expected)
+		})
+	}
+}
+
+func TestNewUnstructuredSourceErrors(t *testing.T) {
+	kubeClient := fakeKube.NewClientset()
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "webapps", Kind: "WebApp", Namespaced: true}},
+	}}
+	dynamicClient := fakeDynamic.NewSimpleDynamicClient(runtime.NewScheme())
+
+	for _, ti := range []struct {
+		title  string
+		config UnstructuredResourceConfig
+		err    string
+	}{
+		{
+			title:  "missing hostnames",
+			config: UnstructuredResourceConfig{APIVersion: "example.com/v1", Kind: "WebApp"},
+			err:    "resources[0]: hostnames must be set",
+		},
+		{
+			title:  "unknown kind",
+			config: UnstructuredResourceConfig{APIVersion: "example.com/v1", Kind: "Unknown", Hostnames: ".spec.host"},
+			err:    `unable to find Resource Kind "Unknown"`,
+		},
+		{
+			title:  "invalid expression",
+			config: UnstructuredResourceConfig{APIVersion: "example.com/v1", Kind: "WebApp", Hostnames: ".spec.host", Targets: "{.spec[}"},
+			err:    "invalid targets expression",
+		},
+		{
+			title:  "invalid CEL expression",
+			config: UnstructuredResourceConfig{APIVersion: "example.com/v1", Kind: "WebApp", Hostnames: "cel:object.spec.hosts +", Targets: ".spec.address"},
+			err:    "invalid hostnames expression",
+		},
+	} {
+		t.Run(ti.title, func(t *testing.T) {
+			_, err := NewUnstructuredSource(context.Background(), dynamicClient, kubeClient, "", "", labels.Everything(), false,
+				&UnstructuredSourceConfig{Resources: []UnstructuredResourceConfig{ti.config}})
+			assert.ErrorContains(t, err, ti.err)
+		})
+	}
+}
+
+func TestLoadUnstructuredSourceConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+resources:
+- apiVersion: example.com/v1
+  kind: WebApp
+  hostnames: .spec.hosts[*]
+  targets: .status.address
+`), 0o600))
+
+	config, err := LoadUnstructuredSourceConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, &UnstructuredSourceConfig{Resources: []UnstructuredResourceConfig{{
+		APIVersion: "example.com/v1",
+		Kind:       "WebApp",
+		Hostnames:  ".spec.hosts[*]",
+		Targets:    ".status.address",
+	}}}, config)
+
+	require.NoError(t, os.WriteFile(path, []byte("resources: []\n"), 0o600))
+	_, err = LoadUnstructuredSourceConfig(path)
+	assert.ErrorContains(t, err, "has no resources")
+
+	require.NoError(t, os.WriteFile(path, []byte("unknown: true\n"), 0o600))
+	_, err = LoadUnstructuredSourceConfig(path)
+	assert.ErrorContains(t, err, "failed to parse")
+
+	_, err = LoadUnstructuredSourceConfig(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read")
+}
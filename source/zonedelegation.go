@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// zoneDelegations enforces the DNSZoneDelegations on the endpoints of DNSEndpoints.
+// It keeps track of the endpoints admitted in each delegation to enforce their quotas.
+type zoneDelegations struct {
+	delegations []*zoneDelegation
+}
+
+type zoneDelegation struct {
+	name         string
+	namespaces   []string
+	domains      *endpoint.DomainFilter
+	recordTypes  []string
+	maxEndpoints int
+	used         int
+}
+
+// newZoneDelegations compiles the given delegations, ordered by name.
+func newZoneDelegations(items []apiv1alpha1.DNSZoneDelegation) *zoneDelegations {
+	zd := &zoneDelegations{}
+	for _, item := range items {
+		domains := make([]string, 0, len(item.Spec.Domains))
+		for _, domain := range item.Spec.Domains {
+			// the domain filter matches only subdomains of domains prefixed with "."
+			domains = append(domains, strings.TrimPrefix(domain, "*"))
+		}
+		recordTypes := make([]string, 0, len(item.Spec.RecordTypes))
+		for _, recordType := range item.Spec.RecordTypes {
+			recordTypes = append(recordTypes, strings.ToUpper(recordType))
+		}
+		zd.delegations = append(zd.delegations, &zoneDelegation{
+			name:         item.Name,
+			namespaces:   item.Spec.Namespaces,
+			domains:      endpoint.NewDomainFilter(domains),
+			recordTypes:  recordTypes,
+			maxEndpoints: item.Spec.MaxEndpoints,
+		})
+	}
+	slices.SortFunc(zd.delegations, func(a, b *zoneDelegation) int { return strings.Compare(a.name, b.name) })
+	return zd
+}
+
+// admit checks that all the endpoints of a DNSEndpoint in the given namespace fall within
+// a delegation of the namespace with enough quota left. The endpoints are only accounted
+// for in the quotas of the delegations if they are all admitted.
+func (zd *zoneDelegations) admit(namespace string, endpoints []*endpoint.Endpoint) error {
+	pending := make(map[*zoneDelegation]int)
+	for _, ep := range endpoints {
+		var (
+			delegated bool
+			found     *zoneDelegation
+		)
+		for _, d := range zd.delegations {
+			if !slices.Contains(d.namespaces, namespace) || !d.domains.Match(ep.DNSName) {
+				continue
+			}
+			delegated = true
+			if len(d.recordTypes) > 0 && !slices.Contains(d.recordTypes, ep.RecordType) {
+				continue
+			}
+			if d.maxEndpoints > 0 && d.used+pending[d] >= d.maxEndpoints {
+				continue
+			}
+			found = d
+			break
+		}
+		if found == nil {
+			if !delegated {
+				return fmt.Errorf("%s is not delegated to namespace %q", ep.DNSName, namespace)
+			}
+			return fmt.Errorf("%s record %s exceeds the record types or quotas delegated to namespace %q", ep.RecordType, ep.DNSName, namespace)
+		}
+		pending[found]++
+	}
+	for d, n := range pending {
+		d.used += n
+	}
+	return nil
+}
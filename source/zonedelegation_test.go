@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/rest/fake"
+
+	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestZoneDelegationsAdmit(t *testing.T) {
+	delegations := []apiv1alpha1.DNSZoneDelegation{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec: apiv1alpha1.DNSZoneDelegationSpec{
+				Namespaces:   []string{"team-a"},
+				Domains:      []string{"*.team-a.example.com"},
+				RecordTypes:  []string{"a", "CNAME"},
+				MaxEndpoints: 2,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+			Spec: apiv1alpha1.DNSZoneDelegationSpec{
+				Namespaces: []string{"team-b", "team-b-staging"},
+				Domains:    []string{"team-b.example.com"},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		namespace string
+		endpoints [][]*endpoint.Endpoint
+		errors    []string
+	}{
+		{
+			name:      "within delegation",
+			namespace: "team-a",
+			endpoints: [][]*endpoint.Endpoint{
+				{endpoint.NewEndpoint("www.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+				{endpoint.NewEndpoint("*.team-a.example.com", endpoint.RecordTypeCNAME, "www.team-a.example.com")},
+			},
+			errors: []string{"", ""},
+		},
+		{
+			name:      "apex not delegated with wildcard",
+			namespace: "team-a",
+			endpoints: [][]*endpoint.Endpoint{
+				{endpoint.NewEndpoint("team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+			},
+			errors: []string{`team-a.example.com is not delegated to namespace "team-a"`},
+		},
+		{
+			name:      "other namespace",
+			namespace: "team-b",
+			endpoints: [][]*endpoint.Endpoint{
+				{endpoint.NewEndpoint("www.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+				{endpoint.NewEndpoint("team-b.example.com", endpoint.RecordTypeTXT, "hello")},
+			},
+			errors: []string{`www.team-a.example.com is not delegated to namespace "team-b"`, ""},
+		},
+		{
+			name:      "record type not allowed",
+			namespace: "team-a",
+			endpoints: [][]*endpoint.Endpoint{
+				{endpoint.NewEndpoint("www.team-a.example.com", endpoint.RecordTypeTXT, "hello")},
+			},
+			errors: []string{`TXT record www.team-a.example.com exceeds the record types or quotas delegated to namespace "team-a"`},
+		},
+		{
+			name:      "quota exceeded",
+			namespace: "team-a",
+			endpoints: [][]*endpoint.Endpoint{
+				{endpoint.NewEndpoint("a.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+				{
+					endpoint.NewEndpoint("b.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+					endpoint.NewEndpoint("c.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				},
+				{endpoint.NewEndpoint("d.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+			},
+			errors: []string{"", `A record c.team-a.example.com exceeds the record types or quotas delegated to namespace "team-a"`, ""},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			zd := newZoneDelegations(delegations)
+			for i, endpoints := range tt.endpoints {
+				err := zd.admit(tt.namespace, endpoints)
+				if tt.errors[i] == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, tt.errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCRDSourceZoneDelegation(t *testing.T) {
+	groupVersion := apiv1alpha1.GroupVersion
+	scheme := runtime.NewScheme()
+	require.NoError(t, addKnownTypes(scheme, groupVersion))
+	codecFactory := serializer.WithoutConversionCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
+	codec := codecFactory.LegacyCodec(groupVersion)
+
+	now := time.Now()
+	dnsEndpoint := func(namespace, name string, created time.Time, endpoints ...*endpoint.Endpoint) apiv1alpha1.DNSEndpoint {
+		return apiv1alpha1.DNSEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created), Generation: 1},
+			Spec:       apiv1alpha1.DNSEndpointSpec{Endpoints: endpoints},
+		}
+	}
+	dnsEndpoints := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{
+		dnsEndpoint("team-a", "new", now, endpoint.NewEndpoint("new.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")),
+		dnsEndpoint("team-a", "old", now.Add(-time.Hour), endpoint.NewEndpoint("old.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4")),
+		dnsEndpoint("team-b", "hijack", now, endpoint.NewEndpoint("www.team-a.example.com", endpoint.RecordTypeA, "5.6.7.8")),
+	}}
+	delegations := &apiv1alpha1.DNSZoneDelegationList{Items: []apiv1alpha1.DNSZoneDelegation{{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec: apiv1alpha1.DNSZoneDelegationSpec{
+			Namespaces:   []string{"team-a"},
+			Domains:      []string{"team-a.example.com"},
+			MaxEndpoints: 1,
+		},
+	}}}
+
+	var statusUpdates []string
+	client := &fake.RESTClient{
+		GroupVersion:         groupVersion,
+		VersionedAPIPath:     "/apis/" + groupVersion.String(),
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			switch req.URL.Path {
+			case "/apis/" + groupVersion.String() + "/dnsendpoints":
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, dnsEndpoints)}, nil
+			case "/apis/" + groupVersion.String() + "/dnszonedelegations":
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, delegations)}, nil
+			default:
+				statusUpdates = append(statusUpdates, req.URL.Path)
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, &apiv1alpha1.DNSEndpoint{})}, nil
+			}
+		}),
+	}
+
+	src, err := NewCRDSource(client, "", "DNSEndpoint", "", labels.Everything(), true, scheme, false)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "old.team-a.example.com", endpoints[0].DNSName)
+	// the generation of the rejected DNSEndpoints is not observed
+	assert.Equal(t, []string{"/apis/" + groupVersion.String() + "/namespaces/team-a/dnsendpoints/old/status"}, statusUpdates)
+}